@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"sync"
 
 	"github.com/gruntwork-io/terragrunt/engine"
 	"github.com/gruntwork-io/terragrunt/internal/os/exec"
@@ -26,6 +27,8 @@ import (
 	"github.com/gruntwork-io/go-commons/version"
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/featureflags"
+	"github.com/gruntwork-io/terragrunt/internal/sensitive"
 	"github.com/gruntwork-io/terragrunt/util"
 	hashicorpversion "github.com/hashicorp/go-version"
 
@@ -341,6 +344,11 @@ func initialSetup(cliCtx *cli.Context, opts *options.TerragruntOptions) error {
 		opts.ExcludeByDefault = true
 	}
 
+	if !opts.ExcludeByDefault && opts.QueueIncludeChangedSince != "" {
+		opts.Logger.Debugf("Units changed since set. Excluding by default.")
+		opts.ExcludeByDefault = true
+	}
+
 	if !opts.ExcludeByDefault && opts.StrictInclude {
 		opts.Logger.Debugf("Strict include set. Excluding by default.")
 		opts.ExcludeByDefault = true
@@ -358,6 +366,17 @@ func initialSetup(cliCtx *cli.Context, opts *options.TerragruntOptions) error {
 
 	opts.ExcludeDirs = append(opts.ExcludeDirs, excludeDirs...)
 
+	// --- Feature Flags
+	if opts.FeatureFlagFile != "" && !filepath.IsAbs(opts.FeatureFlagFile) {
+		opts.FeatureFlagFile = util.JoinPath(opts.WorkingDir, opts.FeatureFlagFile)
+	}
+
+	// The feature flags of the external sources are resolved once, when the first configuration is parsed, and shared
+	// by all the units of the run.
+	opts.LoadFeatureFlags = sync.OnceValue(func() error {
+		return featureflags.Load(cliCtx.Context, opts)
+	})
+
 	// --- Terragrunt Version
 	terragruntVersion, err := hashicorpversion.NewVersion(cliCtx.App.Version)
 	if err != nil {
@@ -383,6 +402,9 @@ func initialSetup(cliCtx *cli.Context, opts *options.TerragruntOptions) error {
 
 	opts.RunTerragrunt = runCmd.Run
 
+	// The sensitive values of a previous run of the same process must not redact the logs of this one.
+	sensitive.Reset()
+
 	exec.PrepareConsole(opts.Logger)
 
 	return nil
@@ -0,0 +1,68 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// RunBootstrap creates or updates the resources backing the remote state of the current unit, or of every unit of
+// the stack, such as buckets and lock tables. With --dry-run, it only reports what it would create or update.
+func RunBootstrap(ctx context.Context, opts *Options) error {
+	backends, err := readUnitBackends(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	errs := &errors.MultiError{}
+
+	for _, unitBackend := range backends {
+		if unitBackend.Error != "" {
+			errs = errs.Append(errors.New(BackendStatusReadError{UnitPath: unitBackend.Unit, Err: unitBackend.Error}))
+			continue
+		}
+
+		if opts.DryRun || len(unitBackend.Actions) == 0 {
+			continue
+		}
+
+		// Running the command is the confirmation that the resources should be created or updated.
+		unitBackend.opts.NonInteractive = true
+		unitBackend.opts.FailIfBucketCreationRequired = false
+
+		if err := unitBackend.remoteState.Initialize(ctx, unitBackend.opts); err != nil {
+			unitBackend.Error = err.Error()
+			errs = errs.Append(err)
+
+			continue
+		}
+
+		unitBackend.Bootstrapped = true
+
+		opts.Logger.Infof("Bootstrapped the backend of unit %s", unitBackend.Unit)
+
+		// Keep the actions that were taken, but report the status after taking them.
+		actions := unitBackend.Actions
+		unitBackend.readStatus(ctx)
+		unitBackend.Actions = actions
+	}
+
+	if opts.Format == FormatJSON {
+		if err := writeJSON(opts, backends); err != nil {
+			return err
+		}
+	} else {
+		pending := "bootstrapped"
+		if opts.DryRun {
+			pending = "would bootstrap"
+		}
+
+		for _, unitBackend := range backends {
+			if err := writeUnitBackend(opts, unitBackend, pending); err != nil {
+				return err
+			}
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,197 @@
+// Package backend provides the `backend` command, which manages the resources backing the remote state of units,
+// such as buckets, lock tables, state and state locks.
+package backend
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/experiment"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+const (
+	CommandName = "backend"
+
+	BootstrapCommandName = "bootstrap"
+	StatusCommandName    = "status"
+	DeleteCommandName    = "delete"
+
+	LockCommandName    = "lock"
+	ListCommandName    = "list"
+	InspectCommandName = "inspect"
+	UnlockCommandName  = "unlock"
+
+	AllFlagName    = "all"
+	FormatFlagName = "format"
+	DryRunFlagName = "dry-run"
+	ForceFlagName  = "force"
+)
+
+func NewCommand(opts *options.TerragruntOptions) *cli.Command {
+	cmdOpts := NewOptions(opts)
+	prefix := flags.Prefix{CommandName}
+
+	return &cli.Command{
+		Name:                 CommandName,
+		Usage:                "Manage the resources backing the remote state of units.",
+		ErrorOnUndefinedFlag: true,
+		Before: func(ctx *cli.Context) error {
+			if !opts.Experiments.Evaluate(experiment.CLIRedesign) {
+				return cli.NewExitError(errors.Errorf("requires that the %[1]s experiment is enabled. e.g. --experiment %[1]s", experiment.CLIRedesign), cli.ExitCodeGeneralError)
+			}
+
+			return nil
+		},
+		Subcommands: cli.Commands{
+			newBootstrapCommand(cmdOpts, prefix),
+			newStatusCommand(cmdOpts, prefix),
+			newDeleteCommand(cmdOpts, prefix),
+			newLockCommand(cmdOpts, prefix),
+		},
+		Action: cli.ShowCommandHelp,
+	}
+}
+
+func newBootstrapCommand(opts *Options, prefix flags.Prefix) *cli.Command {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return &cli.Command{
+		Name:      BootstrapCommandName,
+		Usage:     "Create or update the resources backing the remote state of the current unit, or of every unit of the stack.",
+		UsageText: "terragrunt backend bootstrap [--all] [--dry-run] [--format <text|json>]",
+		Flags: append(append(allFlags(opts, prefix), formatFlags(opts, prefix)...),
+			flags.NewFlag(&cli.BoolFlag{
+				Name:        DryRunFlagName,
+				EnvVars:     tgPrefix.EnvVars(DryRunFlagName),
+				Destination: &opts.DryRun,
+				Usage:       "Only report the resources that would be created or updated.",
+			}),
+		),
+		Before: validateOptions(opts),
+		Action: func(ctx *cli.Context) error {
+			return RunBootstrap(ctx.Context, opts)
+		},
+	}
+}
+
+func newStatusCommand(opts *Options, prefix flags.Prefix) *cli.Command {
+	return &cli.Command{
+		Name:      StatusCommandName,
+		Usage:     "Show whether the resources backing the remote state of the current unit, or of every unit of the stack, are bootstrapped.",
+		UsageText: "terragrunt backend status [--all] [--format <text|json>]",
+		Flags:     append(allFlags(opts, prefix), formatFlags(opts, prefix)...),
+		Before:    validateOptions(opts),
+		Action: func(ctx *cli.Context) error {
+			return RunStatus(ctx.Context, opts)
+		},
+	}
+}
+
+func newDeleteCommand(opts *Options, prefix flags.Prefix) *cli.Command {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return &cli.Command{
+		Name:      DeleteCommandName,
+		Usage:     "Delete the state of the current unit, or of every unit of the stack, from the backend.",
+		UsageText: "terragrunt backend delete [--all] [--force] [--format <text|json>]",
+		Flags: append(append(allFlags(opts, prefix), formatFlags(opts, prefix)...),
+			flags.NewFlag(&cli.BoolFlag{
+				Name:        ForceFlagName,
+				EnvVars:     tgPrefix.EnvVars(ForceFlagName),
+				Destination: &opts.Force,
+				Usage:       "Delete the state even if the backend does not keep previous versions of it.",
+			}),
+		),
+		Before: validateOptions(opts),
+		Action: func(ctx *cli.Context) error {
+			return RunDelete(ctx.Context, opts)
+		},
+	}
+}
+
+func newLockCommand(opts *Options, prefix flags.Prefix) *cli.Command {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+	validate := validateOptions(opts)
+
+	return &cli.Command{
+		Name:  LockCommandName,
+		Usage: "Inspect and release state locks.",
+		Subcommands: cli.Commands{
+			&cli.Command{
+				Name:      ListCommandName,
+				Usage:     "List the state locks of the current unit, or of every unit of the stack.",
+				UsageText: "terragrunt backend lock list [--all] [--format <text|json>]",
+				Flags:     append(allFlags(opts, prefix), formatFlags(opts, prefix)...),
+				Before:    validate,
+				Action: func(ctx *cli.Context) error {
+					return RunLockList(ctx.Context, opts)
+				},
+			},
+			&cli.Command{
+				Name:      InspectCommandName,
+				Usage:     "Show the full details of the state locks held on the current unit, or on any unit of the stack.",
+				UsageText: "terragrunt backend lock inspect [--all] [--format <text|json>]",
+				Flags:     append(allFlags(opts, prefix), formatFlags(opts, prefix)...),
+				Before:    validate,
+				Action: func(ctx *cli.Context) error {
+					return RunLockInspect(ctx.Context, opts)
+				},
+			},
+			&cli.Command{
+				Name:      UnlockCommandName,
+				Usage:     "Forcibly release the state lock of the current unit, or the state locks of the stack.",
+				UsageText: "terragrunt backend lock unlock [--all] [--force] [<lock ID>]",
+				Flags: append(allFlags(opts, prefix),
+					flags.NewFlag(&cli.BoolFlag{
+						Name:        ForceFlagName,
+						EnvVars:     tgPrefix.EnvVars(ForceFlagName),
+						Destination: &opts.Force,
+						Usage:       "Release the state locks without confirmation when running non-interactively.",
+					}),
+				),
+				Action: func(ctx *cli.Context) error {
+					return RunLockUnlock(ctx.Context, opts, ctx.Args().First())
+				},
+			},
+		},
+		Action: cli.ShowCommandHelp,
+	}
+}
+
+func validateOptions(opts *Options) cli.ActionFunc {
+	return func(ctx *cli.Context) error {
+		if err := opts.Validate(); err != nil {
+			return cli.NewExitError(err, cli.ExitCodeGeneralError)
+		}
+
+		return nil
+	}
+}
+
+func allFlags(opts *Options, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        AllFlagName,
+			EnvVars:     tgPrefix.EnvVars(AllFlagName),
+			Destination: &opts.All,
+			Usage:       "Operate on every unit of the stack in the working dir.",
+		}),
+	}
+}
+
+func formatFlags(opts *Options, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FormatFlagName,
+			EnvVars:     tgPrefix.EnvVars(FormatFlagName),
+			Destination: &opts.Format,
+			Usage:       "Output format. Valid values: text, json.",
+			DefaultText: FormatText,
+		}),
+	}
+}
@@ -0,0 +1,82 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/shell"
+)
+
+// RunDelete deletes the state of the current unit, or of every unit of the stack, from the backend. The resources
+// backing the state are kept. Unless --force is set, state is only deleted from backends that keep previous versions
+// of it. Every deletion has to be confirmed, unless running non-interactively.
+func RunDelete(ctx context.Context, opts *Options) error {
+	backends, err := readUnitBackends(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	deleted := 0
+	errs := &errors.MultiError{}
+
+	for _, unitBackend := range backends {
+		if unitBackend.Error != "" {
+			errs = errs.Append(errors.New(BackendStatusReadError{UnitPath: unitBackend.Unit, Err: unitBackend.Error}))
+			continue
+		}
+
+		status := unitBackend.Status
+
+		if !status.StateExists {
+			opts.Logger.Debugf("Unit %s has no state at %s, skipping", unitBackend.Unit, status.StatePath)
+			continue
+		}
+
+		if !status.Versioning && !opts.Force {
+			err := errors.New(StateNotVersionedError{UnitPath: unitBackend.Unit, StatePath: status.StatePath})
+			unitBackend.Error = err.Error()
+			errs = errs.Append(err)
+
+			continue
+		}
+
+		prompt := fmt.Sprintf("Delete the state of unit %s at %s?", unitBackend.Unit, status.StatePath)
+		if !status.Versioning {
+			prompt = fmt.Sprintf("Delete the state of unit %s at %s? Previous versions of the state are not kept, so this can not be undone.", unitBackend.Unit, status.StatePath)
+		}
+
+		shouldDelete, err := shell.PromptUserForYesNo(ctx, prompt, opts.TerragruntOptions)
+		if err != nil {
+			return err
+		}
+
+		if !shouldDelete {
+			continue
+		}
+
+		if err := unitBackend.remoteState.DeleteState(ctx, unitBackend.opts); err != nil {
+			unitBackend.Error = err.Error()
+			errs = errs.Append(err)
+
+			continue
+		}
+
+		unitBackend.Deleted = true
+		status.StateExists = false
+
+		opts.Logger.Infof("Deleted the state of unit %s at %s", unitBackend.Unit, status.StatePath)
+
+		deleted++
+	}
+
+	if opts.Format == FormatJSON {
+		if err := writeJSON(opts, backends); err != nil {
+			return err
+		}
+	} else if deleted == 0 && errs.ErrorOrNil() == nil {
+		opts.Logger.Infof("No state to delete")
+	}
+
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,52 @@
+package backend
+
+import (
+	"fmt"
+)
+
+// Custom error types
+
+type UnitNotFoundError string
+
+func (path UnitNotFoundError) Error() string {
+	return fmt.Sprintf("No Terragrunt configuration found in %s, use --%s to operate on the units of a stack", string(path), AllFlagName)
+}
+
+type LockNotFoundError string
+
+func (lockID LockNotFoundError) Error() string {
+	return fmt.Sprintf("No state lock with ID %s is held", string(lockID))
+}
+
+type LockReadError struct {
+	UnitPath string
+	Err      string
+}
+
+func (err LockReadError) Error() string {
+	return fmt.Sprintf("Unable to read the state lock of unit %s: %s", err.UnitPath, err.Err)
+}
+
+type BackendStatusReadError struct {
+	UnitPath string
+	Err      string
+}
+
+func (err BackendStatusReadError) Error() string {
+	return fmt.Sprintf("Unable to read the backend status of unit %s: %s", err.UnitPath, err.Err)
+}
+
+type StateNotVersionedError struct {
+	UnitPath  string
+	StatePath string
+}
+
+func (err StateNotVersionedError) Error() string {
+	return fmt.Sprintf("The backend of unit %s does not keep previous versions of the state at %s, so deleting it can not be undone. Use --%s to delete it anyway", err.UnitPath, err.StatePath, ForceFlagName)
+}
+
+type UnlockRequiresForceError struct{}
+
+func (err UnlockRequiresForceError) Error() string {
+	return fmt.Sprintf("Releasing state locks without confirmation requires --%s when running non-interactively", ForceFlagName)
+}
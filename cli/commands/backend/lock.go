@@ -0,0 +1,196 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/remote"
+	"github.com/gruntwork-io/terragrunt/shell"
+)
+
+// UnitLock is the state lock of a unit, as reported by the `backend lock` commands.
+type UnitLock struct {
+	Lock *remote.LockInfo `json:"lock,omitempty"`
+
+	// Unit is the path of the unit, relative to the working dir.
+	Unit    string `json:"unit"`
+	Backend string `json:"backend"`
+
+	// Error is set if the lock could not be read, e.g. because the backend does not support inspecting locks.
+	Error string `json:"error,omitempty"`
+
+	Locked bool `json:"locked"`
+
+	remoteState *remote.RemoteState
+	opts        *options.TerragruntOptions
+}
+
+// RunLockList prints the lock status of the current unit, or of every unit of the stack.
+func RunLockList(ctx context.Context, opts *Options) error {
+	locks, err := readUnitLocks(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.Format == FormatJSON {
+		return writeJSON(opts, locks)
+	}
+
+	for _, unitLock := range locks {
+		var status string
+
+		switch {
+		case unitLock.Error != "":
+			status = "unknown: " + unitLock.Error
+		case unitLock.Locked:
+			status = fmt.Sprintf("locked by %s for %s (ID %s, operation %s)", unitLock.Lock.Who, lockAge(unitLock.Lock), unitLock.Lock.ID, unitLock.Lock.Operation)
+		default:
+			status = "unlocked"
+		}
+
+		if _, err := fmt.Fprintf(opts.Writer, "%s\t%s\t%s\n", unitLock.Unit, unitLock.Backend, status); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
+
+// RunLockInspect prints all the details of the locks held on the current unit, or on any unit of the stack.
+func RunLockInspect(ctx context.Context, opts *Options) error {
+	locks, err := readUnitLocks(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	heldLocks := make([]*UnitLock, 0, len(locks))
+
+	for _, unitLock := range locks {
+		if unitLock.Locked {
+			heldLocks = append(heldLocks, unitLock)
+		}
+	}
+
+	if opts.Format == FormatJSON {
+		return writeJSON(opts, heldLocks)
+	}
+
+	if len(heldLocks) == 0 {
+		opts.Logger.Infof("No state locks are held")
+		return nil
+	}
+
+	for _, unitLock := range heldLocks {
+		lock := unitLock.Lock
+
+		if _, err := fmt.Fprintf(opts.Writer, "Unit:      %s\nBackend:   %s\nID:        %s\nPath:      %s\nOperation: %s\nWho:       %s\nVersion:   %s\nCreated:   %s (%s ago)\nInfo:      %s\n\n",
+			unitLock.Unit, unitLock.Backend, lock.ID, lock.Path, lock.Operation, lock.Who, lock.Version, lock.Created.Format(time.RFC3339), lockAge(lock), lock.Info); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
+
+// RunLockUnlock forcibly releases the lock held on the current unit, or the locks held on the units of the stack. If a
+// lock ID is given, only the lock with that ID is released. Every release has to be confirmed, running
+// non-interactively requires --force instead. A lock is only released if it is still the one that was read, so a lock
+// taken again in the meantime is never released.
+func RunLockUnlock(ctx context.Context, opts *Options, lockID string) error {
+	if opts.NonInteractive && !opts.Force {
+		return errors.New(UnlockRequiresForceError{})
+	}
+
+	locks, err := readUnitLocks(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	released := 0
+	errs := &errors.MultiError{}
+
+	for _, unitLock := range locks {
+		if unitLock.Error != "" {
+			errs = errs.Append(errors.New(LockReadError{UnitPath: unitLock.Unit, Err: unitLock.Error}))
+			continue
+		}
+
+		if !unitLock.Locked || (lockID != "" && unitLock.Lock.ID != lockID) {
+			continue
+		}
+
+		if !unitLock.remoteState.CanReleaseLock() {
+			errs = errs.Append(errors.New(remote.LockReleaseNotSupportedError(unitLock.Backend)))
+			continue
+		}
+
+		prompt := fmt.Sprintf("Release the state lock of unit %s, %s?", unitLock.Unit, unitLock.Lock)
+
+		shouldRelease, err := shell.PromptUserForYesNo(ctx, prompt, opts.TerragruntOptions)
+		if err != nil {
+			return err
+		}
+
+		if !shouldRelease {
+			continue
+		}
+
+		if err := unitLock.remoteState.ReleaseLock(ctx, unitLock.Lock, unitLock.opts); err != nil {
+			errs = errs.Append(err)
+			continue
+		}
+
+		opts.Logger.Infof("Released the state lock %s of unit %s", unitLock.Lock.ID, unitLock.Unit)
+
+		released++
+	}
+
+	if released == 0 && errs.ErrorOrNil() == nil {
+		if lockID != "" {
+			return errors.New(LockNotFoundError(lockID))
+		}
+
+		opts.Logger.Infof("No state locks to release")
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// readUnitLocks reads the lock of the current unit, or of every unit of the stack. Units without a remote_state
+// block are left out.
+func readUnitLocks(ctx context.Context, opts *Options) ([]*UnitLock, error) {
+	units, err := readUnits(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	locks := make([]*UnitLock, 0, len(units))
+
+	for _, unit := range units {
+		unitLock := &UnitLock{
+			Unit:        unit.displayPath,
+			Backend:     unit.remoteState.Backend,
+			remoteState: unit.remoteState,
+			opts:        unit.opts,
+		}
+
+		if lock, err := unit.remoteState.ReadLock(ctx, unit.opts); err != nil {
+			unitLock.Error = err.Error()
+		} else if lock != nil {
+			unitLock.Lock = lock
+			unitLock.Locked = true
+		}
+
+		locks = append(locks, unitLock)
+	}
+
+	return locks, nil
+}
+
+// lockAge returns for how long the given lock has been held, rounded to the second.
+func lockAge(lock *remote.LockInfo) time.Duration {
+	return time.Since(lock.Created).Round(time.Second)
+}
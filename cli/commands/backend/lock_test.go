@@ -0,0 +1,144 @@
+package backend_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/backend"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLockInfo = `{"ID":"3f1b2c4d-0000-1111-2222-333344445555","Operation":"OperationTypeApply","Info":"","Who":"jane@laptop","Version":"1.9.0","Created":"2025-01-02T03:04:05Z","Path":"terraform.tfstate"}`
+
+const testLocalRemoteStateConfig = `
+remote_state {
+  backend = "local"
+  config = {
+    path = "terraform.tfstate"
+  }
+}
+`
+
+// createTestStack creates a stack with a locked unit, an unlocked unit and a unit without remote state.
+func createTestStack(t *testing.T) string {
+	t.Helper()
+
+	stackDir := t.TempDir()
+
+	for _, unit := range []string{"locked", "unlocked"} {
+		unitDir := filepath.Join(stackDir, unit)
+		require.NoError(t, os.MkdirAll(unitDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(unitDir, "terragrunt.hcl"), []byte(testLocalRemoteStateConfig), 0644))
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(stackDir, "locked", ".terraform.tfstate.lock.info"), []byte(testLockInfo), 0644))
+
+	noStateDir := filepath.Join(stackDir, "no-state")
+	require.NoError(t, os.MkdirAll(noStateDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(noStateDir, "terragrunt.hcl"), []byte(""), 0644))
+
+	return stackDir
+}
+
+func newTestOptions(t *testing.T, workingDir string) (*backend.Options, *bytes.Buffer) {
+	t.Helper()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	terragruntOptions.WorkingDir = workingDir
+	terragruntOptions.RootWorkingDir = workingDir
+
+	stdout := &bytes.Buffer{}
+	terragruntOptions.Writer = stdout
+
+	return backend.NewOptions(terragruntOptions), stdout
+}
+
+func TestLockListAll(t *testing.T) {
+	t.Parallel()
+
+	opts, stdout := newTestOptions(t, createTestStack(t))
+	opts.All = true
+	opts.Format = backend.FormatJSON
+
+	require.NoError(t, backend.RunLockList(context.Background(), opts))
+
+	var locks []*backend.UnitLock
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &locks))
+	require.Len(t, locks, 2)
+
+	assert.Equal(t, "locked", locks[0].Unit)
+	assert.Equal(t, "local", locks[0].Backend)
+	assert.True(t, locks[0].Locked)
+	assert.Equal(t, "3f1b2c4d-0000-1111-2222-333344445555", locks[0].Lock.ID)
+
+	assert.Equal(t, "unlocked", locks[1].Unit)
+	assert.False(t, locks[1].Locked)
+	assert.Nil(t, locks[1].Lock)
+}
+
+func TestLockInspectCurrentUnit(t *testing.T) {
+	t.Parallel()
+
+	stackDir := createTestStack(t)
+
+	opts, stdout := newTestOptions(t, filepath.Join(stackDir, "locked"))
+
+	require.NoError(t, backend.RunLockInspect(context.Background(), opts))
+	assert.Contains(t, stdout.String(), "ID:        3f1b2c4d-0000-1111-2222-333344445555")
+	assert.Contains(t, stdout.String(), "Who:       jane@laptop")
+
+	opts, stdout = newTestOptions(t, filepath.Join(stackDir, "unlocked"))
+	opts.Format = backend.FormatJSON
+
+	require.NoError(t, backend.RunLockInspect(context.Background(), opts))
+	assert.JSONEq(t, "[]", stdout.String())
+}
+
+func TestLockUnlock(t *testing.T) {
+	t.Parallel()
+
+	stackDir := createTestStack(t)
+
+	opts, _ := newTestOptions(t, stackDir)
+	opts.All = true
+	opts.NonInteractive = true
+
+	err := backend.RunLockUnlock(context.Background(), opts, "unknown-id")
+
+	var forceErr backend.UnlockRequiresForceError
+	require.True(t, errors.As(err, &forceErr), "unexpected error: %v", err)
+
+	opts.Force = true
+
+	err = backend.RunLockUnlock(context.Background(), opts, "unknown-id")
+
+	var notFoundErr backend.LockNotFoundError
+	require.True(t, errors.As(err, &notFoundErr), "unexpected error: %v", err)
+
+	// The local backend relies on OS file locks, which can't be released from another process.
+	err = backend.RunLockUnlock(context.Background(), opts, "3f1b2c4d-0000-1111-2222-333344445555")
+
+	var notSupportedErr remote.LockReleaseNotSupportedError
+	require.True(t, errors.As(err, &notSupportedErr), "unexpected error: %v", err)
+}
+
+func TestLockUnitNotFound(t *testing.T) {
+	t.Parallel()
+
+	opts, _ := newTestOptions(t, t.TempDir())
+
+	err := backend.RunLockList(context.Background(), opts)
+
+	var notFoundErr backend.UnitNotFoundError
+	require.True(t, errors.As(err, &notFoundErr), "unexpected error: %v", err)
+}
@@ -0,0 +1,47 @@
+package backend
+
+import (
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+const (
+	// FormatText outputs the results in a human-readable format.
+	FormatText = "text"
+
+	// FormatJSON outputs the results in JSON format.
+	FormatJSON = "json"
+)
+
+type Options struct {
+	*options.TerragruntOptions
+
+	// Format determines the format of the output.
+	Format string
+
+	// All makes the command operate on every unit of the stack in the working dir, rather than on the current unit.
+	All bool
+
+	// DryRun makes `backend bootstrap` only report what it would create or update.
+	DryRun bool
+
+	// Force makes `backend delete` delete state from backends that don't keep previous versions of it, and lets
+	// `backend lock unlock` release locks without confirmation when running non-interactively.
+	Force bool
+}
+
+func NewOptions(opts *options.TerragruntOptions) *Options {
+	return &Options{
+		TerragruntOptions: opts,
+		Format:            FormatText,
+	}
+}
+
+func (o *Options) Validate() error {
+	switch o.Format {
+	case FormatText, FormatJSON:
+		return nil
+	default:
+		return errors.New("invalid format: " + o.Format)
+	}
+}
@@ -0,0 +1,127 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/remote"
+)
+
+// UnitBackend is the status of the backend of a unit, as reported by the `backend bootstrap`, `backend status` and
+// `backend delete` commands.
+type UnitBackend struct {
+	Status *remote.BackendStatus `json:"status,omitempty"`
+
+	// Unit is the path of the unit, relative to the working dir.
+	Unit    string `json:"unit"`
+	Backend string `json:"backend"`
+
+	// Error is set if the status could not be read, or if the command failed for this unit.
+	Error string `json:"error,omitempty"`
+
+	// Actions are the resources that bootstrapping the backend creates or updates.
+	Actions []string `json:"actions,omitempty"`
+
+	// Bootstrapped is set by `backend bootstrap` if the resources were created or updated.
+	Bootstrapped bool `json:"bootstrapped,omitempty"`
+
+	// Deleted is set by `backend delete` if the state was deleted.
+	Deleted bool `json:"deleted,omitempty"`
+
+	remoteState *remote.RemoteState
+	opts        *options.TerragruntOptions
+}
+
+// RunStatus prints the status of the backend of the current unit, or of every unit of the stack.
+func RunStatus(ctx context.Context, opts *Options) error {
+	backends, err := readUnitBackends(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.Format == FormatJSON {
+		return writeJSON(opts, backends)
+	}
+
+	for _, unitBackend := range backends {
+		if err := writeUnitBackend(opts, unitBackend, "needs bootstrap"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readUnitBackends reads the backend status of the current unit, or of every unit of the stack. Units without a
+// remote_state block are left out.
+func readUnitBackends(ctx context.Context, opts *Options) ([]*UnitBackend, error) {
+	units, err := readUnits(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	backends := make([]*UnitBackend, 0, len(units))
+
+	for _, unit := range units {
+		unitBackend := &UnitBackend{
+			Unit:        unit.displayPath,
+			Backend:     unit.remoteState.Backend,
+			remoteState: unit.remoteState,
+			opts:        unit.opts,
+		}
+
+		unitBackend.readStatus(ctx)
+
+		backends = append(backends, unitBackend)
+	}
+
+	return backends, nil
+}
+
+// readStatus reads the status of the backend and the actions bootstrapping it takes. Errors are recorded on the unit,
+// so that the other units can still be reported.
+func (unitBackend *UnitBackend) readStatus(ctx context.Context) {
+	status, err := unitBackend.remoteState.GetStatus(ctx, unitBackend.opts)
+	if err != nil {
+		unitBackend.Error = err.Error()
+		return
+	}
+
+	unitBackend.Status = status
+	unitBackend.Actions = status.Actions()
+}
+
+// writeUnitBackend writes a tab-separated line with the status of the backend of the unit, followed by the actions
+// bootstrapping it takes, described as pending.
+func writeUnitBackend(opts *Options, unitBackend *UnitBackend, pending string) error {
+	var line string
+
+	switch {
+	case unitBackend.Error != "":
+		line = fmt.Sprintf("%s\t%s\terror: %s", unitBackend.Unit, unitBackend.Backend, unitBackend.Error)
+	default:
+		status := "ready"
+		if len(unitBackend.Actions) > 0 {
+			status = pending
+		}
+
+		state := "no state"
+		if unitBackend.Status.StateExists {
+			state = "state exists"
+		}
+
+		line = fmt.Sprintf("%s\t%s\t%s\t%s\t%s", unitBackend.Unit, unitBackend.Backend, status, unitBackend.Status.StatePath, state)
+
+		for _, action := range unitBackend.Actions {
+			line += "\n  " + action
+		}
+	}
+
+	if _, err := fmt.Fprintln(opts.Writer, line); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package backend_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/backend"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLocalStateDirRemoteStateConfig = `
+remote_state {
+  backend = "local"
+  config = {
+    path = "state/terraform.tfstate"
+  }
+}
+`
+
+// createTestBackendStack creates a stack with a unit whose state dir is missing, a unit with state and a unit without
+// remote state.
+func createTestBackendStack(t *testing.T) string {
+	t.Helper()
+
+	stackDir := t.TempDir()
+
+	for _, unit := range []string{"bootstrapped", "new"} {
+		unitDir := filepath.Join(stackDir, unit)
+		require.NoError(t, os.MkdirAll(unitDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(unitDir, "terragrunt.hcl"), []byte(testLocalStateDirRemoteStateConfig), 0644))
+	}
+
+	require.NoError(t, os.MkdirAll(filepath.Join(stackDir, "bootstrapped", "state"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(stackDir, "bootstrapped", "state", "terraform.tfstate"), []byte(`{"version":4}`), 0644))
+
+	noStateDir := filepath.Join(stackDir, "no-state")
+	require.NoError(t, os.MkdirAll(noStateDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(noStateDir, "terragrunt.hcl"), []byte(""), 0644))
+
+	return stackDir
+}
+
+func readUnitBackends(t *testing.T, data []byte) []*backend.UnitBackend {
+	t.Helper()
+
+	var backends []*backend.UnitBackend
+	require.NoError(t, json.Unmarshal(data, &backends))
+
+	return backends
+}
+
+func TestStatusAll(t *testing.T) {
+	t.Parallel()
+
+	stackDir := createTestBackendStack(t)
+
+	opts, stdout := newTestOptions(t, stackDir)
+	opts.All = true
+	opts.Format = backend.FormatJSON
+
+	require.NoError(t, backend.RunStatus(context.Background(), opts))
+
+	backends := readUnitBackends(t, stdout.Bytes())
+	require.Len(t, backends, 2)
+
+	assert.Equal(t, "bootstrapped", backends[0].Unit)
+	assert.Equal(t, "local", backends[0].Backend)
+	assert.True(t, backends[0].Status.StateExists)
+	assert.Empty(t, backends[0].Actions)
+
+	assert.Equal(t, "new", backends[1].Unit)
+	assert.False(t, backends[1].Status.StateExists)
+	assert.Equal(t, []string{"create dir " + filepath.Join(stackDir, "new", "state")}, backends[1].Actions)
+}
+
+func TestBootstrap(t *testing.T) {
+	t.Parallel()
+
+	stackDir := createTestBackendStack(t)
+	stateDir := filepath.Join(stackDir, "new", "state")
+
+	opts, stdout := newTestOptions(t, stackDir)
+	opts.All = true
+	opts.DryRun = true
+
+	require.NoError(t, backend.RunBootstrap(context.Background(), opts))
+	assert.Contains(t, stdout.String(), "new\tlocal\twould bootstrap")
+	assert.Contains(t, stdout.String(), "  create dir "+stateDir)
+	assert.NoDirExists(t, stateDir)
+
+	opts, stdout = newTestOptions(t, stackDir)
+	opts.All = true
+	opts.Format = backend.FormatJSON
+
+	require.NoError(t, backend.RunBootstrap(context.Background(), opts))
+	assert.DirExists(t, stateDir)
+
+	backends := readUnitBackends(t, stdout.Bytes())
+	require.Len(t, backends, 2)
+	assert.False(t, backends[0].Bootstrapped)
+	assert.True(t, backends[1].Bootstrapped)
+	assert.True(t, backends[1].Status.Resources[0].Exists)
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	stackDir := createTestBackendStack(t)
+	statePath := filepath.Join(stackDir, "bootstrapped", "state", "terraform.tfstate")
+
+	opts, _ := newTestOptions(t, filepath.Join(stackDir, "bootstrapped"))
+	opts.NonInteractive = true
+
+	// The local backend doesn't keep previous versions of the state.
+	err := backend.RunDelete(context.Background(), opts)
+
+	var notVersionedErr backend.StateNotVersionedError
+	require.True(t, errors.As(err, &notVersionedErr), "unexpected error: %v", err)
+	assert.FileExists(t, statePath)
+
+	opts, stdout := newTestOptions(t, filepath.Join(stackDir, "bootstrapped"))
+	opts.NonInteractive = true
+	opts.Force = true
+	opts.Format = backend.FormatJSON
+
+	require.NoError(t, backend.RunDelete(context.Background(), opts))
+	assert.NoFileExists(t, statePath)
+
+	backends := readUnitBackends(t, stdout.Bytes())
+	require.Len(t, backends, 1)
+	assert.True(t, backends[0].Deleted)
+}
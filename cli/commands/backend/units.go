@@ -0,0 +1,119 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/discovery"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/remote"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// unit is a unit with a remote_state block, along with the options to operate on its backend.
+type unit struct {
+	remoteState *remote.RemoteState
+	opts        *options.TerragruntOptions
+
+	// displayPath is the path of the unit, relative to the working dir.
+	displayPath string
+}
+
+// readUnits reads the remote state configuration of the current unit, or of every unit of the stack. Units without a
+// remote_state block are left out.
+func readUnits(ctx context.Context, opts *Options) ([]*unit, error) {
+	unitPaths, err := findUnits(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	units := make([]*unit, 0, len(unitPaths))
+
+	for _, unitPath := range unitPaths {
+		unitOpts, err := opts.CloneWithConfigPath(config.GetDefaultConfigPath(unitPath))
+		if err != nil {
+			return nil, err
+		}
+
+		remoteState, err := readRemoteState(ctx, unitOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		if remoteState == nil {
+			opts.Logger.Debugf("Unit %s has no remote_state block, skipping", unitPath)
+			continue
+		}
+
+		units = append(units, &unit{
+			remoteState: remoteState,
+			opts:        unitOpts,
+			displayPath: unitDisplayPath(opts, unitPath),
+		})
+	}
+
+	return units, nil
+}
+
+// findUnits returns the dirs of every unit of the stack in the working dir if --all is set, or the dir of the
+// current unit otherwise.
+func findUnits(ctx context.Context, opts *Options) ([]string, error) {
+	if !opts.All {
+		if !util.FileExists(opts.TerragruntConfigPath) {
+			return nil, errors.New(UnitNotFoundError(opts.WorkingDir))
+		}
+
+		return []string{filepath.Dir(opts.TerragruntConfigPath)}, nil
+	}
+
+	cfgs, err := discovery.NewDiscovery(opts.WorkingDir).Discover(ctx, opts.TerragruntOptions)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	unitPaths := []string{}
+
+	for _, cfg := range cfgs.Sort() {
+		if cfg.Type == discovery.ConfigTypeUnit {
+			unitPaths = append(unitPaths, cfg.Path)
+		}
+	}
+
+	return unitPaths, nil
+}
+
+// readRemoteState partially parses the config of the unit to get its remote state configuration.
+func readRemoteState(ctx context.Context, opts *options.TerragruntOptions) (*remote.RemoteState, error) {
+	parsingCtx := config.NewParsingContext(ctx, opts).WithDecodeList(config.RemoteStateBlock, config.TerragruntFlags)
+
+	cfg, err := config.PartialParseConfigFile(parsingCtx, opts.TerragruntConfigPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg.RemoteState, nil
+}
+
+func writeJSON(opts *Options, value any) error {
+	jsonBytes, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if _, err := opts.Writer.Write(append(jsonBytes, '\n')); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+func unitDisplayPath(opts *Options, unitPath string) string {
+	if relPath, err := filepath.Rel(opts.WorkingDir, unitPath); err == nil {
+		return relPath
+	}
+
+	return unitPath
+}
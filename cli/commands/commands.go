@@ -2,6 +2,8 @@
 package commands
 
 import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/backend"
+	"github.com/gruntwork-io/terragrunt/cli/commands/drift"
 	"github.com/gruntwork-io/terragrunt/cli/commands/find"
 	"github.com/gruntwork-io/terragrunt/cli/commands/info"
 	"github.com/gruntwork-io/terragrunt/cli/commands/list"
@@ -52,6 +54,8 @@ func New(opts *options.TerragruntOptions) cli.Commands {
 		stack.NewCommand(opts),   // stack
 		graph.NewCommand(opts),   // graph
 		execCmd.NewCommand(opts), // exec
+		backend.NewCommand(opts), // backend
+		drift.NewCommand(opts),   // drift
 	}.SetCategory(
 		&cli.Category{
 			Name:  MainCommandsCategoryName,
@@ -2,13 +2,17 @@ package runall
 
 import (
 	"context"
+	"os"
 
+	"github.com/gruntwork-io/terragrunt/cli/commands/common/runall/tui"
 	"github.com/gruntwork-io/terragrunt/configstack"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/shell"
 	"github.com/gruntwork-io/terragrunt/telemetry"
 	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+	"golang.org/x/term"
 )
 
 // Known terraform commands that are explicitly not supported in run-all due to the nature of the command. This is
@@ -53,6 +57,11 @@ func Run(ctx context.Context, opts *options.TerragruntOptions) error {
 func RunAllOnStack(ctx context.Context, opts *options.TerragruntOptions, stack *configstack.Stack) error {
 	opts.Logger.Debugf("%s", stack.String())
 
+	// Persist the completion state of the units, so that the run can be resumed with --resume if it fails.
+	if shouldPersistRunState(opts) {
+		stack = stack.WithOptions(configstack.WithRunState())
+	}
+
 	if err := stack.LogModuleDeployOrder(opts.Logger, opts.TerraformCommand); err != nil {
 		return err
 	}
@@ -83,6 +92,77 @@ func RunAllOnStack(ctx context.Context, opts *options.TerragruntOptions, stack *
 		"terraform_command": opts.TerraformCommand,
 		"working_dir":       opts.WorkingDir,
 	}, func(childCtx context.Context) error {
-		return stack.Run(ctx, opts)
+		eventStream, err := configstack.OpenEventStream(opts)
+		if err != nil {
+			return err
+		}
+
+		runner := stack.Runner(opts)
+
+		showTUI := opts.TUI && term.IsTerminal(int(os.Stdout.Fd()))
+		if opts.TUI && !showTUI {
+			opts.Logger.Warnf("Not showing the terminal UI, the standard output is not a terminal")
+		}
+
+		if eventStream == nil && !showTUI {
+			if err := runner.Start(ctx); err != nil {
+				return err
+			}
+
+			return runner.Wait()
+		}
+
+		events := runner.Events()
+
+		if eventStream != nil {
+			defer eventStream.Close() //nolint:errcheck
+
+			events = writeEvents(opts, eventStream, events)
+		}
+
+		if showTUI {
+			return tui.Run(ctx, opts, stack, runner, events)
+		}
+
+		if err := runner.Start(ctx); err != nil {
+			return err
+		}
+
+		for range events { //nolint:revive
+		}
+
+		return runner.Wait()
 	})
 }
+
+// writeEvents writes the events of the units to the event stream, and forwards them to the returned channel, which
+// is closed once the run finished. The events of a failed stream are dropped, so that the run is not held back by its
+// consumer.
+func writeEvents(opts *options.TerragruntOptions, eventStream *configstack.EventStream, events <-chan configstack.UnitEvent) <-chan configstack.UnitEvent {
+	written := make(chan configstack.UnitEvent)
+
+	go func() {
+		defer close(written)
+
+		var streamErr error
+
+		for event := range events {
+			if streamErr == nil {
+				if streamErr = eventStream.Write(event); streamErr != nil {
+					opts.Logger.Warnf("Failed to write the events of the units, no more events are written: %v", streamErr)
+				}
+			}
+
+			written <- event
+		}
+	}()
+
+	return written
+}
+
+// shouldPersistRunState returns true if the completion state of the units has to be persisted: when resuming a run,
+// or when the command can change the state of the units, so that a failed run can be resumed. Read-only commands, such
+// as `plan`, are cheap to run again from scratch.
+func shouldPersistRunState(opts *options.TerragruntOptions) bool {
+	return opts.Resume || util.ListContainsElement(tf.CommandNamesModifyingState, opts.TerraformCommand)
+}
@@ -0,0 +1,136 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+)
+
+// listKeyMap is the set of keybindings for the list of units. It satisfies the help.KeyMap interface, which is used
+// to render the menu.
+type listKeyMap struct {
+	// Browsing.
+	Up   key.Binding
+	Down key.Binding
+
+	// View the output of the selected unit.
+	Choose key.Binding
+
+	// Cancel the run, the running units are interrupted.
+	Cancel key.Binding
+
+	// Quit once the run finished.
+	Quit key.Binding
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view. It's part
+// of the key.Map interface.
+func (keys listKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		keys.Up,
+		keys.Down,
+		keys.Choose,
+		keys.Cancel,
+		keys.Quit,
+	}
+}
+
+// FullHelp returns keybindings for the expanded help view. It's part of the
+// key.Map interface.
+func (keys listKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{keys.ShortHelp()}
+}
+
+// newListKeyMap returns a set of keybindings for the list of units.
+func newListKeyMap() listKeyMap {
+	return listKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("k", "up", "ctrl+p"),
+			key.WithHelp("k/↑", "move up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("j", "down", "ctrl+n"),
+			key.WithHelp("j/↓", "move down"),
+		),
+		Choose: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "view output"),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("ctrl+c"),
+			key.WithHelp("ctrl+c", "cancel run"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "esc"),
+			key.WithHelp("q", "quit"),
+		),
+	}
+}
+
+// pagerKeyMap is the set of keybindings for the output of a unit. It satisfies the help.KeyMap interface, which is
+// used to render the menu.
+type pagerKeyMap struct {
+	viewport.KeyMap
+
+	// Cancel the run, the running units are interrupted.
+	Cancel key.Binding
+
+	// Go back to the list of units.
+	Back key.Binding
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view. It's part
+// of the key.Map interface.
+func (keys pagerKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		keys.Up,
+		keys.Down,
+		keys.PageUp,
+		keys.PageDown,
+		keys.Cancel,
+		keys.Back,
+	}
+}
+
+// FullHelp returns keybindings for the expanded help view. It's part of the
+// key.Map interface.
+func (keys pagerKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{keys.ShortHelp()}
+}
+
+// newPagerKeyMap returns a set of keybindings for the output of a unit.
+func newPagerKeyMap() pagerKeyMap {
+	return pagerKeyMap{
+		KeyMap: viewport.KeyMap{
+			HalfPageUp: key.NewBinding(
+				key.WithDisabled(),
+			),
+			HalfPageDown: key.NewBinding(
+				key.WithDisabled(),
+			),
+			Up: key.NewBinding(
+				key.WithKeys("k", "up", "ctrl+p"),
+				key.WithHelp("k/↑", "scroll up"),
+			),
+			Down: key.NewBinding(
+				key.WithKeys("j", "down", "ctrl+n"),
+				key.WithHelp("j/↓", "scroll down"),
+			),
+			PageDown: key.NewBinding(
+				key.WithKeys("l", "right", "pgdown", "ctrl+v"),
+				key.WithHelp("l/→/pgdn", "page down"),
+			),
+			PageUp: key.NewBinding(
+				key.WithKeys("h", "left", "pgup", "alt+v"),
+				key.WithHelp("h/←/pgup", "page up"),
+			),
+		},
+		Cancel: key.NewBinding(
+			key.WithKeys("ctrl+c"),
+			key.WithHelp("ctrl+c", "cancel run"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("q", "esc"),
+			key.WithHelp("q", "back to units"),
+		),
+	}
+}
@@ -0,0 +1,248 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+// sessionState keeps track of the view we are currently on.
+type sessionState int
+
+const (
+	listState sessionState = iota
+	pagerState
+)
+
+const (
+	// The statuses of the units that did not finish yet, on top of the statuses of finished units.
+	statusQueued   configstack.UnitRunStatus = "queued"
+	statusWaiting  configstack.UnitRunStatus = "waiting"
+	statusRunning  configstack.UnitRunStatus = "running"
+	statusRetrying configstack.UnitRunStatus = "retrying"
+
+	tickInterval = time.Second
+)
+
+// unit is the state of a unit of the run, as shown in the UI.
+type unit struct {
+	startTime time.Time
+	endTime   time.Time
+	output    *strings.Builder
+	status    configstack.UnitRunStatus
+	// path is the path of the unit, relative to the working dir.
+	path string
+	// waitingFor are the paths of the units the unit waits for, relative to the working dir.
+	waitingFor []string
+	// depth is the length of the longest chain of units the unit waits for, used to render the DAG.
+	depth    int
+	attempt  int
+	exitCode int
+}
+
+// elapsed returns how long the unit has been running, or ran.
+func (unit *unit) elapsed(now time.Time) time.Duration {
+	switch {
+	case unit.startTime.IsZero():
+		return 0
+	case unit.endTime.IsZero():
+		return now.Sub(unit.startTime)
+	default:
+		return unit.endTime.Sub(unit.startTime)
+	}
+}
+
+func (unit *unit) finished() bool {
+	switch unit.status {
+	case statusQueued, statusWaiting, statusRunning, statusRetrying:
+		return false
+	}
+
+	return true
+}
+
+type model struct {
+	startTime         time.Time
+	now               time.Time
+	runErr            error
+	terragruntOptions *options.TerragruntOptions
+	runner            configstack.Runner
+	events            <-chan configstack.UnitEvent
+	// unitsByPath maps the absolute path of each unit to its state.
+	unitsByPath map[string]*unit
+	// selected is the unit whose output is shown in the pager.
+	selected *unit
+	// units are the units in the order of the run queue.
+	units     []*unit
+	help      help.Model
+	listKeys  listKeyMap
+	pagerKeys pagerKeyMap
+	viewport  viewport.Model
+	cursor    int
+	state     sessionState
+	height    int
+	width     int
+	// done is true once the run finished.
+	done       bool
+	cancelling bool
+	ready      bool
+}
+
+func newModel(opts *options.TerragruntOptions, runner configstack.Runner, events <-chan configstack.UnitEvent) model {
+	now := time.Now()
+
+	return model{
+		terragruntOptions: opts,
+		runner:            runner,
+		events:            events,
+		unitsByPath:       map[string]*unit{},
+		help:              help.New(),
+		listKeys:          newListKeyMap(),
+		pagerKeys:         newPagerKeyMap(),
+		viewport:          viewport.New(0, 0),
+		startTime:         now,
+		now:               now,
+	}
+}
+
+// Init implements bubbletea.Model.Init
+func (m model) Init() tea.Cmd {
+	return tea.Batch(
+		waitForEventCmd(m.events, m.runner),
+		tickCmd(),
+	)
+}
+
+// unit returns the state of the unit with the given absolute path, adding it if it is not known yet.
+func (m *model) unit(path string) *unit {
+	if unit, ok := m.unitsByPath[path]; ok {
+		return unit
+	}
+
+	unit := &unit{
+		path:   m.relPath(path),
+		status: statusQueued,
+		output: &strings.Builder{},
+	}
+
+	m.unitsByPath[path] = unit
+	m.units = append(m.units, unit)
+
+	return unit
+}
+
+// relPath returns the given path relative to the working dir.
+func (m *model) relPath(path string) string {
+	if relPath, err := filepath.Rel(m.terragruntOptions.WorkingDir, path); err == nil {
+		return relPath
+	}
+
+	return path
+}
+
+// handleEvent updates the state of the unit of the given event.
+func (m *model) handleEvent(event configstack.UnitEvent) {
+	unit := m.unit(event.Path)
+
+	switch event.Type {
+	case configstack.UnitQueued:
+		// The units may log before they are queued, move them in the order of the run queue.
+		for i, queued := range m.units {
+			if queued == unit {
+				m.units = append(append(m.units[:i:i], m.units[i+1:]...), unit)
+				break
+			}
+		}
+
+		unit.status = statusQueued
+	case configstack.UnitWaiting:
+		unit.status = statusWaiting
+		unit.waitingFor = make([]string, 0, len(event.WaitingFor))
+
+		for _, path := range event.WaitingFor {
+			unit.waitingFor = append(unit.waitingFor, m.relPath(path))
+		}
+
+		m.updateDepths()
+	case configstack.UnitStarted:
+		unit.status = statusRunning
+		unit.startTime = event.Time
+	case configstack.UnitRetrying:
+		unit.status = statusRetrying
+		unit.attempt = event.Attempt
+	case configstack.UnitOutput:
+		unit.output.WriteString(event.Output)
+	case configstack.UnitFinished:
+		unit.status = event.Status
+		unit.exitCode = event.ExitCode
+
+		if !unit.startTime.IsZero() {
+			unit.endTime = event.Time
+		}
+
+		if event.Err != nil && event.Status != configstack.UnitFailed {
+			// Units that did not run have no output, show why instead.
+			unit.output.WriteString(event.Err.Error() + "\n")
+		}
+	}
+}
+
+// updateDepths sets the depth of every unit. The units are queued in the order of their dependencies, so the units a
+// unit waits for always come before it.
+func (m *model) updateDepths() {
+	depths := make(map[string]int, len(m.units))
+
+	for _, unit := range m.units {
+		unit.depth = 0
+
+		for _, path := range unit.waitingFor {
+			unit.depth = max(unit.depth, depths[path]+1)
+		}
+
+		depths[unit.path] = unit.depth
+	}
+}
+
+// selectedUnit returns the unit under the cursor, if any.
+func (m *model) selectedUnit() *unit {
+	if m.cursor < 0 || m.cursor >= len(m.units) {
+		return nil
+	}
+
+	return m.units[m.cursor]
+}
+
+type unitEventMsg configstack.UnitEvent
+
+type unitLogMsg struct {
+	path   string
+	output string
+}
+
+type runFinishedMsg struct{ err error }
+
+type tickMsg time.Time
+
+// waitForEventCmd returns a tea.Cmd waiting for the next event of the run, or for the run to finish.
+func waitForEventCmd(events <-chan configstack.UnitEvent, runner configstack.Runner) tea.Cmd {
+	return func() tea.Msg {
+		if event, ok := <-events; ok {
+			return unitEventMsg(event)
+		}
+
+		return runFinishedMsg{err: runner.Wait()}
+	}
+}
+
+func tickCmd() tea.Cmd {
+	return tea.Tick(tickInterval, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
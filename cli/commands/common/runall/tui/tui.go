@@ -0,0 +1,113 @@
+// Package tui provides a text-based user interface showing the progress of runs of all units.
+package tui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// Run runs the units of the stack with the given runner, showing their progress until the user quits. The output and
+// the logs of the units are shown in the UI instead of being written to the terminal, and the output of the failed
+// units is written to the terminal once the UI exits, along with the logs of the run.
+//
+// The given events must be the events of the runner, which must not be started yet.
+func Run(ctx context.Context, opts *options.TerragruntOptions, stack *configstack.Stack, runner configstack.Runner, events <-chan configstack.UnitEvent) error {
+	m := newModel(opts, runner, events)
+	program := tea.NewProgram(m, tea.WithAltScreen(), tea.WithContext(ctx))
+
+	for _, module := range stack.Modules {
+		module.TerragruntOptions.Writer = io.Discard
+		module.TerragruntOptions.ErrWriter = io.Discard
+		module.TerragruntOptions.Logger = module.TerragruntOptions.Logger.WithOptions(log.WithOutput(&logWriter{
+			program: program,
+			path:    module.Path,
+		}))
+	}
+
+	// The logs of the run itself are shown once the UI exits.
+	runLogs := &strings.Builder{}
+	logger := opts.Logger
+	opts.Logger = logger.WithOptions(log.WithOutput(&syncWriter{out: runLogs}))
+
+	defer func() { opts.Logger = logger }()
+
+	if err := runner.Start(ctx); err != nil {
+		return err
+	}
+
+	finalModel, programErr := program.Run()
+
+	// The UI can only be quit once the run finished, unless it was killed.
+	runner.Cancel()
+
+	for range events { //nolint:revive
+	}
+
+	runErr := runner.Wait()
+
+	if _, err := io.WriteString(opts.ErrWriter, runLogs.String()); err != nil {
+		return errors.New(err)
+	}
+
+	if final, ok := finalModel.(model); ok {
+		if err := final.writeFailedOutput(opts.ErrWriter); err != nil {
+			return err
+		}
+	}
+
+	if programErr != nil && !errors.Is(programErr, tea.ErrProgramKilled) {
+		return errors.New(programErr)
+	}
+
+	return runErr
+}
+
+// writeFailedOutput writes the output of the units that failed.
+func (m model) writeFailedOutput(w io.Writer) error {
+	for _, unit := range m.units {
+		if unit.status != configstack.UnitFailed {
+			continue
+		}
+
+		if _, err := fmt.Fprintf(w, "\nOutput of the failed unit %s:\n\n%s", unit.path, unit.output.String()); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
+
+// logWriter sends the logs of a unit to the UI.
+type logWriter struct {
+	program *tea.Program
+	path    string
+}
+
+func (writer *logWriter) Write(p []byte) (int, error) {
+	writer.program.Send(unitLogMsg{path: writer.path, output: string(p)})
+
+	return len(p), nil
+}
+
+// syncWriter serializes the writes to a writer shared by several loggers.
+type syncWriter struct {
+	out io.Writer
+	mu  sync.Mutex
+}
+
+func (writer *syncWriter) Write(p []byte) (int, error) {
+	writer.mu.Lock()
+	defer writer.mu.Unlock()
+
+	return writer.out.Write(p)
+}
@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/gruntwork-io/terragrunt/configstack"
+)
+
+func updateList(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.listKeys.Up):
+			m.cursor = max(m.cursor-1, 0)
+		case key.Matches(msg, m.listKeys.Down):
+			m.cursor = max(min(m.cursor+1, len(m.units)-1), 0)
+		case key.Matches(msg, m.listKeys.Choose):
+			if unit := m.selectedUnit(); unit != nil {
+				m.selected = unit
+				m.viewport.SetContent(unit.output.String())
+				m.viewport.GotoBottom()
+				m.state = pagerState
+			}
+		case key.Matches(msg, m.listKeys.Quit):
+			// The run must finish before quitting, otherwise the units would be interrupted without notice.
+			if m.done {
+				return m, tea.Quit
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func updatePager(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	if msg, ok := msg.(tea.KeyMsg); ok && key.Matches(msg, m.pagerKeys.Back) {
+		m.state = listState
+		return m, nil
+	}
+
+	// Handle keyboard and mouse events in the viewport
+	m.viewport, cmd = m.viewport.Update(msg)
+
+	return m, cmd
+}
+
+// Update handles all TUI interactions and implements bubbletea.Model.Update.
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.viewport.Width = msg.Width
+		m.viewport.Height = max(msg.Height-lipgloss.Height(m.pagerHeaderView())-lipgloss.Height(m.pagerFooterView()), 0)
+		m.ready = true
+
+		return m, nil
+
+	case tea.KeyMsg:
+		if key.Matches(msg, m.listKeys.Cancel) {
+			if m.done {
+				return m, tea.Quit
+			}
+
+			if !m.cancelling {
+				m.cancelling = true
+				m.runner.Cancel()
+			}
+
+			return m, nil
+		}
+
+	case unitEventMsg:
+		m.handleEvent(configstack.UnitEvent(msg))
+		m.refreshPager(msg.Path)
+
+		return m, waitForEventCmd(m.events, m.runner)
+
+	case unitLogMsg:
+		m.unit(msg.path).output.WriteString(msg.output)
+		m.refreshPager(msg.path)
+
+		return m, nil
+
+	case runFinishedMsg:
+		m.done = true
+		m.runErr = msg.err
+		m.now = time.Now()
+
+		return m, nil
+
+	case tickMsg:
+		if m.done {
+			return m, nil
+		}
+
+		m.now = time.Time(msg)
+
+		return m, tickCmd()
+	}
+
+	// Hand off the message and model to the appropriate update function for the
+	// appropriate view based on the current state.
+	switch m.state {
+	case listState:
+		return updateList(msg, m)
+	case pagerState:
+		return updatePager(msg, m)
+	}
+
+	return m, nil
+}
+
+// refreshPager updates the output shown in the pager, if it shows the output of the unit with the given absolute path.
+// The output keeps following the new lines, unless scrolled up.
+func (m *model) refreshPager(path string) {
+	unit := m.unit(path)
+	if m.state != pagerState || unit != m.selected {
+		return
+	}
+
+	following := m.viewport.AtBottom()
+
+	m.viewport.SetContent(unit.output.String())
+
+	if following {
+		m.viewport.GotoBottom()
+	}
+}
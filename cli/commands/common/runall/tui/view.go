@@ -0,0 +1,203 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/gruntwork-io/terragrunt/configstack"
+)
+
+const (
+	titleForegroundColor = "#A8ACB1"
+	titleBackgroundColor = "#1D252F"
+
+	depthIndent = "  "
+)
+
+var (
+	titleStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color(titleForegroundColor)).
+			Background(lipgloss.Color(titleBackgroundColor)).
+			Padding(0, 1)
+	headerStyle   = lipgloss.NewStyle().Padding(1, 1)       //nolint:mnd
+	footerStyle   = lipgloss.NewStyle().Padding(1, 1, 0, 1) //nolint:mnd
+	selectedStyle = lipgloss.NewStyle().Bold(true).Reverse(true)
+	dimStyle      = lipgloss.NewStyle().Faint(true)
+
+	statusStyles = map[configstack.UnitRunStatus]lipgloss.Style{
+		statusQueued:              dimStyle,
+		statusWaiting:             dimStyle,
+		statusRunning:             lipgloss.NewStyle().Foreground(lipgloss.Color("12")),
+		statusRetrying:            lipgloss.NewStyle().Foreground(lipgloss.Color("11")),
+		configstack.UnitSucceeded: lipgloss.NewStyle().Foreground(lipgloss.Color("10")),
+		configstack.UnitFailed:    lipgloss.NewStyle().Foreground(lipgloss.Color("9")),
+		configstack.UnitCancelled: lipgloss.NewStyle().Foreground(lipgloss.Color("11")),
+		configstack.UnitSkipped:   dimStyle,
+	}
+
+	statusIcons = map[configstack.UnitRunStatus]string{
+		statusQueued:              "○",
+		statusWaiting:             "○",
+		statusRunning:             "●",
+		statusRetrying:            "↻",
+		configstack.UnitSucceeded: "✓",
+		configstack.UnitFailed:    "✗",
+		configstack.UnitCancelled: "⊘",
+		configstack.UnitSkipped:   "-",
+	}
+
+	// statusOrder is the order of the statuses in the summary of the run.
+	statusOrder = []configstack.UnitRunStatus{
+		statusRunning,
+		statusRetrying,
+		statusWaiting,
+		statusQueued,
+		configstack.UnitSucceeded,
+		configstack.UnitFailed,
+		configstack.UnitCancelled,
+		configstack.UnitSkipped,
+	}
+)
+
+// View is the main view, which just calls the appropriate sub-view and returns a string representation of the TUI
+// based on the application's state.
+func (m model) View() string {
+	if !m.ready {
+		return ""
+	}
+
+	switch m.state {
+	case listState:
+		return m.listView()
+	case pagerState:
+		return lipgloss.JoinVertical(lipgloss.Left, m.pagerHeaderView(), m.viewport.View(), m.pagerFooterView())
+	}
+
+	return ""
+}
+
+func (m model) listView() string {
+	header := m.headerView()
+	footer := footerStyle.Render(m.help.View(m.listKeys))
+
+	height := max(m.height-lipgloss.Height(header)-lipgloss.Height(footer), 1)
+
+	// Scroll the list so that the cursor is always visible.
+	first := max(min(m.cursor-height/2, len(m.units)-height), 0) //nolint:mnd
+	last := min(first+height, len(m.units))
+
+	rows := make([]string, 0, height)
+	for i := first; i < last; i++ {
+		rows = append(rows, m.unitView(m.units[i], i == m.cursor))
+	}
+
+	list := lipgloss.NewStyle().Height(height).Render(strings.Join(rows, "\n"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, list, footer)
+}
+
+// headerView renders the summary of the run.
+func (m model) headerView() string {
+	title := titleStyle.Render(fmt.Sprintf("%s in %d units", m.terragruntOptions.TerraformCommand, len(m.units)))
+
+	counts := map[configstack.UnitRunStatus]int{}
+	for _, unit := range m.units {
+		counts[unit.status]++
+	}
+
+	summary := []string{title, formatDuration(m.now.Sub(m.startTime))}
+
+	for _, status := range statusOrder {
+		if counts[status] > 0 {
+			summary = append(summary, statusStyles[status].Render(fmt.Sprintf("%d %s", counts[status], status)))
+		}
+	}
+
+	var state string
+
+	switch {
+	case m.done && m.runErr != nil:
+		state = statusStyles[configstack.UnitFailed].Render("Run finished with errors, press q to quit.")
+	case m.done:
+		state = statusStyles[configstack.UnitSucceeded].Render("Run finished, press q to quit.")
+	case m.cancelling:
+		state = statusStyles[configstack.UnitCancelled].Render("Cancelling the run, waiting for the running units to stop...")
+	}
+
+	return headerStyle.Render(lipgloss.JoinVertical(lipgloss.Left, strings.Join(summary, "  "), state))
+}
+
+// unitView renders a unit of the list, indented by its depth in the DAG.
+func (m model) unitView(unit *unit, selected bool) string {
+	style := statusStyles[unit.status]
+
+	status := string(unit.status)
+
+	switch {
+	case unit.status == statusRetrying:
+		status = fmt.Sprintf("%s (attempt %d)", status, unit.attempt)
+	case unit.status == statusWaiting:
+		status = fmt.Sprintf("%s for %s", status, strings.Join(m.pendingDependencies(unit), ", "))
+	case unit.finished() && !unit.startTime.IsZero():
+		status = fmt.Sprintf("%s (exit code %d)", status, unit.exitCode)
+	}
+
+	var elapsed string
+	if !unit.startTime.IsZero() {
+		elapsed = formatDuration(unit.elapsed(m.now))
+	}
+
+	path := strings.Repeat(depthIndent, unit.depth) + unit.path
+	if selected {
+		path = selectedStyle.Render(path)
+	}
+
+	return fmt.Sprintf(" %s %s  %s  %s", style.Render(statusIcons[unit.status]), path, style.Render(status), dimStyle.Render(elapsed))
+}
+
+// pendingDependencies returns the paths of the units the given unit waits for that did not finish yet.
+func (m model) pendingDependencies(unit *unit) []string {
+	finished := make(map[string]bool, len(m.units))
+	for _, dependency := range m.units {
+		finished[dependency.path] = dependency.finished()
+	}
+
+	pending := make([]string, 0, len(unit.waitingFor))
+
+	for _, path := range unit.waitingFor {
+		if !finished[path] {
+			pending = append(pending, path)
+		}
+	}
+
+	if len(pending) == 0 {
+		// All dependencies finished, the unit waits for a free slot.
+		return []string{"a free slot"}
+	}
+
+	return pending
+}
+
+func (m model) pagerHeaderView() string {
+	if m.selected == nil {
+		return headerStyle.Render(titleStyle.Render("output"))
+	}
+
+	style := statusStyles[m.selected.status]
+
+	return headerStyle.Render(titleStyle.Render(m.selected.path) + "  " + style.Render(string(m.selected.status)))
+}
+
+func (m model) pagerFooterView() string {
+	return footerStyle.Render(m.help.View(m.pagerKeys))
+}
+
+// formatDuration formats the given duration as minutes and seconds.
+func formatDuration(duration time.Duration) string {
+	duration = duration.Round(time.Second)
+
+	return fmt.Sprintf("%02d:%02d", int(duration.Minutes()), int(duration.Seconds())%60) //nolint:mnd
+}
@@ -0,0 +1,66 @@
+// Package drift provides the `drift` command, which detects the units of a stack whose infrastructure drifted from
+// their configuration.
+package drift
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+const (
+	CommandName = "drift"
+
+	FormatFlagName = "format"
+	ModeFlagName   = "mode"
+)
+
+func NewFlags(opts *Options, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FormatFlagName,
+			EnvVars:     tgPrefix.EnvVars(FormatFlagName),
+			Destination: &opts.Format,
+			Usage:       "Output format of the drift of the units. Valid values: text, json.",
+			DefaultText: FormatText,
+		}),
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        ModeFlagName,
+			EnvVars:     tgPrefix.EnvVars(ModeFlagName),
+			Destination: &opts.Mode,
+			Usage:       "How the units are planned: refresh-only only detects the resources changed outside of OpenTofu/Terraform, plan detects any difference with the configuration.",
+			DefaultText: ModeRefreshOnly,
+		}),
+	}
+}
+
+func NewCommand(opts *options.TerragruntOptions) *cli.Command {
+	cmdOpts := NewOptions(opts)
+	prefix := flags.Prefix{CommandName}
+
+	return &cli.Command{
+		Name:      CommandName,
+		Usage:     "Detect the units of the stack whose infrastructure drifted from their configuration.",
+		UsageText: "terragrunt drift [--mode <refresh-only|plan>] [--format <text|json>] [-- <plan args>]",
+		Description: "Plans every unit of the stack in the working dir with -detailed-exitcode, and reports the units whose plan has changes along with the drifted resources. " +
+			"Exits with code 2 when drift is detected.",
+		Examples: []string{
+			"# Detect the resources changed outside of OpenTofu/Terraform\nterragrunt drift",
+			"# Detect any difference between the configuration and the infrastructure, as JSON\nterragrunt drift --mode plan --format json",
+		},
+		Flags: append(NewFlags(cmdOpts, prefix), run.NewFlags(opts, nil)...),
+		Before: func(ctx *cli.Context) error {
+			if err := cmdOpts.Validate(); err != nil {
+				return cli.NewExitError(err, cli.ExitCodeGeneralError)
+			}
+
+			return nil
+		},
+		Action: func(ctx *cli.Context) error {
+			return Run(ctx.Context, cmdOpts)
+		},
+	}
+}
@@ -0,0 +1,76 @@
+package drift
+
+import (
+	"context"
+	"os"
+
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/tf"
+)
+
+// Run plans every unit of the stack in the working dir and reports the units whose infrastructure drifted. It exits
+// with the code of `plan -detailed-exitcode` reporting changes when drift is detected.
+func Run(ctx context.Context, opts *Options) error {
+	tgOpts := opts.TerragruntOptions
+
+	args := cli.Args{tf.CommandNamePlan, tf.FlagNameDetailedExitCode}
+	if opts.Mode == ModeRefreshOnly {
+		args = append(args, "-refresh-only")
+	}
+
+	tgOpts.TerraformCommand = tf.CommandNamePlan
+	tgOpts.OriginalTerraformCommand = tf.CommandNamePlan
+	tgOpts.TerraformCliArgs = append(args, tgOpts.TerraformCliArgs.Tail()...)
+
+	// The drifted resources are read from the JSON plans of the units.
+	if tgOpts.JSONOutputFolder == "" {
+		jsonDir, err := os.MkdirTemp("", "terragrunt-drift-")
+		if err != nil {
+			return errors.New(err)
+		}
+
+		defer os.RemoveAll(jsonDir) //nolint:errcheck
+
+		tgOpts.JSONOutputFolder = jsonDir
+	}
+
+	writer := tgOpts.Writer
+
+	// Keep the output of the units apart from the JSON report.
+	if opts.Format == FormatJSON {
+		tgOpts.Writer = tgOpts.ErrWriter
+	}
+
+	stack, err := configstack.FindStackInSubfolders(ctx, tgOpts)
+	if err != nil {
+		return err
+	}
+
+	report, runErr := stack.DetectDrift(ctx, tgOpts)
+	if report == nil {
+		return runErr
+	}
+
+	switch opts.Format {
+	case FormatJSON:
+		err = report.WriteJSON(writer)
+	default:
+		err = report.Print(writer)
+	}
+
+	if err != nil {
+		return errors.Join(runErr, err)
+	}
+
+	if runErr != nil {
+		return runErr
+	}
+
+	if report.Drifted > 0 {
+		return cli.NewExitError(errors.New(DriftDetectedError(report.Drifted)), tf.DetailedExitCodeChanges)
+	}
+
+	return nil
+}
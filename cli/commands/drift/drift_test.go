@@ -0,0 +1,137 @@
+package drift_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/drift"
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionsValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		format      string
+		mode        string
+		expectedErr bool
+	}{
+		{name: "defaults", format: drift.FormatText, mode: drift.ModeRefreshOnly},
+		{name: "json plan", format: drift.FormatJSON, mode: drift.ModePlan},
+		{name: "invalid format", format: "yaml", mode: drift.ModeRefreshOnly, expectedErr: true},
+		{name: "invalid mode", format: drift.FormatText, mode: "apply", expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := drift.NewOptions(options.NewTerragruntOptions())
+			opts.Format = tc.format
+			opts.Mode = tc.mode
+
+			err := opts.Validate()
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+// newDriftTestOptions creates a unit for each of the given JSON plans, whose plan has changes if its JSON plan is not
+// nil, and returns the options to detect the drift of the stack. OpenTofu/Terraform is replaced by a script reading
+// the JSON plan of the unit.
+func newDriftTestOptions(t *testing.T, plans map[string]any) *drift.Options {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the OpenTofu/Terraform stand-in is a shell script")
+	}
+
+	fakeTofuPath, err := filepath.Abs(filepath.Join("testdata", "fake_tofu.sh"))
+	require.NoError(t, err)
+
+	tmpDir := t.TempDir()
+
+	for unit, plan := range plans {
+		unitDir := filepath.Join(tmpDir, unit)
+		require.NoError(t, os.MkdirAll(unitDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(unitDir, "terragrunt.hcl"), nil, 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(unitDir, "main.tf"), nil, 0644))
+
+		if plan != nil {
+			content, err := json.Marshal(plan)
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(filepath.Join(unitDir, "fake-drift"), content, 0644))
+		}
+	}
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest(filepath.Join(tmpDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	terragruntOptions.WorkingDir = tmpDir
+	terragruntOptions.RootWorkingDir = tmpDir
+	terragruntOptions.TerraformPath = fakeTofuPath
+	terragruntOptions.NonInteractive = true
+	terragruntOptions.RunTerragrunt = run.Run
+	terragruntOptions.Writer = &bytes.Buffer{}
+	terragruntOptions.ErrWriter = &bytes.Buffer{}
+
+	opts := drift.NewOptions(terragruntOptions)
+	opts.Format = drift.FormatJSON
+
+	return opts
+}
+
+func TestDriftDetected(t *testing.T) {
+	t.Parallel()
+
+	opts := newDriftTestOptions(t, map[string]any{
+		"app": map[string]any{
+			"resource_drift": []any{
+				map[string]any{"address": "aws_instance.web", "mode": "managed", "change": map[string]any{"actions": []string{"update"}}},
+			},
+		},
+		"vpc": nil,
+	})
+	out := opts.Writer.(*bytes.Buffer)
+
+	err := drift.Run(context.Background(), opts)
+
+	var exitErr cli.ExitCoder
+	require.True(t, errors.As(err, &exitErr), "unexpected error: %v", err)
+	assert.Equal(t, tf.DetailedExitCodeChanges, exitErr.ExitCode())
+
+	var report configstack.DriftReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+
+	assert.Equal(t, 1, report.Drifted)
+	assert.Equal(t, 1, report.InSync)
+	require.Len(t, report.Units, 2)
+	assert.Equal(t, "app", report.Units[0].Path)
+	assert.Equal(t, []*configstack.DriftedResource{{Address: "aws_instance.web", Actions: []string{"update"}}}, report.Units[0].Resources)
+}
+
+func TestDriftNotDetected(t *testing.T) {
+	t.Parallel()
+
+	opts := newDriftTestOptions(t, map[string]any{"app": nil, "vpc": nil})
+
+	require.NoError(t, drift.Run(context.Background(), opts))
+}
@@ -0,0 +1,13 @@
+package drift
+
+import (
+	"fmt"
+)
+
+// Custom error types
+
+type DriftDetectedError int
+
+func (count DriftDetectedError) Error() string {
+	return fmt.Sprintf("Drift detected in %d units", int(count))
+}
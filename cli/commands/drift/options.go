@@ -0,0 +1,60 @@
+package drift
+
+import (
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+const (
+	// FormatText outputs the drift of the units in a human-readable format.
+	FormatText = "text"
+
+	// FormatJSON outputs the drift of the units in JSON format.
+	FormatJSON = "json"
+
+	// ModeRefreshOnly detects the resources changed outside of OpenTofu/Terraform, with `plan -refresh-only`.
+	ModeRefreshOnly = "refresh-only"
+
+	// ModePlan detects any difference between the configuration and the infrastructure, with a normal plan.
+	ModePlan = "plan"
+)
+
+type Options struct {
+	*options.TerragruntOptions
+
+	// Format determines the format of the output.
+	Format string
+
+	// Mode determines how the units are planned to detect their drift.
+	Mode string
+}
+
+func NewOptions(opts *options.TerragruntOptions) *Options {
+	return &Options{
+		TerragruntOptions: opts,
+		Format:            FormatText,
+		Mode:              ModeRefreshOnly,
+	}
+}
+
+func (o *Options) Validate() error {
+	var errs []error
+
+	switch o.Format {
+	case FormatText, FormatJSON:
+	default:
+		errs = append(errs, errors.New("invalid format: "+o.Format))
+	}
+
+	switch o.Mode {
+	case ModeRefreshOnly, ModePlan:
+	default:
+		errs = append(errs, errors.New("invalid mode: "+o.Mode))
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errors.Join(errs...))
+	}
+
+	return nil
+}
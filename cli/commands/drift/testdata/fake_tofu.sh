@@ -0,0 +1,27 @@
+#!/bin/sh
+# A stand-in for OpenTofu/Terraform, whose plan of a unit has changes if the working dir has a fake-drift file. The
+# content of that file is the JSON representation of the plan, output by `show -json`.
+
+case "$1" in
+  -version|version)
+    echo "Terraform v1.9.0"
+    ;;
+  plan)
+    for arg in "$@"; do
+      case "$arg" in
+        -out=*) touch "${arg#-out=}" ;;
+      esac
+    done
+
+    if [ -f fake-drift ]; then
+      exit 2
+    fi
+    ;;
+  show)
+    if [ -f fake-drift ]; then
+      cat fake-drift
+    else
+      echo '{}'
+    fi
+    ;;
+esac
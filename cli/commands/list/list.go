@@ -5,11 +5,13 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/tree"
 	"github.com/charmbracelet/x/term"
+	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/discovery"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/internal/queue"
@@ -95,6 +97,9 @@ type ListedConfig struct {
 	Type discovery.ConfigType
 	Path string
 
+	// Schedule describes the concurrency group and weight of the config, listed in DAG mode.
+	Schedule string
+
 	Dependencies []*ListedConfig
 }
 
@@ -141,6 +146,10 @@ func discoveredToListed(configs discovery.DiscoveredConfigs, opts *Options) (Lis
 			Path: relPath,
 		}
 
+		if opts.Mode == ModeDAG {
+			listedCfg.Schedule = scheduleLabel(config.Scheduling)
+		}
+
 		if len(config.Dependencies) == 0 {
 			listedCfgs = append(listedCfgs, listedCfg)
 
@@ -173,6 +182,38 @@ func discoveredToListed(configs discovery.DiscoveredConfigs, opts *Options) (Lis
 	return listedCfgs, errors.Join(errs...)
 }
 
+// scheduleLabel returns the label describing the given scheduling block, or an empty string if there is none.
+func scheduleLabel(scheduling *config.SchedulingConfig) string {
+	if scheduling == nil {
+		return ""
+	}
+
+	parts := []string{}
+
+	if scheduling.Group != nil {
+		parts = append(parts, "group="+*scheduling.Group)
+	}
+
+	if scheduling.Weight != nil {
+		parts = append(parts, "weight="+strconv.Itoa(*scheduling.Weight))
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// displayLen returns the length of the config as displayed, including its schedule.
+func (l *ListedConfig) displayLen() int {
+	if l.Schedule == "" {
+		return len(l.Path)
+	}
+
+	return len(l.Path) + 1 + len(l.Schedule)
+}
+
 // Colorizer is a colorizer for the discovered configurations.
 type Colorizer struct {
 	unitColorizer    func(string) string
@@ -231,6 +272,15 @@ func (c *Colorizer) Colorize(config *ListedConfig) string {
 	}
 }
 
+// ColorizeWithSchedule colorizes the config, followed by its schedule if any.
+func (c *Colorizer) ColorizeWithSchedule(config *ListedConfig) string {
+	if config.Schedule == "" {
+		return c.Colorize(config)
+	}
+
+	return c.Colorize(config) + " " + c.pathColorizer(config.Schedule)
+}
+
 func (c *Colorizer) ColorizeType(t discovery.ConfigType) string {
 	switch t {
 	case discovery.ConfigTypeUnit:
@@ -282,7 +332,7 @@ func renderLong(opts *Options, configs ListedConfigs, c *Colorizer) error {
 			return errors.New(err)
 		}
 
-		_, err = opts.Writer.Write([]byte(" " + c.Colorize(config)))
+		_, err = opts.Writer.Write([]byte(" " + c.ColorizeWithSchedule(config)))
 		if err != nil {
 			return errors.New(err)
 		}
@@ -296,7 +346,7 @@ func renderLong(opts *Options, configs ListedConfigs, c *Colorizer) error {
 
 			const extraDependenciesPadding = 2
 
-			dependenciesPadding := (longestPathLen - len(config.Path)) + extraDependenciesPadding
+			dependenciesPadding := (longestPathLen - config.displayLen()) + extraDependenciesPadding
 			for range dependenciesPadding {
 				_, err := opts.Writer.Write([]byte(" "))
 				if err != nil {
@@ -363,13 +413,13 @@ func renderTabular(opts *Options, configs ListedConfigs, c *Colorizer) error {
 			}
 		}
 
-		_, err := opts.Writer.Write([]byte(c.Colorize(config)))
+		_, err := opts.Writer.Write([]byte(c.ColorizeWithSchedule(config)))
 		if err != nil {
 			return errors.New(err)
 		}
 
 		// Add padding until the length of maxCols
-		padding := colWidth - len(config.Path)
+		padding := colWidth - config.displayLen()
 		for range padding {
 			_, err := opts.Writer.Write([]byte(" "))
 			if err != nil {
@@ -483,7 +533,7 @@ func generateDAGTree(configs ListedConfigs, s *TreeStyler) *tree.Tree {
 	// First pass: create all root nodes
 	for _, config := range configs {
 		if len(config.Dependencies) == 0 || !configs.Contains(config.Path) {
-			rootNodes[config.Path] = tree.New().Root(s.colorizer.Colorize(config))
+			rootNodes[config.Path] = tree.New().Root(s.colorizer.ColorizeWithSchedule(config))
 		}
 	}
 
@@ -503,7 +553,7 @@ func generateDAGTree(configs ListedConfigs, s *TreeStyler) *tree.Tree {
 
 		for _, dependency := range sortedDeps {
 			if _, exists := rootNodes[dependency]; exists {
-				dependencyNode := tree.New().Root(s.colorizer.Colorize(config))
+				dependencyNode := tree.New().Root(s.colorizer.ColorizeWithSchedule(config))
 				rootNodes[dependency].Child(dependencyNode)
 				dependencyNodes[config.Path] = dependencyNode
 
@@ -511,7 +561,7 @@ func generateDAGTree(configs ListedConfigs, s *TreeStyler) *tree.Tree {
 			}
 
 			if _, exists := dependencyNodes[dependency]; exists {
-				newDependencyNode := tree.New().Root(s.colorizer.Colorize(config))
+				newDependencyNode := tree.New().Root(s.colorizer.ColorizeWithSchedule(config))
 				dependencyNodes[dependency].Child(newDependencyNode)
 				dependencyNodes[config.Path] = newDependencyNode
 			}
@@ -613,13 +663,14 @@ func getTerminalWidth() int {
 }
 
 // getLongestPathLen returns the length of the
-// longest path in the list of configurations.
+// longest path in the list of configurations,
+// including the schedule listed in DAG mode.
 func getLongestPathLen(configs ListedConfigs) int {
 	longest := 0
 
 	for _, config := range configs {
-		if len(config.Path) > longest {
-			longest = len(config.Path)
+		if config.displayLen() > longest {
+			longest = config.displayLen()
 		}
 	}
 
@@ -243,6 +243,61 @@ dependency "unit2" {
 	assert.Equal(t, expectedPaths, fields)
 }
 
+func TestDAGListsScheduling(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	testFiles := map[string]string{
+		"cluster/terragrunt.hcl": `
+scheduling {
+  group  = "eks"
+  weight = 2
+}`,
+		"addons/terragrunt.hcl": `
+dependency "cluster" {
+  config_path = "../cluster"
+}
+
+scheduling {
+  group = "eks"
+}`,
+		"vpc/terragrunt.hcl": "",
+	}
+
+	for path, content := range testFiles {
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, filepath.Dir(path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, path), []byte(content), 0644))
+	}
+
+	tgOpts := options.NewTerragruntOptions()
+	tgOpts.WorkingDir = tmpDir
+	tgOpts.Logger.Formatter().SetDisabledColors(true)
+
+	opts := list.NewOptions(tgOpts)
+	opts.Format = "text"
+	opts.Mode = "dag"
+	opts.Dependencies = true
+	opts.External = false
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+
+	opts.Writer = w
+
+	err = list.Run(context.Background(), opts)
+	require.NoError(t, err)
+
+	w.Close()
+
+	output, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(output), "cluster [group=eks weight=2]")
+	assert.Contains(t, string(output), "addons [group=eks]")
+	assert.NotContains(t, string(output), "vpc [")
+}
+
 func TestDAGSortingReversedDependencies(t *testing.T) {
 	t.Parallel()
 
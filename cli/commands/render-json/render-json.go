@@ -20,6 +20,7 @@ import (
 	"github.com/gruntwork-io/terragrunt/cli/commands/run"
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/sensitive"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/util"
 )
@@ -93,9 +94,10 @@ func runRenderJSON(ctx context.Context, opts *options.TerragruntOptions, cfg *co
 // marshalCtyValueJSONWithoutType marshals the given cty.Value object into a JSON object that does not have the type.
 // Using ctyjson directly would render a json object with two attributes, "value" and "type", and this function returns
 // just the "value".
-// NOTE: We have to do two marshalling passes so that we can extract just the value.
+// NOTE: We have to do two marshalling passes so that we can extract just the value. The sensitive values are redacted
+// from the value.
 func marshalCtyValueJSONWithoutType(ctyVal cty.Value) ([]byte, error) {
-	jsonBytesIntermediate, err := ctyjson.Marshal(ctyVal, cty.DynamicPseudoType)
+	jsonBytesIntermediate, err := ctyjson.Marshal(sensitive.Unmark(ctyVal), cty.DynamicPseudoType)
 	if err != nil {
 		return nil, errors.New(err)
 	}
@@ -105,7 +107,7 @@ func marshalCtyValueJSONWithoutType(ctyVal cty.Value) ([]byte, error) {
 		return nil, errors.New(err)
 	}
 
-	jsonBytes, err := json.Marshal(ctyJSONOutput.Value)
+	jsonBytes, err := json.Marshal(sensitive.RedactValue(ctyJSONOutput.Value))
 
 	return jsonBytes, errors.New(err)
 }
@@ -0,0 +1,388 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/sensitive"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/remote"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	// BackendMigrationLogFileName is the name of the file, next to the terragrunt.hcl of a unit, to which every
+	// backend migration of the unit is appended as a line of JSON.
+	BackendMigrationLogFileName = ".terragrunt-backend-migrations.jsonl"
+
+	backendMigrationLogFileMode = 0600
+
+	// pendingBackendMigrationFileName is the name of the file, in the data dir of a unit, that holds the state pulled
+	// from the old backend until it has been pushed to the new one. As `init -reconfigure` makes the data dir describe
+	// the new backend, this file is the only record of the old one if the migration fails after `init`.
+	pendingBackendMigrationFileName = "terragrunt-backend-migration.json"
+	pendingBackendMigrationFileMode = 0600
+
+	reconfigureFlag  = "-reconfigure"
+	migrateStateFlag = "-migrate-state"
+)
+
+// backendConfigSecretKeys are the backend settings holding credentials, which are redacted from the migration log.
+var backendConfigSecretKeys = []string{
+	"access_key",
+	"access_token",
+	"client_certificate_password",
+	"client_private_key_pem",
+	"client_secret",
+	"credentials",
+	"encryption_key",
+	"password",
+	"sas_token",
+	"secret_key",
+	"session_token",
+	"token",
+}
+
+// BackendMigrationLogEntry is a record of the state of a unit being migrated from one backend to another.
+type BackendMigrationLogEntry struct {
+	Time          time.Time      `json:"time"`
+	OldConfig     map[string]any `json:"old_config"`
+	NewConfig     map[string]any `json:"new_config"`
+	Unit          string         `json:"unit"`
+	OldBackend    string         `json:"old_backend"`
+	NewBackend    string         `json:"new_backend"`
+	Lineage       string         `json:"lineage"`
+	Serial        int            `json:"serial"`
+	AlreadyPushed bool           `json:"already_pushed,omitempty"`
+}
+
+// backendMigration holds the state pulled from the old backend of a unit, until it can be pushed to the new backend
+// once `init` has been run against it.
+type backendMigration struct {
+	oldBackend *remote.RemoteState
+	newBackend *remote.RemoteState
+	state      *remote.TerraformState
+	rawState   []byte
+}
+
+// pendingBackendMigration is the content of the pending migration file of a unit.
+type pendingBackendMigration struct {
+	OldConfig  map[string]any `json:"old_config"`
+	OldBackend string         `json:"old_backend"`
+	State      []byte         `json:"state"`
+}
+
+// prepareBackendMigration checks whether the backend recorded in the data dir of the unit differs from the configured
+// one and if so, pulls the state from the old backend. Returns nil if there is nothing to migrate.
+//
+// If a previous migration of the unit failed after `init` switched the data dir to the new backend, the state pulled
+// back then is returned instead, so that the migration is retried.
+func prepareBackendMigration(ctx context.Context, opts *options.TerragruntOptions, remoteState *remote.RemoteState) (*backendMigration, error) {
+	migration, err := readPendingBackendMigration(opts, remoteState)
+	if err != nil || migration != nil {
+		return migration, err
+	}
+
+	parsedState, err := remote.ParseTerraformStateFileFromLocation(remoteState.Backend, remoteState.Config, opts.WorkingDir, opts.DataDir())
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the keys passed to the backend are recorded in the data dir, so the keys only used by Terragrunt, such as
+	// the tags of the S3 bucket, are not compared.
+	if parsedState == nil || parsedState.Backend == nil || !remoteState.BackendDiffersFrom(parsedState.Backend, opts) {
+		return nil, nil
+	}
+
+	oldBackend := &remote.RemoteState{
+		Backend: parsedState.Backend.Type,
+		Config:  withoutNullValues(parsedState.Backend.Config),
+	}
+
+	if !oldBackend.CanReadState() {
+		return nil, errors.New(BackendMigrationNotSupportedError{UnitPath: opts.TerragruntConfigPath, Backend: oldBackend.Backend})
+	}
+
+	opts.Logger.Infof("Backend configuration changed, pulling state from the previous %s backend", oldBackend.Backend)
+
+	rawState, err := oldBackend.ReadState(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rawState) == 0 {
+		opts.Logger.Infof("The previous %s backend has no state, nothing to migrate", oldBackend.Backend)
+		return nil, nil
+	}
+
+	state, err := remote.ParseTerraformState(rawState)
+	if err != nil {
+		return nil, err
+	}
+
+	migration = &backendMigration{
+		oldBackend: oldBackend,
+		newBackend: remoteState,
+		rawState:   rawState,
+		state:      state,
+	}
+
+	if err := migration.savePending(opts); err != nil {
+		return nil, err
+	}
+
+	return migration, nil
+}
+
+// readPendingBackendMigration returns the migration of the unit that failed after `init`, or nil if there is none.
+func readPendingBackendMigration(opts *options.TerragruntOptions, remoteState *remote.RemoteState) (*backendMigration, error) {
+	pendingPath := filepath.Join(opts.DataDir(), pendingBackendMigrationFileName)
+
+	if !util.FileExists(pendingPath) {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(pendingPath)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	var pending pendingBackendMigration
+	if err := json.Unmarshal(content, &pending); err != nil {
+		return nil, errors.Errorf("invalid pending backend migration %s: %w", pendingPath, err)
+	}
+
+	state, err := remote.ParseTerraformState(pending.State)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Logger.Infof("Resuming the migration of the state from the previous %s backend, recorded in %s", pending.OldBackend, pendingPath)
+
+	return &backendMigration{
+		oldBackend: &remote.RemoteState{Backend: pending.OldBackend, Config: pending.OldConfig},
+		newBackend: remoteState,
+		rawState:   pending.State,
+		state:      state,
+	}, nil
+}
+
+// savePending writes the migration to the data dir of the unit, so that it survives a failure after `init`.
+func (migration *backendMigration) savePending(opts *options.TerragruntOptions) error {
+	content, err := json.Marshal(&pendingBackendMigration{
+		OldBackend: migration.oldBackend.Backend,
+		OldConfig:  migration.oldBackend.Config,
+		State:      migration.rawState,
+	})
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if err := util.EnsureDirectory(opts.DataDir()); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(opts.DataDir(), pendingBackendMigrationFileName), content, pendingBackendMigrationFileMode); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// removePending removes the migration from the data dir of the unit, once it has been completed.
+func (migration *backendMigration) removePending(opts *options.TerragruntOptions) error {
+	if err := os.Remove(filepath.Join(opts.DataDir(), pendingBackendMigrationFileName)); err != nil && !os.IsNotExist(err) {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// initArgs returns the args to add to the `init` command, so that OpenTofu/Terraform switches to the new backend
+// without trying to migrate the state itself.
+func (migration *backendMigration) initArgs(opts *options.TerragruntOptions) []string {
+	if util.ListContainsElement(opts.TerraformCliArgs, reconfigureFlag) || util.ListContainsElement(opts.TerraformCliArgs, migrateStateFlag) {
+		return nil
+	}
+
+	return []string{reconfigureFlag}
+}
+
+// complete pushes the pulled state to the new backend, verifies that the new backend returns the same serial and
+// lineage, and records the migration in the migration log of the unit. It must be called after `init` has been run
+// against the new backend. If it fails, the pending migration is kept in the data dir and retried on the next `init`.
+func (migration *backendMigration) complete(ctx context.Context, opts *options.TerragruntOptions) error {
+	existingState, err := pullState(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	alreadyPushed := false
+
+	if existingState != nil && existingState.Lineage != "" {
+		if existingState.Lineage != migration.state.Lineage {
+			return errors.New(BackendMigrationConflictError{
+				UnitPath:        opts.TerragruntConfigPath,
+				Lineage:         migration.state.Lineage,
+				ExistingLineage: existingState.Lineage,
+			})
+		}
+
+		alreadyPushed = existingState.Serial >= migration.state.Serial
+	}
+
+	if alreadyPushed {
+		opts.Logger.Infof("The new %s backend already has the state (serial %d), skipping push", migration.newBackend.Backend, existingState.Serial)
+	} else {
+		if err := pushState(ctx, opts, migration.rawState); err != nil {
+			return err
+		}
+
+		migratedState, err := pullState(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		if migratedState == nil || migratedState.Serial != migration.state.Serial || migratedState.Lineage != migration.state.Lineage {
+			return errors.New(BackendMigrationVerificationError{
+				UnitPath: opts.TerragruntConfigPath,
+				Expected: migration.state,
+				Actual:   migratedState,
+			})
+		}
+
+		opts.Logger.Infof("Migrated state from the %s backend to the %s backend (serial %d, lineage %s)", migration.oldBackend.Backend, migration.newBackend.Backend, migration.state.Serial, migration.state.Lineage)
+	}
+
+	if err := appendBackendMigrationLog(opts, &BackendMigrationLogEntry{
+		Time:          time.Now().UTC(),
+		Unit:          filepath.Dir(opts.TerragruntConfigPath),
+		OldBackend:    migration.oldBackend.Backend,
+		OldConfig:     redactBackendConfig(migration.oldBackend.Config),
+		NewBackend:    migration.newBackend.Backend,
+		NewConfig:     redactBackendConfig(migration.newBackend.Config),
+		Serial:        migration.state.Serial,
+		Lineage:       migration.state.Lineage,
+		AlreadyPushed: alreadyPushed,
+	}); err != nil {
+		return err
+	}
+
+	// Only now that the state is in the new backend and recorded, the state pulled from the old one can be dropped.
+	return migration.removePending(opts)
+}
+
+// redactBackendConfig returns a copy of the given backend config, for the migration log, where the credentials and
+// the sensitive values are redacted.
+func redactBackendConfig(config map[string]any) map[string]any {
+	redacted := make(map[string]any, len(config))
+
+	for key, value := range config {
+		if value != nil && slices.Contains(backendConfigSecretKeys, key) {
+			redacted[key] = sensitive.Redacted
+			continue
+		}
+
+		if nested, ok := value.(map[string]any); ok {
+			redacted[key] = redactBackendConfig(nested)
+			continue
+		}
+
+		redacted[key] = sensitive.RedactValue(value)
+	}
+
+	return redacted
+}
+
+// appendBackendMigrationLog appends the given entry to the migration log next to the terragrunt.hcl of the unit.
+func appendBackendMigrationLog(opts *options.TerragruntOptions, entry *BackendMigrationLogEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	logPath := filepath.Join(filepath.Dir(opts.TerragruntConfigPath), BackendMigrationLogFileName)
+
+	file, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, backendMigrationLogFileMode)
+	if err != nil {
+		return errors.New(err)
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return errors.New(err)
+	}
+
+	if err := file.Close(); err != nil {
+		return errors.New(err)
+	}
+
+	opts.Logger.Debugf("Recorded backend migration in %s", logPath)
+
+	return nil
+}
+
+// pullState returns the state stored in the backend the working dir is initialized with, or nil if there is none.
+func pullState(ctx context.Context, opts *options.TerragruntOptions) (*remote.TerraformState, error) {
+	opts = opts.Clone()
+	opts.Writer = io.Discard
+
+	output, err := tf.RunCommandWithOutput(ctx, opts, tf.CommandNameState, "pull")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(output.Stdout.Bytes()) == 0 {
+		return nil, nil
+	}
+
+	return remote.ParseTerraformState(output.Stdout.Bytes())
+}
+
+// pushState replaces the state stored in the backend the working dir is initialized with by the given state.
+func pushState(ctx context.Context, opts *options.TerragruntOptions, rawState []byte) error {
+	stateFile, err := os.CreateTemp("", "terragrunt-backend-migration-*.tfstate")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	defer func() {
+		if err := os.Remove(stateFile.Name()); err != nil {
+			opts.Logger.Debugf("Failed to remove temporary state file %s: %v", stateFile.Name(), err)
+		}
+	}()
+
+	if _, err := stateFile.Write(rawState); err != nil {
+		stateFile.Close()
+		return errors.New(err)
+	}
+
+	if err := stateFile.Close(); err != nil {
+		return errors.New(err)
+	}
+
+	opts = opts.Clone()
+	opts.Writer = io.Discard
+
+	return tf.RunCommand(ctx, opts, tf.CommandNameState, "push", stateFile.Name())
+}
+
+// withoutNullValues returns a copy of the given backend config without the keys set to null, which OpenTofu/Terraform
+// records for every backend setting that was not configured.
+func withoutNullValues(config map[string]any) map[string]any {
+	result := make(map[string]any, len(config))
+
+	for key, value := range config {
+		if value != nil {
+			result[key] = value
+		}
+	}
+
+	return result
+}
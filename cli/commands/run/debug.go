@@ -9,6 +9,7 @@ import (
 
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/sensitive"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/tf"
 	"github.com/gruntwork-io/terragrunt/util"
@@ -63,7 +64,8 @@ func WriteTerragruntDebugFile(terragruntOptions *options.TerragruntOptions, terr
 
 // terragruntDebugFileContents will return a tfvars file in json format of all the terragrunt rendered variables values
 // that should be set to invoke the terraform module in the same way as terragrunt. Note that this will only include the
-// values of variables that are actually defined in the module.
+// values of variables that are actually defined in the module. The sensitive values are redacted, so they must be set
+// separately.
 func terragruntDebugFileContents(
 	terragruntOptions *options.TerragruntOptions,
 	terragruntConfig *config.TerragruntConfig,
@@ -86,7 +88,7 @@ func terragruntDebugFileContents(
 		// terraform using this file (due to the order in which terraform resolves config sources).
 		switch {
 		case !varIsInEnv && varIsDefined:
-			jsonValuesByKey[varName] = varValue
+			jsonValuesByKey[varName] = sensitive.RedactValue(varValue)
 		case varIsInEnv:
 			terragruntOptions.Logger.Debugf(
 				"WARN: The variable %s was omitted from the debug file because the env var %s is already set.",
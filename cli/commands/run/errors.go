@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/remote"
 )
 
 // Custom error types
@@ -66,3 +67,63 @@ type RunAllDisabledErr struct {
 func (err RunAllDisabledErr) Error() string {
 	return fmt.Sprintf("%s with run-all is disabled: %s", err.command, err.reason)
 }
+
+type BackendMigrationNotSupportedError struct {
+	UnitPath string
+	Backend  string
+}
+
+func (err BackendMigrationNotSupportedError) Error() string {
+	return fmt.Sprintf("Can not migrate the state of %s: reading state from the previous %s backend is not supported. Run init with -migrate-state instead.", err.UnitPath, err.Backend)
+}
+
+type BackendMigrationConflictError struct {
+	UnitPath        string
+	Lineage         string
+	ExistingLineage string
+}
+
+func (err BackendMigrationConflictError) Error() string {
+	return fmt.Sprintf("Can not migrate the state of %s: the new backend already has a different state (lineage %s, expected %s). Refusing to overwrite it.", err.UnitPath, err.ExistingLineage, err.Lineage)
+}
+
+type BackendMigrationVerificationError struct {
+	Expected *remote.TerraformState
+	Actual   *remote.TerraformState
+	UnitPath string
+}
+
+func (err BackendMigrationVerificationError) Error() string {
+	if err.Actual == nil {
+		return fmt.Sprintf("Failed to verify the migrated state of %s: the new backend has no state after the push", err.UnitPath)
+	}
+
+	return fmt.Sprintf("Failed to verify the migrated state of %s: expected serial %d and lineage %s, the new backend has serial %d and lineage %s", err.UnitPath, err.Expected.Serial, err.Expected.Lineage, err.Actual.Serial, err.Actual.Lineage)
+}
+
+type ApplyCancelledError struct {
+	UnitPath string
+}
+
+func (err ApplyCancelledError) Error() string {
+	return fmt.Sprintf("The apply of %s was cancelled", err.UnitPath)
+}
+
+type SavedPlanNotFoundError struct {
+	UnitPath string
+	Path     string
+}
+
+func (err SavedPlanNotFoundError) Error() string {
+	return fmt.Sprintf("Can not apply the saved plan of %s: %s not found. Plan the unit with --out-dir first.", err.UnitPath, err.Path)
+}
+
+type PlanFingerprintMismatchError struct {
+	UnitPath   string
+	PlanFile   string
+	Mismatches []string
+}
+
+func (err PlanFingerprintMismatchError) Error() string {
+	return fmt.Sprintf("Refusing to apply the saved plan %s of %s, it was made from different code: %s changed since it was planned. Plan the unit again.", err.PlanFile, err.UnitPath, strings.Join(err.Mismatches, ", "))
+}
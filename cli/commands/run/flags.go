@@ -3,6 +3,7 @@ package run
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/gruntwork-io/terragrunt/cli/flags"
 	"github.com/gruntwork-io/terragrunt/internal/cli"
@@ -20,14 +21,24 @@ const (
 	TFForwardStdoutFlagName                = "tf-forward-stdout"
 	TFPathFlagName                         = "tf-path"
 	FeatureFlagName                        = "feature"
+	FeatureEnvPrefixFlagName               = "feature-env-prefix"
+	FeatureFileFlagName                    = "feature-file"
+	FeatureURLFlagName                     = "feature-url"
+	FeatureURLHeaderFlagName               = "feature-url-header"
+	FeatureURLTokenFlagName                = "feature-url-token"
 	ParallelismFlagName                    = "parallelism"
+	ParallelismGroupFlagName               = "parallelism-group"
 	InputsDebugFlagName                    = "inputs-debug"
 	UnitsThatIncludeFlagName               = "units-that-include"
 	DependencyFetchOutputFromStateFlagName = "dependency-fetch-output-from-state"
+	DependencyOutputCacheFlagName          = "dependency-output-cache"
+	DependencyOutputCacheTTLFlagName       = "dependency-output-cache-ttl"
+	DependencyOutputCacheDirFlagName       = "dependency-output-cache-dir"
 	UsePartialParseConfigCacheFlagName     = "use-partial-parse-config-cache"
 
 	BackendRequireBootstrapFlagName = "backend-require-bootstrap"
 	DisableBucketUpdateFlagName     = "disable-bucket-update"
+	BackendMigrateFlagName          = "backend-migrate"
 
 	DisableCommandValidationFlagName   = "disable-command-validation"
 	AuthProviderCmdFlagName            = "auth-provider-cmd"
@@ -55,6 +66,16 @@ const (
 	QueueIncludeExternalFlagName     = "queue-include-external"
 	QueueStrictIncludeFlagName       = "queue-strict-include"
 	QueueIncludeUnitsReadingFlagName = "queue-include-units-reading"
+	QueueIncludeChangedSinceFlagName = "queue-include-changed-since"
+	QueueCriticalPathFlagName        = "queue-critical-path"
+	QueueDurationsFileFlagName       = "queue-durations-file"
+
+	EventStreamFileFlagName   = "event-stream-file"
+	EventStreamSocketFlagName = "event-stream-socket"
+	TUIFlagName               = "tui"
+
+	FailFastFlagName          = "fail-fast"
+	FailFastInterruptFlagName = "fail-fast-interrupt"
 
 	// Terragrunt Provider Cache related flags.
 
@@ -74,8 +95,18 @@ const (
 
 	// `run-all/-all` related flags.
 
-	OutDirFlagName     = "out-dir"
-	JSONOutDirFlagName = "json-out-dir"
+	OutDirFlagName       = "out-dir"
+	JSONOutDirFlagName   = "json-out-dir"
+	FromPlanDirFlagName  = "from-plan-dir"
+	ReportFileFlagName   = "report-file"
+	ReportFormatFlagName = "report-format"
+	ResumeFlagName       = "resume"
+	RunIDFlagName        = "run-id"
+	RunStateDirFlagName  = "run-state-dir"
+
+	PlanSummaryFlagName       = "plan-summary"
+	PlanSummaryFileFlagName   = "plan-summary-file"
+	PlanSummaryFormatFlagName = "plan-summary-format"
 
 	// `graph/-graph` related flags.
 
@@ -167,6 +198,90 @@ func NewFlags(opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
 		},
 			flags.WithDeprecatedNames(terragruntPrefix.FlagNames(DeprecatedJSONOutDirFlagName), terragruntPrefixControl)),
 
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FromPlanDirFlagName,
+			EnvVars:     tgPrefix.EnvVars(FromPlanDirFlagName),
+			Destination: &opts.FromPlanDir,
+			Usage:       "Directory of the plans saved with --out-dir to apply, refusing the plans made from different code, inputs or OpenTofu/Terraform version.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        ReportFileFlagName,
+			EnvVars:     tgPrefix.EnvVars(ReportFileFlagName),
+			Destination: &opts.RunReportFile,
+			Usage:       "Path of the file to write a report of the run of every unit to.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        EventStreamFileFlagName,
+			EnvVars:     tgPrefix.EnvVars(EventStreamFileFlagName),
+			Destination: &opts.EventStreamFile,
+			Usage:       "Path of the file to write the events of every unit to, as newline-delimited JSON.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        EventStreamSocketFlagName,
+			EnvVars:     tgPrefix.EnvVars(EventStreamSocketFlagName),
+			Destination: &opts.EventStreamSocket,
+			Usage:       "Path of the unix socket to write the events of every unit to, as newline-delimited JSON.",
+		}),
+
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        TUIFlagName,
+			EnvVars:     tgPrefix.EnvVars(TUIFlagName),
+			Destination: &opts.TUI,
+			Usage:       "Show the progress of every unit in an interactive terminal UI, where the output of each unit can be viewed.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        ReportFormatFlagName,
+			EnvVars:     tgPrefix.EnvVars(ReportFormatFlagName),
+			Destination: &opts.RunReportFormat,
+			Usage:       "Format of the run report. Valid values: json, junit. Derived from the extension of the report file by default.",
+		}),
+
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        PlanSummaryFlagName,
+			EnvVars:     tgPrefix.EnvVars(PlanSummaryFlagName),
+			Destination: &opts.PlanSummary,
+			Usage:       "Print a table of the resources each unit plans to create, update, replace and destroy. Requires --json-out-dir.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        PlanSummaryFileFlagName,
+			EnvVars:     tgPrefix.EnvVars(PlanSummaryFileFlagName),
+			Destination: &opts.PlanSummaryFile,
+			Usage:       "Path of the file to write the summary of the changes planned in every unit to. Requires --json-out-dir.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        PlanSummaryFormatFlagName,
+			EnvVars:     tgPrefix.EnvVars(PlanSummaryFormatFlagName),
+			Destination: &opts.PlanSummaryFormat,
+			Usage:       "Format of the plan summary file. Valid values: json, markdown. Derived from the extension of the summary file by default.",
+		}),
+
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        ResumeFlagName,
+			EnvVars:     tgPrefix.EnvVars(ResumeFlagName),
+			Destination: &opts.Resume,
+			Usage:       "Resume the previous attempt of the run, only running the units that failed or did not run.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        RunIDFlagName,
+			EnvVars:     tgPrefix.EnvVars(RunIDFlagName),
+			Destination: &opts.RunID,
+			Usage:       "ID of the run, used to resume it. Derived from the working dir and the command by default.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        RunStateDirFlagName,
+			EnvVars:     tgPrefix.EnvVars(RunStateDirFlagName),
+			Destination: &opts.RunStateDir,
+			Usage:       "Directory to persist the completion state of the units of runs in, used to resume them.",
+		}),
+
 		// `graph/-grpah` related flags.
 
 		flags.NewFlag(&cli.GenericFlag[string]{
@@ -305,6 +420,20 @@ func NewFlags(opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
 		},
 			flags.WithDeprecatedNames(terragruntPrefix.FlagNames(DeprecatedIgnoreDependencyErrorsFlagName), terragruntPrefixControl)),
 
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        FailFastFlagName,
+			EnvVars:     tgPrefix.EnvVars(FailFastFlagName),
+			Destination: &opts.FailFast,
+			Usage:       "Cancel the Units that did not start yet as soon as a Unit fails.",
+		}),
+
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        FailFastInterruptFlagName,
+			EnvVars:     tgPrefix.EnvVars(FailFastInterruptFlagName),
+			Destination: &opts.FailFastInterrupt,
+			Usage:       "Same as --fail-fast, but also interrupt the Units that are running.",
+		}),
+
 		flags.NewFlag(&cli.BoolFlag{
 			Name:        QueueIgnoreDAGOrderFlagName,
 			EnvVars:     tgPrefix.EnvVars(QueueIgnoreDAGOrderFlagName),
@@ -337,6 +466,27 @@ func NewFlags(opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
 		},
 			flags.WithDeprecatedNames(terragruntPrefix.FlagNames(DeprecatedParallelismFlagName), terragruntPrefixControl)),
 
+		flags.NewFlag(&cli.MapFlag[string, int]{
+			Name:        ParallelismGroupFlagName,
+			EnvVars:     tgPrefix.EnvVars(ParallelismGroupFlagName),
+			Destination: &opts.ParallelismGroups,
+			Usage:       "Parallelism of a concurrency group for --all commands, in the form group=limit. Units join a group with their scheduling block.",
+		}),
+
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        QueueCriticalPathFlagName,
+			EnvVars:     tgPrefix.EnvVars(QueueCriticalPathFlagName),
+			Destination: &opts.QueueCriticalPath,
+			Usage:       "Start first the units with the longest chain of dependents for --all commands, weighted by the durations of previous runs.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        QueueDurationsFileFlagName,
+			EnvVars:     tgPrefix.EnvVars(QueueDurationsFileFlagName),
+			Destination: &opts.QueueDurationsFile,
+			Usage:       "File to record the durations of the units in, used by --queue-critical-path.",
+		}),
+
 		flags.NewFlag(&cli.GenericFlag[string]{
 			Name:        QueueExcludesFileFlagName,
 			EnvVars:     tgPrefix.EnvVars(QueueExcludesFileFlagName),
@@ -385,6 +535,27 @@ func NewFlags(opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
 		},
 			flags.WithDeprecatedNames(terragruntPrefix.FlagNames(DeprecatedFetchDependencyOutputFromStateFlagName), terragruntPrefixControl)),
 
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        DependencyOutputCacheFlagName,
+			EnvVars:     tgPrefix.EnvVars(DependencyOutputCacheFlagName),
+			Destination: &opts.DependencyOutputCache,
+			Usage:       "Enables the persistent on-disk cache of dependency outputs, shared across Terragrunt invocations.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[time.Duration]{
+			Name:        DependencyOutputCacheTTLFlagName,
+			EnvVars:     tgPrefix.EnvVars(DependencyOutputCacheTTLFlagName),
+			Destination: &opts.DependencyOutputCacheTTL,
+			Usage:       "How long entries of the persistent dependency output cache are considered valid.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        DependencyOutputCacheDirFlagName,
+			EnvVars:     tgPrefix.EnvVars(DependencyOutputCacheDirFlagName),
+			Destination: &opts.DependencyOutputCacheDir,
+			Usage:       "The directory where the persistent dependency output cache is stored. Defaults to a directory in the Terragrunt cache dir.",
+		}),
+
 		flags.NewFlag(&cli.BoolFlag{
 			Name:        TFForwardStdoutFlagName,
 			EnvVars:     tgPrefix.EnvVars(TFForwardStdoutFlagName),
@@ -427,6 +598,13 @@ func NewFlags(opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
 		},
 			flags.WithDeprecatedNames(terragruntPrefix.FlagNames(DeprecatedUnitsReadingFlagName), terragruntPrefixControl)),
 
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        QueueIncludeChangedSinceFlagName,
+			EnvVars:     tgPrefix.EnvVars(QueueIncludeChangedSinceFlagName),
+			Destination: &opts.QueueIncludeChangedSince,
+			Usage:       "If flag is set, 'run --all' will only run the command against Terragrunt units affected by the files changed since the specified git ref, and the units that depend on them.",
+		}),
+
 		flags.NewFlag(&cli.BoolFlag{
 			Name:        BackendRequireBootstrapFlagName,
 			EnvVars:     tgPrefix.EnvVars(BackendRequireBootstrapFlagName),
@@ -443,6 +621,13 @@ func NewFlags(opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
 		},
 			flags.WithDeprecatedNames(terragruntPrefix.FlagNames(DeprecatedDisableBucketUpdateFlagName), terragruntPrefixControl)),
 
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        BackendMigrateFlagName,
+			EnvVars:     tgPrefix.EnvVars(BackendMigrateFlagName),
+			Destination: &opts.BackendMigrate,
+			Usage:       "When the remote_state configuration changes, migrate the state from the previous backend to the new one.",
+		}),
+
 		flags.NewFlag(&cli.BoolFlag{
 			Name:        DisableCommandValidationFlagName,
 			EnvVars:     tgPrefix.EnvVars(DisableCommandValidationFlagName),
@@ -532,6 +717,41 @@ func NewFlags(opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
 		},
 			flags.WithDeprecatedNames(terragruntPrefix.FlagNames(DeprecatedFeatureFlagName), terragruntPrefixControl)),
 
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FeatureEnvPrefixFlagName,
+			EnvVars:     tgPrefix.EnvVars(FeatureEnvPrefixFlagName),
+			Destination: &opts.FeatureFlagEnvPrefix,
+			Usage:       "Set feature flags from the environment variables with the given prefix.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FeatureFileFlagName,
+			EnvVars:     tgPrefix.EnvVars(FeatureFileFlagName),
+			Destination: &opts.FeatureFlagFile,
+			Usage:       "Set feature flags from the given JSON or YAML file.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FeatureURLFlagName,
+			EnvVars:     tgPrefix.EnvVars(FeatureURLFlagName),
+			Destination: &opts.FeatureFlagURL,
+			Usage:       "Set feature flags from the JSON object returned by the given HTTP endpoint.",
+		}),
+
+		flags.NewFlag(&cli.SliceFlag[string]{
+			Name:        FeatureURLHeaderFlagName,
+			EnvVars:     tgPrefix.EnvVars(FeatureURLHeaderFlagName),
+			Destination: &opts.FeatureFlagURLHeaders,
+			Usage:       "Header of the requests to the feature flag endpoint of --feature-url, in the format \"Name: Value\".",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FeatureURLTokenFlagName,
+			EnvVars:     tgPrefix.EnvVars(FeatureURLTokenFlagName),
+			Destination: &opts.FeatureFlagURLToken,
+			Usage:       "Bearer token to authenticate to the feature flag endpoint of --feature-url.",
+		}),
+
 		// Terragrunt engine flags.
 
 		flags.NewFlag(&cli.BoolFlag{
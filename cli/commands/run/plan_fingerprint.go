@@ -0,0 +1,281 @@
+package run
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// planFingerprintFilePerm is the mode of the fingerprints written next to the saved plans.
+const planFingerprintFilePerm = 0644
+
+// PlanFingerprint records what a saved plan was made from, so that it is only applied to the same code.
+type PlanFingerprint struct {
+	// SourceHash is the hash of the OpenTofu/Terraform code in the working dir of the unit, including the dependency
+	// lock file, but not the generated files.
+	SourceHash string `json:"source_hash"`
+	// GeneratedFiles are the hashes of the files generated by the `generate` blocks and the `remote_state` block, by
+	// path relative to the working dir.
+	GeneratedFiles map[string]string `json:"generated_files"`
+	// InputsHash is the hash of the inputs of the unit.
+	InputsHash string `json:"inputs_hash"`
+	// TerraformVersion is the implementation and version of OpenTofu/Terraform the plan was made with.
+	TerraformVersion string `json:"terraform_version"`
+}
+
+// RunTerraformWithPlanFingerprint runs the terraform command, recording the fingerprint of the plans saved to
+// --out-dir next to them, and refusing to apply the plans of --from-plan-dir whose fingerprint differs from the
+// fingerprint of the unit to apply.
+func RunTerraformWithPlanFingerprint(ctx context.Context, terragruntOptions *options.TerragruntOptions, terragruntConfig *config.TerragruntConfig) error {
+	args := terragruntOptions.TerraformCliArgs
+
+	switch {
+	case terragruntOptions.FromPlanDir != "" && args.First() == tf.CommandNameApply:
+		if err := checkPlanFingerprint(terragruntOptions, terragruntConfig); err != nil {
+			return err
+		}
+	case terragruntOptions.OutputFolder != "" && args.First() == tf.CommandNamePlan && flagValue(args, flagNameOut) != "":
+		if err := RunTerraformWithPolicy(ctx, terragruntOptions, terragruntConfig.Policy); err != nil {
+			return err
+		}
+
+		return recordPlanFingerprint(terragruntOptions, terragruntConfig, flagValue(args, flagNameOut))
+	}
+
+	return RunTerraformWithPolicy(ctx, terragruntOptions, terragruntConfig.Policy)
+}
+
+// recordPlanFingerprint writes the fingerprint of the unit next to the given saved plan.
+func recordPlanFingerprint(terragruntOptions *options.TerragruntOptions, terragruntConfig *config.TerragruntConfig, planFile string) error {
+	fingerprint, err := NewPlanFingerprint(terragruntOptions, terragruntConfig)
+	if err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(fingerprint, "", "  ")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	path := planFingerprintFile(terragruntOptions, planFile)
+
+	terragruntOptions.Logger.Debugf("Recording the fingerprint of the plan %s to %s", planFile, path)
+
+	if err := os.WriteFile(path, content, planFingerprintFilePerm); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// checkPlanFingerprint checks that the saved plan to apply was made from the same code as the unit.
+func checkPlanFingerprint(terragruntOptions *options.TerragruntOptions, terragruntConfig *config.TerragruntConfig) error {
+	unitPath := filepath.Dir(terragruntOptions.TerragruntConfigPath)
+
+	planFile := savedPlanFile(terragruntOptions)
+	if planFile == "" {
+		return errors.New(SavedPlanNotFoundError{UnitPath: unitPath, Path: terragruntOptions.TerraformCliArgs.Last()})
+	}
+
+	path := planFingerprintFile(terragruntOptions, planFile)
+
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return errors.New(SavedPlanNotFoundError{UnitPath: unitPath, Path: path})
+	} else if err != nil {
+		return errors.New(err)
+	}
+
+	var recorded PlanFingerprint
+	if err := json.Unmarshal(content, &recorded); err != nil {
+		return errors.Errorf("failed to read the fingerprint of the plan %s: %w", planFile, err)
+	}
+
+	fingerprint, err := NewPlanFingerprint(terragruntOptions, terragruntConfig)
+	if err != nil {
+		return err
+	}
+
+	if mismatches := recorded.Mismatches(fingerprint); len(mismatches) > 0 {
+		return errors.New(PlanFingerprintMismatchError{UnitPath: unitPath, PlanFile: planFile, Mismatches: mismatches})
+	}
+
+	terragruntOptions.Logger.Debugf("The plan %s was made from the same code, applying it", planFile)
+
+	return nil
+}
+
+// planFingerprintFile returns the path of the fingerprint of the given saved plan.
+func planFingerprintFile(terragruntOptions *options.TerragruntOptions, planFile string) string {
+	if !filepath.IsAbs(planFile) {
+		planFile = filepath.Join(terragruntOptions.WorkingDir, planFile)
+	}
+
+	return filepath.Join(filepath.Dir(planFile), tf.TerraformPlanFingerprintFile)
+}
+
+// NewPlanFingerprint returns the fingerprint of the unit, once its code is downloaded and generated in the working dir.
+func NewPlanFingerprint(terragruntOptions *options.TerragruntOptions, terragruntConfig *config.TerragruntConfig) (*PlanFingerprint, error) {
+	fingerprint := &PlanFingerprint{GeneratedFiles: map[string]string{}}
+
+	generatedFiles := generatedFilePaths(terragruntConfig)
+
+	for _, path := range generatedFiles {
+		hash, err := util.FileSHA256(filepath.Join(terragruntOptions.WorkingDir, path))
+		if err != nil {
+			// Files may not be generated, e.g. when disabled.
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		fingerprint.GeneratedFiles[filepath.ToSlash(path)] = hex.EncodeToString(hash)
+	}
+
+	sourceHash, err := hashSource(terragruntOptions, generatedFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint.SourceHash = sourceHash
+
+	inputs, err := json.Marshal(terragruntConfig.Inputs)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	inputsHash := sha256.Sum256(inputs)
+	fingerprint.InputsHash = hex.EncodeToString(inputsHash[:])
+
+	if terragruntOptions.TerraformVersion != nil {
+		fingerprint.TerraformVersion = fmt.Sprintf("%s %s", terragruntOptions.TerraformImplementation, terragruntOptions.TerraformVersion)
+	}
+
+	return fingerprint, nil
+}
+
+// Mismatches returns what differs between the fingerprints.
+func (fingerprint *PlanFingerprint) Mismatches(other *PlanFingerprint) []string {
+	var mismatches []string
+
+	if fingerprint.SourceHash != other.SourceHash {
+		mismatches = append(mismatches, "the source code")
+	}
+
+	paths := slices.AppendSeq(slices.Collect(maps.Keys(fingerprint.GeneratedFiles)), maps.Keys(other.GeneratedFiles))
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+
+	for _, path := range paths {
+		if fingerprint.GeneratedFiles[path] != other.GeneratedFiles[path] {
+			mismatches = append(mismatches, "the generated file "+path)
+		}
+	}
+
+	if fingerprint.InputsHash != other.InputsHash {
+		mismatches = append(mismatches, "the inputs")
+	}
+
+	if fingerprint.TerraformVersion != other.TerraformVersion {
+		mismatches = append(mismatches, fmt.Sprintf("the OpenTofu/Terraform version (planned with %q, applying with %q)", fingerprint.TerraformVersion, other.TerraformVersion))
+	}
+
+	return mismatches
+}
+
+// generatedFilePaths returns the paths of the files generated in the working dir, relative to it.
+func generatedFilePaths(terragruntConfig *config.TerragruntConfig) []string {
+	var paths []string
+
+	for _, generateConfig := range terragruntConfig.GenerateConfigs {
+		paths = append(paths, filepath.Clean(generateConfig.Path))
+	}
+
+	if terragruntConfig.RemoteState != nil && terragruntConfig.RemoteState.Generate != nil {
+		paths = append(paths, filepath.Clean(terragruntConfig.RemoteState.Generate.Path))
+	}
+
+	return util.RemoveDuplicatesFromList(paths)
+}
+
+// hashSource hashes the files in the working dir, except for the given generated files, the data dirs of
+// OpenTofu/Terraform, including those of nested modules, and the files and dirs Terragrunt writes there, which change
+// from one run to another.
+func hashSource(terragruntOptions *options.TerragruntOptions, generatedFiles []string) (string, error) {
+	workingDir := terragruntOptions.WorkingDir
+	dataDir := filepath.Clean(terragruntOptions.DataDir())
+	dataDirName := filepath.Base(dataDir)
+	hash := sha256.New()
+
+	err := filepath.WalkDir(workingDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if path == workingDir {
+				return nil
+			}
+
+			if path == dataDir || entry.Name() == dataDirName || strings.HasPrefix(entry.Name(), ".terragrunt") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		relPath, err := filepath.Rel(workingDir, path)
+		if err != nil {
+			return err
+		}
+
+		if slices.Contains(generatedFiles, relPath) || isUnfingerprintedFile(entry.Name()) {
+			return nil
+		}
+
+		// Follow symlinks to files, but not to dirs.
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			return err
+		}
+
+		fileHash, err := util.FileSHA256(path)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(hash, "%s %x\n", filepath.ToSlash(relPath), fileHash)
+
+		return nil
+	})
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// isUnfingerprintedFile returns true for the files Terragrunt writes to the working dir, such as the source manifests,
+// the null values var file and the plan files.
+func isUnfingerprintedFile(name string) bool {
+	if strings.HasPrefix(name, ".terragrunt") {
+		return true
+	}
+
+	return slices.Contains([]string{TerragruntTFVarsFile, tf.TerraformPlanFile, tf.TerraformPlanJSONFile, tf.TerraformPlanFingerprintFile}, name)
+}
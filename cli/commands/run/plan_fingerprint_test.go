@@ -0,0 +1,117 @@
+package run_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/codegen"
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/hashicorp/go-version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunTerraformWithPlanFingerprint(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+	planDir := t.TempDir()
+	planFile := filepath.Join(planDir, "app", tf.TerraformPlanFile)
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(planFile), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, "main.tf"), []byte(`resource "null_resource" "app" {}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, "backend.tf"), []byte(`terraform { backend "s3" {} }`), 0644))
+
+	terragruntConfig := &config.TerragruntConfig{
+		Inputs:          map[string]any{"name": "app"},
+		GenerateConfigs: map[string]codegen.GenerateConfig{"backend": {Path: "backend.tf"}},
+	}
+
+	var commands []cli.Args
+
+	ctx := tf.ContextWithTerraformCommandHook(context.Background(), func(_ context.Context, _ *options.TerragruntOptions, args cli.Args) (*util.CmdOutput, error) {
+		commands = append(commands, args)
+
+		if args.First() == tf.CommandNamePlan {
+			return &util.CmdOutput{}, os.WriteFile(planFile, []byte("plan"), 0644)
+		}
+
+		return &util.CmdOutput{}, nil
+	})
+
+	newOptions := func(terraformVersion string, args ...string) *options.TerragruntOptions {
+		opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, config.DefaultTerragruntConfigPath))
+		require.NoError(t, err)
+
+		opts.WorkingDir = workingDir
+		opts.TerraformCliArgs = args
+		opts.TerraformImplementation = options.OpenTofuImpl
+		opts.TerraformVersion = version.Must(version.NewVersion(terraformVersion))
+
+		return opts
+	}
+
+	apply := func(terraformVersion string) error {
+		commands = nil
+
+		opts := newOptions(terraformVersion, tf.CommandNameApply, "-input=false", planFile)
+		opts.FromPlanDir = planDir
+
+		return run.RunTerraformWithPlanFingerprint(ctx, opts, terragruntConfig)
+	}
+
+	opts := newOptions("1.9.0", tf.CommandNamePlan, "-input=false", "-out="+planFile)
+	opts.OutputFolder = planDir
+
+	require.NoError(t, run.RunTerraformWithPlanFingerprint(ctx, opts, terragruntConfig))
+	require.FileExists(t, filepath.Join(planDir, "app", tf.TerraformPlanFingerprintFile))
+
+	require.NoError(t, apply("1.9.0"))
+	assert.Equal(t, []cli.Args{{tf.CommandNameApply, "-input=false", planFile}}, commands)
+
+	// Files written by Terragrunt and OpenTofu/Terraform are not part of the code the plan is made from.
+	require.NoError(t, os.MkdirAll(filepath.Join(workingDir, ".terraform"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, ".terraform", "terraform.tfstate"), []byte("{}"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, run.NullTFVarsFile), []byte("{}"), 0644))
+	require.NoError(t, apply("1.9.0"))
+
+	// Nor are the data dirs of nested modules, and the dirs Terragrunt writes, such as the generated stacks.
+	for _, dir := range []string{filepath.Join("modules", "vpc", ".terraform"), filepath.Join("modules", ".terragrunt-stack")} {
+		require.NoError(t, os.MkdirAll(filepath.Join(workingDir, dir), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(workingDir, dir, "terraform.tfstate"), []byte("{}"), 0644))
+	}
+
+	require.NoError(t, apply("1.9.0"))
+
+	info, err := os.Stat(filepath.Join(planDir, "app", tf.TerraformPlanFingerprintFile))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, "main.tf"), []byte(`resource "null_resource" "web" {}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, "backend.tf"), []byte(`terraform { backend "gcs" {} }`), 0644))
+	terragruntConfig.Inputs["name"] = "web"
+
+	err = apply("1.10.0")
+
+	var mismatchErr run.PlanFingerprintMismatchError
+	require.True(t, errors.As(err, &mismatchErr), "unexpected error: %v", err)
+	assert.Equal(t, []string{
+		"the source code",
+		"the generated file backend.tf",
+		"the inputs",
+		`the OpenTofu/Terraform version (planned with "tofu 1.9.0", applying with "tofu 1.10.0")`,
+	}, mismatchErr.Mismatches)
+	assert.Empty(t, commands, "the mismatched plan must not be applied")
+
+	require.NoError(t, os.Remove(planFile))
+	require.ErrorAs(t, apply("1.9.0"), &run.SavedPlanNotFoundError{})
+	assert.Empty(t, commands)
+}
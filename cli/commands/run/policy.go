@@ -0,0 +1,238 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/policy"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/shell"
+	"github.com/gruntwork-io/terragrunt/tf"
+)
+
+const (
+	// policyPlanFile is the plan made in the working dir to check `apply` and `destroy` run without a saved plan.
+	policyPlanFile = ".terragrunt-policy.tfplan"
+	// policyJSONPlanFile is the JSON representation of the checked plan, passed to the external policy commands. It is
+	// written to a temporary dir, outside the source tree, and is only readable by the current user.
+	policyJSONPlanFile = "tfplan.json"
+	policyJSONPlanPerm = 0600
+
+	flagNameOut         = "-out"
+	flagNameAutoApprove = "-auto-approve"
+)
+
+var (
+	// planOnlyFlags are the flags of `apply` that set how the changes are planned, which can not be used when applying
+	// a saved plan.
+	planOnlyFlags = []string{"-var", "-var-file", "-target", "-replace", "-refresh", "-refresh-only", tf.FlagNameDestroy}
+	// applyOnlyFlags are the flags of `apply` that `plan` does not support.
+	applyOnlyFlags = []string{flagNameAutoApprove, "-backup", "-state-out"}
+	// valueFlags are the flags of `apply` whose value may be passed as a separate argument.
+	valueFlags = []string{"-var", "-var-file", "-target", "-replace", "-backup", "-state-out", flagNameOut}
+)
+
+// RunTerraformWithPolicy runs the terraform command, checking the plan against the `policy` block of the unit before it
+// is applied. The plan is checked after `plan -out`, before `apply` of a saved plan, and `apply` and `destroy` run
+// without a saved plan first plan the changes, check the plan and then apply it.
+func RunTerraformWithPolicy(ctx context.Context, terragruntOptions *options.TerragruntOptions, policyConfig *config.PolicyConfig) error {
+	if policyConfig == nil {
+		return RunTerraformWithRetry(ctx, terragruntOptions)
+	}
+
+	args := terragruntOptions.TerraformCliArgs
+
+	switch args.First() {
+	case tf.CommandNamePlan:
+		if err := RunTerraformWithRetry(ctx, terragruntOptions); err != nil {
+			return err
+		}
+
+		if planFile := flagValue(args, flagNameOut); planFile != "" {
+			return checkPlanPolicy(ctx, terragruntOptions, policyConfig, planFile)
+		}
+
+		terragruntOptions.Logger.Debugf("Not checking the plan against the policies, it is not saved with %s", flagNameOut)
+
+		return nil
+	case tf.CommandNameApply, tf.CommandNameDestroy:
+		if planFile := savedPlanFile(terragruntOptions); planFile != "" && args.First() == tf.CommandNameApply {
+			if err := checkPlanPolicy(ctx, terragruntOptions, policyConfig, planFile); err != nil {
+				return err
+			}
+
+			return RunTerraformWithRetry(ctx, terragruntOptions)
+		}
+
+		return applyCheckedPlan(ctx, terragruntOptions, policyConfig)
+	}
+
+	return RunTerraformWithRetry(ctx, terragruntOptions)
+}
+
+// applyCheckedPlan plans the changes of `apply` or `destroy` to a file, checks the plan against the policies and applies
+// it once approved.
+func applyCheckedPlan(ctx context.Context, terragruntOptions *options.TerragruntOptions, policyConfig *config.PolicyConfig) error {
+	args := terragruntOptions.TerraformCliArgs
+	planFile := filepath.Join(terragruntOptions.WorkingDir, policyPlanFile)
+
+	defer func() {
+		if err := os.Remove(planFile); err != nil && !os.IsNotExist(err) {
+			terragruntOptions.Logger.Debugf("Failed to remove the plan file %s: %v", planFile, err)
+		}
+	}()
+
+	planArgs := cli.Args{tf.CommandNamePlan, flagNameOut + "=" + planFile}
+	if args.First() == tf.CommandNameDestroy {
+		planArgs = append(planArgs, tf.FlagNameDestroy)
+	}
+
+	planArgs = append(planArgs, withoutFlags(args.Tail(), applyOnlyFlags)...)
+
+	planOptions := terragruntOptions.Clone()
+	planOptions.TerraformCliArgs = planArgs
+
+	if err := RunTerraformWithRetry(ctx, planOptions); err != nil {
+		return err
+	}
+
+	if err := checkPlanPolicy(ctx, terragruntOptions, policyConfig, planFile); err != nil {
+		return err
+	}
+
+	// Applying a saved plan does not ask for approval, so ask for it like `apply` would.
+	if !slices.Contains(args, flagNameAutoApprove) {
+		approved, err := shell.PromptUserForYesNo(ctx, "Do you want to apply the plan of "+terragruntOptions.WorkingDir+"?", terragruntOptions)
+		if err != nil {
+			return err
+		}
+
+		if !approved {
+			return errors.New(ApplyCancelledError{UnitPath: terragruntOptions.WorkingDir})
+		}
+	}
+
+	applyOptions := terragruntOptions.Clone()
+	applyOptions.TerraformCliArgs = append(append(cli.Args{tf.CommandNameApply}, withoutFlags(args.Tail(), planOnlyFlags)...), planFile)
+
+	return RunTerraformWithRetry(ctx, applyOptions)
+}
+
+// checkPlanPolicy checks the given saved plan against the policies of the unit.
+func checkPlanPolicy(ctx context.Context, terragruntOptions *options.TerragruntOptions, policyConfig *config.PolicyConfig, planFile string) error {
+	terragruntOptions.Logger.Debugf("Checking the plan %s against the policies", planFile)
+
+	jsonOptions := terragruntOptions.Clone()
+	stdout := bytes.Buffer{}
+	jsonOptions.ForwardTFStdout = true
+	jsonOptions.JSONLogFormat = false
+	jsonOptions.Writer = &stdout
+
+	if _, err := tf.RunCommandWithOutput(ctx, jsonOptions, tf.CommandNameShow, tf.FlagNameJSON, planFile); err != nil {
+		return err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "terragrunt-policy-*")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			terragruntOptions.Logger.Debugf("Failed to remove the JSON plan dir %s: %v", tmpDir, err)
+		}
+	}()
+
+	jsonPlanFile := filepath.Join(tmpDir, policyJSONPlanFile)
+	if err := os.WriteFile(jsonPlanFile, stdout.Bytes(), policyJSONPlanPerm); err != nil {
+		return errors.New(err)
+	}
+
+	plan, err := policy.ReadPlan(jsonPlanFile)
+	if err != nil {
+		return err
+	}
+
+	return policy.Evaluate(ctx, plan, policyEvaluators(terragruntOptions, policyConfig)...)
+}
+
+// policyEvaluators returns the evaluators of the policies set in the `policy` block.
+func policyEvaluators(terragruntOptions *options.TerragruntOptions, policyConfig *config.PolicyConfig) []policy.Evaluator {
+	var evaluators []policy.Evaluator
+
+	if len(policyConfig.DenyDestroyTagged) > 0 {
+		evaluators = append(evaluators, &policy.DenyDestroyTagged{Tags: policyConfig.DenyDestroyTagged})
+	}
+
+	if len(policyConfig.DenyDestroyTypes) > 0 {
+		evaluators = append(evaluators, &policy.DenyDestroyTypes{Types: policyConfig.DenyDestroyTypes})
+	}
+
+	if len(policyConfig.Command) > 0 {
+		evaluators = append(evaluators, policy.NewCommand(terragruntOptions, policyConfig.Command))
+	}
+
+	return evaluators
+}
+
+// savedPlanFile returns the plan file `apply` is run with, if any. The plan file is the last argument, and must exist.
+func savedPlanFile(terragruntOptions *options.TerragruntOptions) string {
+	args := terragruntOptions.TerraformCliArgs
+	if args.Len() < 2 || strings.HasPrefix(args.Last(), "-") { //nolint:mnd
+		return ""
+	}
+
+	planFile := args.Last()
+	if !filepath.IsAbs(planFile) {
+		planFile = filepath.Join(terragruntOptions.WorkingDir, planFile)
+	}
+
+	if _, err := os.Stat(planFile); err != nil {
+		return ""
+	}
+
+	return args.Last()
+}
+
+// flagValue returns the value of the given flag, passed either as `-flag=value` or `-flag value`.
+func flagValue(args cli.Args, name string) string {
+	for i, arg := range args {
+		if value, ok := strings.CutPrefix(arg, name+"="); ok {
+			return value
+		}
+
+		if arg == name && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+
+	return ""
+}
+
+// withoutFlags returns the args without the given flags and their values.
+func withoutFlags(args cli.Args, names []string) cli.Args {
+	var filtered cli.Args
+
+	for i := 0; i < len(args); i++ {
+		name, _, hasValue := strings.Cut(args[i], "=")
+
+		if !slices.Contains(names, name) {
+			filtered = append(filtered, args[i])
+			continue
+		}
+
+		if !hasValue && slices.Contains(valueFlags, name) {
+			// Skip the value passed as a separate argument.
+			i++
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,105 @@
+package run_test
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/policy"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runWithPolicyTest runs the given command with the policy, recording the commands run. The plans destroy a database
+// tagged `protected = true`.
+func runWithPolicyTest(t *testing.T, policyConfig *config.PolicyConfig, args ...string) ([]cli.Args, error) {
+	t.Helper()
+
+	workingDir := t.TempDir()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, config.DefaultTerragruntConfigPath))
+	require.NoError(t, err)
+
+	opts.WorkingDir = workingDir
+	opts.TerraformCliArgs = args
+	opts.NonInteractive = true
+
+	plan, err := json.Marshal(map[string]any{
+		"resource_changes": []map[string]any{{
+			"address": "aws_db_instance.main",
+			"mode":    "managed",
+			"type":    "aws_db_instance",
+			"change": map[string]any{
+				"actions": []string{"delete"},
+				"before":  map[string]any{"tags": map[string]string{"protected": "true"}},
+			},
+		}},
+	})
+	require.NoError(t, err)
+
+	var commands []cli.Args
+
+	ctx := tf.ContextWithTerraformCommandHook(context.Background(), func(_ context.Context, opts *options.TerragruntOptions, args cli.Args) (*util.CmdOutput, error) {
+		commands = append(commands, args)
+
+		if args.First() == tf.CommandNameShow {
+			_, err := opts.Writer.Write(plan)
+			return &util.CmdOutput{}, err
+		}
+
+		return &util.CmdOutput{}, nil
+	})
+
+	return commands, run.RunTerraformWithPolicy(ctx, opts, policyConfig)
+}
+
+func TestRunTerraformWithPolicyApply(t *testing.T) {
+	t.Parallel()
+
+	commands, err := runWithPolicyTest(t, &config.PolicyConfig{DenyDestroyTypes: []string{"aws_s3_bucket"}}, tf.CommandNameDestroy, "-auto-approve", "-var-file=prod.tfvars", "-input=false")
+	require.NoError(t, err)
+	require.Len(t, commands, 3)
+
+	planFile := commands[0][1][len("-out="):]
+
+	assert.Equal(t, cli.Args{tf.CommandNamePlan, "-out=" + planFile, tf.FlagNameDestroy, "-var-file=prod.tfvars", "-input=false"}, commands[0])
+	assert.Equal(t, cli.Args{tf.CommandNameShow, tf.FlagNameJSON, planFile}, commands[1])
+	assert.Equal(t, cli.Args{tf.CommandNameApply, "-auto-approve", "-input=false", planFile}, commands[2])
+}
+
+func TestRunTerraformWithPolicyViolations(t *testing.T) {
+	t.Parallel()
+
+	commands, err := runWithPolicyTest(t, &config.PolicyConfig{DenyDestroyTagged: map[string]string{"protected": policy.AnyTagValue}}, tf.CommandNameApply, "-auto-approve")
+
+	var violationsErr policy.ViolationsError
+	require.True(t, errors.As(err, &violationsErr), "unexpected error: %v", err)
+	require.Len(t, violationsErr.Violations, 1)
+	assert.Equal(t, "aws_db_instance.main", violationsErr.Violations[0].Address)
+	assert.Equal(t, policy.DenyDestroyTaggedName, violationsErr.Violations[0].Policy)
+
+	require.Len(t, commands, 2, "the plan violating the policies must not be applied")
+	assert.Equal(t, tf.CommandNameShow, commands[1].First())
+}
+
+func TestRunTerraformWithPolicyPlan(t *testing.T) {
+	t.Parallel()
+
+	policyConfig := &config.PolicyConfig{DenyDestroyTypes: []string{"aws_db_instance"}}
+
+	commands, err := runWithPolicyTest(t, policyConfig, tf.CommandNamePlan, "-out", "tfplan")
+	require.ErrorAs(t, err, &policy.ViolationsError{})
+	assert.Equal(t, []cli.Args{{tf.CommandNamePlan, "-out", "tfplan"}, {tf.CommandNameShow, tf.FlagNameJSON, "tfplan"}}, commands)
+
+	commands, err = runWithPolicyTest(t, policyConfig, tf.CommandNamePlan)
+	require.NoError(t, err)
+	assert.Equal(t, []cli.Args{{tf.CommandNamePlan}}, commands, "plans that are not saved are not checked")
+}
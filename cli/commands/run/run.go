@@ -311,8 +311,12 @@ func runTerragruntWithConfig(ctx context.Context, originalTerragruntOptions *opt
 		return target.runCallback(ctx, terragruntOptions, terragruntConfig)
 	}
 
+	var migration *backendMigration
+
 	if terragruntOptions.TerraformCliArgs.First() == tf.CommandNameInit {
-		if err := prepareInitCommand(ctx, terragruntOptions, terragruntConfig); err != nil {
+		var err error
+
+		if migration, err = prepareInitCommand(ctx, terragruntOptions, terragruntConfig); err != nil {
 			return err
 		}
 	} else {
@@ -346,7 +350,19 @@ func runTerragruntWithConfig(ctx context.Context, originalTerragruntOptions *opt
 	}
 
 	return RunActionWithHooks(ctx, "terraform", terragruntOptions, terragruntConfig, func(ctx context.Context) error {
-		runTerraformError := RunTerraformWithRetry(ctx, terragruntOptions)
+		runTerraformError := RunTerraformWithPlanFingerprint(ctx, terragruntOptions, terragruntConfig)
+
+		// Now that the working dir is initialized with the new backend, push the state pulled from the old one.
+		if runTerraformError == nil && migration != nil {
+			runTerraformError = migration.complete(ctx, terragruntOptions)
+		}
+
+		// Outputs of this unit may have changed, so make sure dependents don't read them from the persistent cache.
+		if terragruntOptions.DependencyOutputCache && util.ListContainsElement(tf.CommandNamesModifyingState, terragruntOptions.TerraformCliArgs.First()) {
+			if err := config.InvalidateDependencyOutputCache(terragruntOptions, terragruntOptions.TerragruntConfigPath); err != nil {
+				terragruntOptions.Logger.Warnf("Failed to invalidate persistent output cache: %v", err)
+			}
+		}
 
 		var lockFileError error
 		if ShouldCopyLockFile(terragruntOptions.TerraformCliArgs, terragruntConfig.Terraform) {
@@ -486,6 +502,9 @@ func RunTerraformWithRetry(ctx context.Context, terragruntOptions *options.Terra
 				select {
 				case <-time.After(terragruntOptions.RetrySleepInterval):
 					// try again
+					if retryCount := tf.RetryCountFromContext(ctx); retryCount != nil {
+						retryCount.Inc()
+					}
 				case <-ctx.Done():
 					return errors.New(ctx.Err())
 				}
@@ -508,26 +527,44 @@ func IsRetryable(opts *options.TerragruntOptions, out *util.CmdOutput) bool {
 }
 
 // Prepare for running 'terraform init' by initializing remote state storage and adding backend configuration arguments
-// to the TerraformCliArgs
-func prepareInitCommand(ctx context.Context, terragruntOptions *options.TerragruntOptions, terragruntConfig *config.TerragruntConfig) error {
-	if terragruntConfig.RemoteState != nil {
-		// Initialize the remote state if necessary  (e.g. create S3 bucket and DynamoDB table)
-		remoteStateNeedsInit, err := remoteStateNeedsInit(terragruntConfig.RemoteState, terragruntOptions)
-		if err != nil {
-			return err
+// to the TerraformCliArgs. If backend migration is enabled and the backend configuration changed, the state is pulled
+// from the old backend and the returned migration must be completed once 'terraform init' has run.
+func prepareInitCommand(ctx context.Context, terragruntOptions *options.TerragruntOptions, terragruntConfig *config.TerragruntConfig) (*backendMigration, error) {
+	if terragruntConfig.RemoteState == nil {
+		return nil, nil
+	}
+
+	var migration *backendMigration
+
+	if terragruntOptions.BackendMigrate {
+		var err error
+
+		// Pull the state before anything touches the new backend or the data dir, which still describes the old one.
+		if migration, err = prepareBackendMigration(ctx, terragruntOptions, terragruntConfig.RemoteState); err != nil {
+			return nil, err
 		}
+	}
 
-		if remoteStateNeedsInit {
-			if err := terragruntConfig.RemoteState.Initialize(ctx, terragruntOptions); err != nil {
-				return err
-			}
+	// Initialize the remote state if necessary  (e.g. create S3 bucket and DynamoDB table)
+	remoteStateNeedsInit, err := remoteStateNeedsInit(terragruntConfig.RemoteState, terragruntOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if remoteStateNeedsInit {
+		if err := terragruntConfig.RemoteState.Initialize(ctx, terragruntOptions); err != nil {
+			return nil, err
 		}
+	}
+
+	// Add backend config arguments to the command
+	terragruntOptions.InsertTerraformCliArgs(terragruntConfig.RemoteState.ToTerraformInitArgs()...)
 
-		// Add backend config arguments to the command
-		terragruntOptions.InsertTerraformCliArgs(terragruntConfig.RemoteState.ToTerraformInitArgs()...)
+	if migration != nil {
+		terragruntOptions.InsertTerraformCliArgs(migration.initArgs(terragruntOptions)...)
 	}
 
-	return nil
+	return migration, nil
 }
 
 func CheckFolderContainsTerraformCode(terragruntOptions *options.TerragruntOptions) error {
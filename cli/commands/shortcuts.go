@@ -11,6 +11,7 @@ import (
 	"github.com/gruntwork-io/terragrunt/tf"
 
 	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/cli/commands/state"
 )
 
 var (
@@ -67,6 +68,10 @@ func NewShortcutsCommands(opts *options.TerragruntOptions) cli.Commands {
 				return runSubCmd.Action(ctx)
 			},
 		}
+
+		if runSubCmd.Name == state.CommandName {
+			cmds[i] = state.WrapCommand(opts, cmds[i])
+		}
 	}
 
 	return cmds
@@ -14,6 +14,7 @@ import (
 	"github.com/gruntwork-io/terragrunt/config"
 
 	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/sensitive"
 	"github.com/gruntwork-io/terragrunt/options"
 )
 
@@ -34,8 +35,9 @@ func PrintRawOutputs(opts *options.TerragruntOptions, writer io.Writer, outputs
 		return errors.New("multiple outputs found, please specify only one index")
 	}
 
+	// As with `output -raw`, the sensitive values are printed.
 	for key, value := range filteredOutputs {
-		valueStr, err := getValueString(value)
+		valueStr, err := getValueString(sensitive.Unmark(value))
 		if err != nil {
 			opts.Logger.Warnf("Error fetching output for '%s': %v", key, err)
 			continue
@@ -69,7 +71,7 @@ func PrintOutputs(writer io.Writer, outputs map[string]map[string]cty.Value, out
 	rootBody := f.Body()
 
 	for key, value := range filteredOutputs {
-		tokens := hclwrite.TokensForValue(value)
+		tokens := hclwrite.TokensForValue(redactSensitiveOutput(value))
 		rootBody.SetAttributeRaw(key, tokens)
 	}
 
@@ -87,7 +89,7 @@ func PrintJSONOutput(writer io.Writer, outputs map[string]map[string]cty.Value,
 		return nil
 	}
 
-	topVal := cty.ObjectVal(filteredOutputs)
+	topVal := redactSensitiveOutput(cty.ObjectVal(filteredOutputs))
 	rawJSON, err := ctyjson.Marshal(topVal, topVal.Type())
 
 	if err != nil {
@@ -127,9 +129,12 @@ func FilterOutputs(outputs map[string]map[string]cty.Value, outputIndex string)
 		value := cty.ObjectVal(values)
 		for _, key := range keys[1:] {
 			if value.Type().IsObjectType() {
-				mapVal := value.AsValueMap()
+				// The attributes of a sensitive output are sensitive too.
+				unmarked, marks := value.Unmark()
+
+				mapVal := unmarked.AsValueMap()
 				if v, exists := mapVal[key]; exists {
-					value = v
+					value = v.WithMarks(marks)
 				} else {
 					return nil
 				}
@@ -143,3 +148,41 @@ func FilterOutputs(outputs map[string]map[string]cty.Value, outputIndex string)
 
 	return currentMap
 }
+
+// redactSensitiveOutput replaces the sensitive parts of the given value with the redacted value.
+func redactSensitiveOutput(value cty.Value) cty.Value {
+	if !value.ContainsMarked() {
+		return value
+	}
+
+	if value.HasMark(sensitive.Mark) {
+		return cty.StringVal(sensitive.Redacted)
+	}
+
+	value, _ = value.Unmark()
+
+	if value.IsNull() || !value.IsKnown() {
+		return value
+	}
+
+	ty := value.Type()
+
+	switch {
+	case ty.IsObjectType() || ty.IsMapType():
+		vals := make(map[string]cty.Value, value.LengthInt())
+		for key, val := range value.AsValueMap() {
+			vals[key] = redactSensitiveOutput(val)
+		}
+
+		return cty.ObjectVal(vals)
+	case ty.IsListType() || ty.IsSetType() || ty.IsTupleType():
+		vals := make([]cty.Value, 0, value.LengthInt())
+		for _, val := range value.AsValueSlice() {
+			vals = append(vals, redactSensitiveOutput(val))
+		}
+
+		return cty.TupleVal(vals)
+	}
+
+	return value
+}
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/gruntwork-io/terragrunt/cli/commands/stack"
+	"github.com/gruntwork-io/terragrunt/internal/sensitive"
+	"github.com/gruntwork-io/terragrunt/options"
 
 	"github.com/stretchr/testify/require"
 
@@ -197,3 +199,34 @@ func TestPrintOutputs(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintOutputsRedactsSensitiveValues(t *testing.T) {
+	t.Parallel()
+
+	outputs := map[string]map[string]cty.Value{
+		"unit1": {
+			"name":     cty.StringVal("app"),
+			"password": cty.StringVal("hunter2").Mark(sensitive.Mark),
+			"db": cty.ObjectVal(map[string]cty.Value{
+				"port": cty.NumberIntVal(5432),
+			}).Mark(sensitive.Mark),
+		},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, stack.PrintOutputs(&out, outputs, ""))
+	assert.Contains(t, out.String(), `name     = "app"`)
+	assert.Contains(t, out.String(), `password = "(sensitive value)"`)
+	assert.NotContains(t, out.String(), "hunter2")
+
+	out.Reset()
+	require.NoError(t, stack.PrintJSONOutput(&out, outputs, "unit1.db.port"))
+	assert.JSONEq(t, `{"unit1.db.port": "(sensitive value)"}`, out.String())
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	out.Reset()
+	require.NoError(t, stack.PrintRawOutputs(opts, &out, outputs, "unit1.password"))
+	assert.Equal(t, "hunter2\n", out.String())
+}
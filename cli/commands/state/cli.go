@@ -0,0 +1,153 @@
+// Package state provides the Terragrunt subcommands of the `state` command, which can operate on the state of several
+// units at once. Any other `state` subcommand, or any of these subcommands used without Terragrunt specific flags,
+// is forwarded to OpenTofu/Terraform.
+package state
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/tf"
+)
+
+const (
+	CommandName = tf.CommandNameState
+
+	MvCommandName     = "mv"
+	PullCommandName   = "pull"
+	PushCommandName   = "push"
+	ListCommandName   = "list"
+	BackupCommandName = "backup"
+
+	FromUnitFlagName  = "from-unit"
+	ToUnitFlagName    = "to-unit"
+	UnitFlagName      = "unit"
+	BackupDirFlagName = "backup-dir"
+)
+
+// WrapCommand adds the Terragrunt `state` subcommands to the given `state` shortcut command. The action of the
+// shortcut is used to forward the subcommands to OpenTofu/Terraform when they are not used with Terragrunt flags.
+func WrapCommand(opts *options.TerragruntOptions, cmd *cli.Command) *cli.Command {
+	forwardAction := cmd.Action
+	cmdOpts := NewOptions(opts)
+	prefix := flags.Prefix{CommandName}
+
+	cmd.Subcommands = cli.Commands{
+		&cli.Command{
+			Name:      MvCommandName,
+			Usage:     "Move resources within the state of a unit, or from the state of one unit to another.",
+			UsageText: "terragrunt state mv [--from-unit <dir>] [--to-unit <dir>] <source address> [<destination address>]",
+			Flags:     mvFlags(cmdOpts, prefix),
+			Action: func(ctx *cli.Context) error {
+				if !cmdOpts.IsCrossUnit() {
+					return forward(ctx, opts, forwardAction)
+				}
+
+				return RunMv(ctx.Context, cmdOpts, ctx.Args().Slice())
+			},
+		},
+		&cli.Command{
+			Name:      PullCommandName,
+			Usage:     "Print the state of a unit.",
+			UsageText: "terragrunt state pull [--unit <dir>]",
+			Flags:     unitFlags(cmdOpts, prefix),
+			Action: func(ctx *cli.Context) error {
+				if !cmdOpts.IsCrossUnit() {
+					return forward(ctx, opts, forwardAction)
+				}
+
+				return RunPull(ctx.Context, cmdOpts)
+			},
+		},
+		&cli.Command{
+			Name:      PushCommandName,
+			Usage:     "Back up the state of a unit and replace it with the given state file.",
+			UsageText: "terragrunt state push [--unit <dir>] <path>",
+			Flags:     append(unitFlags(cmdOpts, prefix), backupFlags(cmdOpts, prefix)...),
+			Action: func(ctx *cli.Context) error {
+				if !cmdOpts.IsCrossUnit() {
+					return forward(ctx, opts, forwardAction)
+				}
+
+				return RunPush(ctx.Context, cmdOpts, ctx.Args().Slice())
+			},
+		},
+		&cli.Command{
+			Name:      ListCommandName,
+			Usage:     "List the resources in the state of one or more units.",
+			UsageText: "terragrunt state list [--unit <dir>]... [<address>...]",
+			Flags:     unitFlags(cmdOpts, prefix),
+			Action: func(ctx *cli.Context) error {
+				if !cmdOpts.IsCrossUnit() {
+					return forward(ctx, opts, forwardAction)
+				}
+
+				return RunList(ctx.Context, cmdOpts, ctx.Args().Slice())
+			},
+		},
+		&cli.Command{
+			Name:      BackupCommandName,
+			Usage:     "Save a backup of the state of one or more units.",
+			UsageText: "terragrunt state backup [--unit <dir>]... [--backup-dir <dir>]",
+			Flags:     append(unitFlags(cmdOpts, prefix), backupFlags(cmdOpts, prefix)...),
+			Action: func(ctx *cli.Context) error {
+				return RunBackup(ctx.Context, cmdOpts)
+			},
+		},
+	}
+
+	return cmd
+}
+
+// forward runs the invoked `state` subcommand with OpenTofu/Terraform, just like the `state` shortcut does.
+func forward(ctx *cli.Context, opts *options.TerragruntOptions, action cli.ActionFunc) error {
+	opts.TerraformCommand = CommandName
+	opts.TerraformCliArgs = append(cli.Args{CommandName}, opts.TerraformCliArgs...)
+
+	return action(ctx)
+}
+
+func unitFlags(opts *Options, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.SliceFlag[string]{
+			Name:        UnitFlagName,
+			EnvVars:     tgPrefix.EnvVars(UnitFlagName),
+			Destination: &opts.Units,
+			Usage:       "Dir of a unit to operate on. Can be specified multiple times for commands that support several units.",
+		}),
+	}
+}
+
+func backupFlags(opts *Options, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        BackupDirFlagName,
+			EnvVars:     tgPrefix.EnvVars(BackupDirFlagName),
+			Destination: &opts.BackupDir,
+			Usage:       "Dir in which state backups are stored. Defaults to the " + DefaultBackupDirName + " dir in the .terraform dir of each unit.",
+		}),
+	}
+}
+
+func mvFlags(opts *Options, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return append(cli.Flags{
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FromUnitFlagName,
+			EnvVars:     tgPrefix.EnvVars(FromUnitFlagName),
+			Destination: &opts.FromUnit,
+			Usage:       "Dir of the unit to move resources from. Defaults to the current unit.",
+		}),
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        ToUnitFlagName,
+			EnvVars:     tgPrefix.EnvVars(ToUnitFlagName),
+			Destination: &opts.ToUnit,
+			Usage:       "Dir of the unit to move resources to. Defaults to the current unit.",
+		}),
+	}, backupFlags(opts, prefix)...)
+}
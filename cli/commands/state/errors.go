@@ -0,0 +1,78 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/gruntwork-io/terragrunt/remote"
+)
+
+// Custom error types
+
+type StateLockedError struct {
+	Lock     *remote.LockInfo
+	UnitPath string
+}
+
+func (err StateLockedError) Error() string {
+	return fmt.Sprintf("The state of unit %s is locked (%s). Refusing to operate on it until the lock is released.", err.UnitPath, err.Lock)
+}
+
+type StateChangedError string
+
+func (path StateChangedError) Error() string {
+	return fmt.Sprintf("The state of unit %s changed while operating on it. Refusing to overwrite it, run the command again.", string(path))
+}
+
+type UnitNotFoundError string
+
+func (path UnitNotFoundError) Error() string {
+	return fmt.Sprintf("No Terragrunt configuration found for unit %s", string(path))
+}
+
+type UnitSkippedError string
+
+func (path UnitSkippedError) Error() string {
+	return fmt.Sprintf("Unit %s is skipped, its state can not be operated on", string(path))
+}
+
+type SingleUnitRequiredError string
+
+func (cmdName SingleUnitRequiredError) Error() string {
+	return fmt.Sprintf("The state %s command operates on a single unit, specify at most one --%s", string(cmdName), UnitFlagName)
+}
+
+type MissingArgumentError string
+
+func (argName MissingArgumentError) Error() string {
+	return "Missing required argument: " + string(argName)
+}
+
+type MoveRolledBackError struct {
+	Err      error
+	FromUnit string
+	ToUnit   string
+}
+
+func (err MoveRolledBackError) Error() string {
+	return fmt.Sprintf("Failed to update the state of unit %s after moving resources to unit %s, the state of %s has been rolled back: %v", err.FromUnit, err.ToUnit, err.ToUnit, err.Err)
+}
+
+func (err MoveRolledBackError) Unwrap() error {
+	return err.Err
+}
+
+type MoveRollbackFailedError struct {
+	Err         error
+	RollbackErr error
+	FromUnit    string
+	ToUnit      string
+	BackupPath  string
+}
+
+func (err MoveRollbackFailedError) Error() string {
+	return fmt.Sprintf("Failed to update the state of unit %s after moving resources to unit %s: %v. Rolling back the state of %s also failed: %v. Restore it manually from the backup %s", err.FromUnit, err.ToUnit, err.Err, err.ToUnit, err.RollbackErr, err.BackupPath)
+}
+
+func (err MoveRollbackFailedError) Unwrap() error {
+	return err.Err
+}
@@ -0,0 +1,38 @@
+package state
+
+import (
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+// DefaultBackupDirName is the name of the dir, in the OpenTofu/Terraform data dir of each unit, in which state backups
+// are stored when no backup dir is given. Backups contain secrets, so they are kept out of the source tree.
+const DefaultBackupDirName = "terragrunt-state-backups"
+
+type Options struct {
+	*options.TerragruntOptions
+
+	// FromUnit is the dir of the unit to move resources from.
+	FromUnit string
+
+	// ToUnit is the dir of the unit to move resources to.
+	ToUnit string
+
+	// BackupDir is the dir in which state backups are stored. If empty, backups are stored in the
+	// DefaultBackupDirName dir of the data dir of each unit.
+	BackupDir string
+
+	// Units are the dirs of the units to operate on.
+	Units []string
+}
+
+func NewOptions(opts *options.TerragruntOptions) *Options {
+	return &Options{
+		TerragruntOptions: opts,
+	}
+}
+
+// IsCrossUnit returns true if any of the flags that make Terragrunt operate on the state of units, rather than
+// forwarding the command to OpenTofu/Terraform, are set.
+func (o *Options) IsCrossUnit() bool {
+	return o.FromUnit != "" || o.ToUnit != "" || len(o.Units) > 0
+}
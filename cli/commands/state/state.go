@@ -0,0 +1,539 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	backupTimeFormat = "20060102T150405.000Z"
+
+	stateFilePerm = 0600
+
+	tfStatePullCommand = "pull"
+	tfStatePushCommand = "push"
+	tfStateListCommand = "list"
+	tfStateMvCommand   = "mv"
+	tfStateRmCommand   = "rm"
+
+	lockFlag = "-lock=true"
+)
+
+// unit is a unit whose working dir has been prepared and initialized, so that OpenTofu/Terraform state commands can
+// be run against its backend.
+type unit struct {
+	opts   *options.TerragruntOptions
+	config *config.TerragruntConfig
+	path   string
+}
+
+// RunPull prints the state of the unit given with --unit.
+func RunPull(ctx context.Context, opts *Options) error {
+	if len(opts.Units) > 1 {
+		return errors.New(SingleUnitRequiredError(PullCommandName))
+	}
+
+	return withUnits(ctx, opts, opts.Units, func(ctx context.Context, units []*unit) error {
+		if err := checkUnlocked(ctx, units[0]); err != nil {
+			return err
+		}
+
+		state, err := pullState(ctx, units[0])
+		if err != nil {
+			return err
+		}
+
+		_, err = opts.Writer.Write(state)
+
+		return errors.New(err)
+	})
+}
+
+// RunList lists the resources in the state of each unit given with --unit. When several units are given, each
+// address is prefixed with the path of its unit.
+func RunList(ctx context.Context, opts *Options, args []string) error {
+	for _, unitPath := range opts.Units {
+		err := withUnits(ctx, opts, []string{unitPath}, func(ctx context.Context, units []*unit) error {
+			if err := checkUnlocked(ctx, units[0]); err != nil {
+				return err
+			}
+
+			output, err := runStateCommand(ctx, units[0].opts, append([]string{tfStateListCommand}, args...)...)
+			if err != nil {
+				return err
+			}
+
+			if len(opts.Units) == 1 {
+				_, err = opts.Writer.Write(output)
+				return errors.New(err)
+			}
+
+			for _, address := range strings.Fields(string(output)) {
+				if _, err := fmt.Fprintf(opts.Writer, "%s\t%s\n", unitDisplayPath(opts, units[0].path), address); err != nil {
+					return errors.New(err)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RunBackup saves a backup of the state of each unit given with --unit, or of the current unit if none is given.
+func RunBackup(ctx context.Context, opts *Options) error {
+	unitPaths := opts.Units
+	if len(unitPaths) == 0 {
+		unitPaths = []string{opts.WorkingDir}
+	}
+
+	for _, unitPath := range unitPaths {
+		err := withUnits(ctx, opts, []string{unitPath}, func(ctx context.Context, units []*unit) error {
+			if err := checkUnlocked(ctx, units[0]); err != nil {
+				return err
+			}
+
+			_, _, err := backupState(ctx, opts, units[0])
+
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RunPush backs up the state of the unit given with --unit and replaces it with the given state file. Any flags in
+// `args` are passed to `state push`.
+func RunPush(ctx context.Context, opts *Options, args []string) error {
+	if len(opts.Units) > 1 {
+		return errors.New(SingleUnitRequiredError(PushCommandName))
+	}
+
+	flags, positional := splitArgs(args)
+	if len(positional) == 0 {
+		return errors.New(MissingArgumentError("path of the state file to push"))
+	}
+
+	// OpenTofu/Terraform runs in the working dir of the unit, so the state file path is resolved beforehand.
+	stateFile := positional[0]
+	if stateFile != "-" && !filepath.IsAbs(stateFile) {
+		stateFile = filepath.Join(opts.WorkingDir, stateFile)
+	}
+
+	return withUnits(ctx, opts, opts.Units, func(ctx context.Context, units []*unit) error {
+		if err := checkUnlocked(ctx, units[0]); err != nil {
+			return err
+		}
+
+		if _, _, err := backupState(ctx, opts, units[0]); err != nil {
+			return err
+		}
+
+		if _, err := runStateCommand(ctx, units[0].opts, append(append([]string{tfStatePushCommand}, flags...), stateFile)...); err != nil {
+			return err
+		}
+
+		invalidateOutputCache(opts, units[0])
+
+		return nil
+	})
+}
+
+// RunMv moves resources within the state of a unit, or from the state of the unit given with --from-unit to the state
+// of the unit given with --to-unit. The states of the units are backed up before being changed. Moves across units are
+// performed on local copies of both states, which are only pushed back once the move succeeded. If updating the source
+// unit fails after the destination unit has been updated, the destination unit is rolled back.
+func RunMv(ctx context.Context, opts *Options, args []string) error {
+	flags, positional := splitArgs(args)
+	if len(positional) == 0 {
+		return errors.New(MissingArgumentError("source address"))
+	}
+
+	srcAddr, dstAddr := positional[0], positional[0]
+	if len(positional) > 1 {
+		dstAddr = positional[1]
+	}
+
+	fromPath, toPath := resolveUnitPath(opts, opts.FromUnit), resolveUnitPath(opts, opts.ToUnit)
+
+	if fromPath == toPath {
+		return withUnits(ctx, opts, []string{fromPath}, func(ctx context.Context, units []*unit) error {
+			if err := checkUnlocked(ctx, units[0]); err != nil {
+				return err
+			}
+
+			if _, _, err := backupState(ctx, opts, units[0]); err != nil {
+				return err
+			}
+
+			if _, err := runStateCommand(ctx, units[0].opts, append(append([]string{tfStateMvCommand}, flags...), srcAddr, dstAddr)...); err != nil {
+				return err
+			}
+
+			invalidateOutputCache(opts, units[0])
+
+			return nil
+		})
+	}
+
+	return withUnits(ctx, opts, []string{fromPath, toPath}, func(ctx context.Context, units []*unit) error {
+		return moveAcrossUnits(ctx, opts, units[0], units[1], flags, srcAddr, dstAddr)
+	})
+}
+
+func moveAcrossUnits(ctx context.Context, opts *Options, from, to *unit, flags []string, srcAddr, dstAddr string) error {
+	for _, u := range []*unit{from, to} {
+		if err := checkUnlocked(ctx, u); err != nil {
+			return err
+		}
+	}
+
+	fromState, _, err := backupState(ctx, opts, from)
+	if err != nil {
+		return err
+	}
+
+	toState, toBackupPath, err := backupState(ctx, opts, to)
+	if err != nil {
+		return err
+	}
+
+	if len(fromState) == 0 {
+		return errors.Errorf("the state of unit %s is empty, there is nothing to move", unitDisplayPath(opts, from.path))
+	}
+
+	tmpDir, err := os.MkdirTemp("", "terragrunt-state-mv-*")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			opts.Logger.Warnf("Failed to remove temporary dir %s: %v", tmpDir, err)
+		}
+	}()
+
+	fromStateFile := filepath.Join(tmpDir, "from.tfstate")
+	toStateFile := filepath.Join(tmpDir, "to.tfstate")
+
+	if err := os.WriteFile(fromStateFile, fromState, stateFilePerm); err != nil {
+		return errors.New(err)
+	}
+
+	if len(toState) > 0 {
+		if err := os.WriteFile(toStateFile, toState, stateFilePerm); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	// Move the resources between the local copies of the states in an empty dir, where OpenTofu/Terraform uses the
+	// local backend, so that the real states are left untouched if the move fails.
+	mvOpts := from.opts.Clone()
+	mvOpts.WorkingDir = tmpDir
+
+	mvArgs := append([]string{tfStateMvCommand, "-lock=false", "-state=" + fromStateFile, "-state-out=" + toStateFile}, flags...)
+	if _, err := runStateCommand(ctx, mvOpts, append(mvArgs, srcAddr, dstAddr)...); err != nil {
+		return err
+	}
+
+	// The states are only read and checked for locks before the move, so that a concurrent change, e.g. by an apply, is
+	// not overwritten, they must be unchanged before being pushed, with the lock held by the push.
+	for _, unitState := range []struct {
+		u     *unit
+		state []byte
+	}{{from, fromState}, {to, toState}} {
+		if err := checkStateUnchanged(ctx, opts, unitState.u, unitState.state); err != nil {
+			return err
+		}
+	}
+
+	if _, err := runStateCommand(ctx, to.opts, tfStatePushCommand, lockFlag, toStateFile); err != nil {
+		return err
+	}
+
+	invalidateOutputCache(opts, to)
+
+	if _, err := runStateCommand(ctx, from.opts, tfStatePushCommand, lockFlag, fromStateFile); err != nil {
+		opts.Logger.Errorf("Failed to update the state of unit %s, rolling back the state of unit %s", unitDisplayPath(opts, from.path), unitDisplayPath(opts, to.path))
+
+		if rollbackErr := rollbackMove(ctx, to, toBackupPath, dstAddr); rollbackErr != nil {
+			return errors.New(MoveRollbackFailedError{
+				Err:         err,
+				RollbackErr: rollbackErr,
+				FromUnit:    unitDisplayPath(opts, from.path),
+				ToUnit:      unitDisplayPath(opts, to.path),
+				BackupPath:  toBackupPath,
+			})
+		}
+
+		return errors.New(MoveRolledBackError{
+			Err:      err,
+			FromUnit: unitDisplayPath(opts, from.path),
+			ToUnit:   unitDisplayPath(opts, to.path),
+		})
+	}
+
+	invalidateOutputCache(opts, from)
+
+	opts.Logger.Infof("Moved %s from unit %s to %s in unit %s", srcAddr, unitDisplayPath(opts, from.path), dstAddr, unitDisplayPath(opts, to.path))
+
+	return nil
+}
+
+// rollbackMove restores the state of the destination unit of a move across units. If the destination unit had a
+// state before the move, its backup is force pushed. Otherwise the moved resources are removed again.
+func rollbackMove(ctx context.Context, to *unit, backupPath, dstAddr string) error {
+	if backupPath != "" {
+		_, err := runStateCommand(ctx, to.opts, tfStatePushCommand, lockFlag, "-force", backupPath)
+		return err
+	}
+
+	_, err := runStateCommand(ctx, to.opts, tfStateRmCommand, dstAddr)
+
+	return err
+}
+
+// withUnits prepares and initializes the working dirs of the given units one after another, then calls `fn` with all
+// of them. If no unit paths are given, the current unit is used.
+func withUnits(ctx context.Context, opts *Options, unitPaths []string, fn func(ctx context.Context, units []*unit) error) error {
+	if len(unitPaths) == 0 {
+		unitPaths = []string{opts.WorkingDir}
+	}
+
+	units := make([]*unit, 0, len(unitPaths))
+
+	var prepare func(ctx context.Context, i int) error
+
+	prepare = func(ctx context.Context, i int) error {
+		if i == len(unitPaths) {
+			return fn(ctx, units)
+		}
+
+		unitPath := resolveUnitPath(opts, unitPaths[i])
+
+		unitOpts, err := newUnitOptions(opts.TerragruntOptions, unitPath)
+		if err != nil {
+			return err
+		}
+
+		target := run.NewTarget(run.TargetPointInitCommand, func(ctx context.Context, unitOpts *options.TerragruntOptions, cfg *config.TerragruntConfig) error {
+			units = append(units, &unit{opts: unitOpts, config: cfg, path: unitPath})
+			return prepare(ctx, i+1)
+		})
+
+		if err := run.RunWithTarget(ctx, unitOpts, target); err != nil {
+			return err
+		}
+
+		// The target callback is not called for units with `skip = true`.
+		if len(units) <= i {
+			return errors.New(UnitSkippedError(unitDisplayPath(opts, unitPath)))
+		}
+
+		return nil
+	}
+
+	return prepare(ctx, 0)
+}
+
+// newUnitOptions returns the options to run OpenTofu/Terraform state commands in the unit at the given path.
+func newUnitOptions(opts *options.TerragruntOptions, unitPath string) (*options.TerragruntOptions, error) {
+	configPath := config.GetDefaultConfigPath(unitPath)
+	if !util.FileExists(configPath) {
+		return nil, errors.New(UnitNotFoundError(unitPath))
+	}
+
+	unitOpts, err := opts.CloneWithConfigPath(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each unit gets its own download dir, unless one was explicitly specified.
+	_, defaultDownloadDir, err := options.DefaultWorkingAndDownloadDirs(opts.TerragruntConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.DownloadDir == defaultDownloadDir {
+		_, unitOpts.DownloadDir, err = options.DefaultWorkingAndDownloadDirs(configPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	unitOpts.TerraformCommand = tf.CommandNameState
+	unitOpts.TerraformCliArgs = cli.Args{tf.CommandNameState}
+
+	return unitOpts, nil
+}
+
+// checkUnlocked returns a StateLockedError if the state of the given unit is currently locked. Backends that can't be
+// inspected are left to the locking of OpenTofu/Terraform itself.
+func checkUnlocked(ctx context.Context, u *unit) error {
+	remoteState := u.config.RemoteState
+
+	if remoteState == nil || !remoteState.CanReadLock() {
+		u.opts.Logger.Debugf("Unable to inspect the state lock of unit %s, relying on OpenTofu/Terraform locking", u.path)
+		return nil
+	}
+
+	lock, err := remoteState.ReadLock(ctx, u.opts)
+	if err != nil {
+		return err
+	}
+
+	if lock != nil {
+		return errors.New(StateLockedError{UnitPath: u.path, Lock: lock})
+	}
+
+	return nil
+}
+
+// checkStateUnchanged returns a StateChangedError if the state of the given unit differs from the given one, read
+// before operating on it.
+func checkStateUnchanged(ctx context.Context, opts *Options, u *unit, state []byte) error {
+	current, err := pullState(ctx, u)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(current, state) {
+		return errors.New(StateChangedError(unitDisplayPath(opts, u.path)))
+	}
+
+	return nil
+}
+
+// backupState pulls the state of the given unit and saves it to the backup dir. Returns the state along with the path
+// of the backup, which is empty if the unit has no state yet.
+func backupState(ctx context.Context, opts *Options, u *unit) ([]byte, string, error) {
+	state, err := pullState(ctx, u)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(state) == 0 {
+		opts.Logger.Infof("Unit %s has no state yet, skipping backup", unitDisplayPath(opts, u.path))
+		return nil, "", nil
+	}
+
+	backupDir := filepath.Join(u.opts.DataDir(), DefaultBackupDirName)
+	if opts.BackupDir != "" {
+		backupDir = filepath.Join(resolveUnitPath(opts, opts.BackupDir), backupSubdir(opts, u.path))
+	}
+
+	if err := util.EnsureDirectory(backupDir); err != nil {
+		return nil, "", err
+	}
+
+	backupPath := filepath.Join(backupDir, time.Now().UTC().Format(backupTimeFormat)+".tfstate")
+
+	if err := os.WriteFile(backupPath, state, stateFilePerm); err != nil {
+		return nil, "", errors.New(err)
+	}
+
+	opts.Logger.Infof("Backed up state of unit %s to %s", unitDisplayPath(opts, u.path), backupPath)
+
+	return state, backupPath, nil
+}
+
+// backupSubdir returns the dir within the backup dir for the given unit, which mirrors the path of the unit relative
+// to the current working dir.
+func backupSubdir(opts *Options, unitPath string) string {
+	relPath, err := filepath.Rel(opts.RootWorkingDir, unitPath)
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return strings.TrimPrefix(filepath.ToSlash(unitPath), "/")
+	}
+
+	return relPath
+}
+
+func pullState(ctx context.Context, u *unit) ([]byte, error) {
+	state, err := runStateCommand(ctx, u.opts, tfStatePullCommand)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSpace(state), nil
+}
+
+// runStateCommand runs the given `state` subcommand and returns its stdout.
+func runStateCommand(ctx context.Context, opts *options.TerragruntOptions, args ...string) ([]byte, error) {
+	opts = opts.Clone()
+	opts.Writer = io.Discard
+
+	output, err := tf.RunCommandWithOutput(ctx, opts, append([]string{tf.CommandNameState}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Stdout.Bytes(), nil
+}
+
+func invalidateOutputCache(opts *Options, u *unit) {
+	if !opts.DependencyOutputCache {
+		return
+	}
+
+	if err := config.InvalidateDependencyOutputCache(u.opts, u.opts.TerragruntConfigPath); err != nil {
+		opts.Logger.Warnf("Failed to invalidate dependency output cache for %s: %v", u.opts.TerragruntConfigPath, err)
+	}
+}
+
+// resolveUnitPath returns the absolute path of the given unit dir, relative to the working dir.
+func resolveUnitPath(opts *Options, path string) string {
+	if path == "" {
+		return filepath.Clean(opts.WorkingDir)
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(opts.WorkingDir, path)
+	}
+
+	return filepath.Clean(path)
+}
+
+func unitDisplayPath(opts *Options, unitPath string) string {
+	if relPath, err := filepath.Rel(opts.RootWorkingDir, unitPath); err == nil {
+		return relPath
+	}
+
+	return unitPath
+}
+
+// splitArgs separates the flags for OpenTofu/Terraform from the positional args.
+func splitArgs(args []string) ([]string, []string) {
+	var flags, positional []string
+
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") && arg != "-" {
+			flags = append(flags, arg)
+			continue
+		}
+
+		positional = append(positional, arg)
+	}
+
+	return flags, positional
+}
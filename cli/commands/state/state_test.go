@@ -0,0 +1,257 @@
+package state_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/state"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateCommandForwarding(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		args            []string
+		expectedCliArgs []string
+	}{
+		{
+			args:            []string{"state", "rm", "aws_instance.foo"},
+			expectedCliArgs: []string{"state", "rm", "aws_instance.foo"},
+		},
+		{
+			args:            []string{"state", "mv", "aws_instance.foo", "aws_instance.bar"},
+			expectedCliArgs: []string{"state", "mv", "aws_instance.foo", "aws_instance.bar"},
+		},
+		{
+			args:            []string{"state", "list", "-id=i-123"},
+			expectedCliArgs: []string{"state", "list", "-id=i-123"},
+		},
+		{
+			args:            []string{"state", "pull"},
+			expectedCliArgs: []string{"state", "pull"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.args[1], func(t *testing.T) {
+			t.Parallel()
+
+			opts := options.NewTerragruntOptions()
+
+			var forwardedArgs []string
+
+			cmd := state.WrapCommand(opts, &cli.Command{
+				Name: state.CommandName,
+				Action: func(ctx *cli.Context) error {
+					forwardedArgs = opts.TerraformCliArgs
+					return nil
+				},
+			})
+
+			app := cli.NewApp()
+			app.Writer = &bytes.Buffer{}
+			app.ErrWriter = &bytes.Buffer{}
+			// Mimic the initial setup of the Terragrunt app, which derives the OpenTofu/Terraform args from the invoked command.
+			app.Commands = cli.Commands{cmd}.WrapAction(func(ctx *cli.Context, action cli.ActionFunc) error {
+				opts.TerraformCommand = ctx.Command.Name
+				opts.TerraformCliArgs = append(cli.Args{ctx.Command.Name}, ctx.Args()...)
+
+				return action(ctx)
+			})
+
+			require.NoError(t, app.Run(append([]string{"terragrunt"}, tc.args...)))
+			assert.Equal(t, tc.expectedCliArgs, forwardedArgs)
+			assert.Equal(t, state.CommandName, opts.TerraformCommand)
+		})
+	}
+}
+
+func TestStateUnitNotFound(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest(filepath.Join(tmpDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	terragruntOptions.WorkingDir = tmpDir
+	terragruntOptions.RootWorkingDir = tmpDir
+
+	opts := state.NewOptions(terragruntOptions)
+	opts.Units = []string{"missing"}
+
+	err = state.RunBackup(context.Background(), opts)
+
+	var notFoundErr state.UnitNotFoundError
+	require.True(t, errors.As(err, &notFoundErr), "unexpected error: %v", err)
+	assert.Equal(t, filepath.Join(tmpDir, "missing"), string(notFoundErr))
+}
+
+func TestStateSingleUnitRequired(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("terragrunt.hcl")
+	require.NoError(t, err)
+
+	opts := state.NewOptions(terragruntOptions)
+	opts.Units = []string{"a", "b"}
+
+	err = state.RunPush(context.Background(), opts, []string{"terraform.tfstate"})
+
+	var singleUnitErr state.SingleUnitRequiredError
+	require.True(t, errors.As(err, &singleUnitErr), "unexpected error: %v", err)
+}
+
+const testUnitConfig = `
+remote_state {
+  backend = "local"
+  config = {
+    path = "terraform.tfstate"
+  }
+}
+`
+
+// newMvTestOptions creates the units `from` and `to` with the given states, and returns the options to move resources
+// from the first to the second one. OpenTofu/Terraform is replaced by a script keeping the state of a unit as one
+// resource address per line.
+func newMvTestOptions(t *testing.T, fromState, toState string) (*state.Options, string) {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the OpenTofu/Terraform stand-in is a shell script")
+	}
+
+	fakeTofuPath, err := filepath.Abs(filepath.Join("testdata", "fake_tofu.sh"))
+	require.NoError(t, err)
+
+	tmpDir := t.TempDir()
+
+	for unit, unitState := range map[string]string{"from": fromState, "to": toState} {
+		unitDir := filepath.Join(tmpDir, unit)
+		require.NoError(t, os.MkdirAll(unitDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(unitDir, "terragrunt.hcl"), []byte(testUnitConfig), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(unitDir, "main.tf"), []byte(`terraform { backend "local" {} }`), 0644))
+
+		if unitState != "" {
+			require.NoError(t, os.WriteFile(filepath.Join(unitDir, "fake-state"), []byte(unitState), 0644))
+		}
+	}
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest(filepath.Join(tmpDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	terragruntOptions.WorkingDir = tmpDir
+	terragruntOptions.RootWorkingDir = tmpDir
+	terragruntOptions.TerraformPath = fakeTofuPath
+	terragruntOptions.NonInteractive = true
+
+	opts := state.NewOptions(terragruntOptions)
+	opts.FromUnit = "from"
+	opts.ToUnit = "to"
+
+	return opts, tmpDir
+}
+
+func readTestState(t *testing.T, unitDir string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join(unitDir, "fake-state"))
+	require.NoError(t, err)
+
+	return string(content)
+}
+
+func TestStateMvAcrossUnits(t *testing.T) {
+	t.Parallel()
+
+	opts, tmpDir := newMvTestOptions(t, "aws_instance.foo\naws_instance.bar\n", "aws_instance.existing\n")
+
+	require.NoError(t, state.RunMv(context.Background(), opts, []string{"aws_instance.foo", "aws_instance.moved"}))
+
+	assert.Equal(t, "aws_instance.bar\n", readTestState(t, filepath.Join(tmpDir, "from")))
+	assert.Equal(t, "aws_instance.existing\naws_instance.moved\n", readTestState(t, filepath.Join(tmpDir, "to")))
+
+	// Both states are backed up before being changed.
+	for _, unit := range []string{"from", "to"} {
+		backupDir := filepath.Join(tmpDir, unit, ".terraform", state.DefaultBackupDirName)
+
+		backups, err := os.ReadDir(backupDir)
+		require.NoError(t, err)
+		require.Len(t, backups, 1, unit)
+
+		info, err := os.Stat(filepath.Join(backupDir, backups[0].Name()))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), unit)
+	}
+}
+
+func TestStateMvAcrossUnitsRollback(t *testing.T) {
+	t.Parallel()
+
+	opts, tmpDir := newMvTestOptions(t, "aws_instance.foo\n", "aws_instance.existing\n")
+
+	// The destination unit is updated first, then updating the source unit fails.
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "from", "fail-push"), nil, 0644))
+
+	err := state.RunMv(context.Background(), opts, []string{"aws_instance.foo"})
+
+	var rolledBackErr state.MoveRolledBackError
+	require.True(t, errors.As(err, &rolledBackErr), "unexpected error: %v", err)
+
+	assert.Equal(t, "aws_instance.foo\n", readTestState(t, filepath.Join(tmpDir, "from")))
+	assert.Equal(t, "aws_instance.existing", readTestState(t, filepath.Join(tmpDir, "to")))
+}
+
+func TestStateMvAcrossUnitsLocked(t *testing.T) {
+	t.Parallel()
+
+	lockInfo := `{"ID": "5f7e2ab1", "Operation": "OperationTypeApply", "Who": "jane@host", "Created": "2025-01-02T03:04:05Z"}`
+
+	for _, lockedUnit := range []string{"from", "to"} {
+		t.Run(lockedUnit, func(t *testing.T) {
+			t.Parallel()
+
+			opts, tmpDir := newMvTestOptions(t, "aws_instance.foo\n", "aws_instance.existing\n")
+
+			require.NoError(t, os.WriteFile(filepath.Join(tmpDir, lockedUnit, ".terraform.tfstate.lock.info"), []byte(lockInfo), 0644))
+
+			err := state.RunMv(context.Background(), opts, []string{"aws_instance.foo"})
+
+			var lockedErr state.StateLockedError
+			require.True(t, errors.As(err, &lockedErr), "unexpected error: %v", err)
+			assert.Equal(t, filepath.Join(tmpDir, lockedUnit), lockedErr.UnitPath)
+
+			// Neither state is changed.
+			assert.Equal(t, "aws_instance.foo\n", readTestState(t, filepath.Join(tmpDir, "from")))
+			assert.Equal(t, "aws_instance.existing\n", readTestState(t, filepath.Join(tmpDir, "to")))
+		})
+	}
+}
+
+func TestStateMvAcrossUnitsChangedConcurrently(t *testing.T) {
+	t.Parallel()
+
+	opts, tmpDir := newMvTestOptions(t, "aws_instance.foo\n", "aws_instance.existing\n")
+
+	// The state of the destination unit is changed after it was read, while the resources are being moved.
+	opts.Env = map[string]string{"FAKE_TOFU_CONCURRENT_WRITE": filepath.Join(tmpDir, "to", "fake-state")}
+
+	err := state.RunMv(context.Background(), opts, []string{"aws_instance.foo"})
+
+	var changedErr state.StateChangedError
+	require.True(t, errors.As(err, &changedErr), "unexpected error: %v", err)
+	assert.Equal(t, "to", string(changedErr))
+
+	// The concurrent change is not overwritten, and the source state is left untouched.
+	assert.Equal(t, "aws_instance.foo\n", readTestState(t, filepath.Join(tmpDir, "from")))
+	assert.Equal(t, "aws_instance.existing\naws_instance.concurrent\n", readTestState(t, filepath.Join(tmpDir, "to")))
+}
@@ -0,0 +1,71 @@
+#!/bin/sh
+# A stand-in for OpenTofu/Terraform, which keeps the state of a unit in the fake-state file of its working dir,
+# as one resource address per line. Pushing a state fails if the working dir has a fail-push file. Moving resources
+# appends a resource to the state file given with FAKE_TOFU_CONCURRENT_WRITE, as a concurrent apply would.
+
+state_cmd() {
+  cmd="$1"
+  shift
+
+  case "$cmd" in
+    pull)
+      if [ -f fake-state ]; then
+        cat fake-state
+      fi
+      ;;
+    push)
+      if [ -f fail-push ]; then
+        echo "Error: failed to push state" >&2
+        exit 1
+      fi
+
+      for arg in "$@"; do
+        file="$arg"
+      done
+
+      cp "$file" fake-state
+      ;;
+    mv)
+      for arg in "$@"; do
+        case "$arg" in
+          -state=*) from="${arg#-state=}" ;;
+          -state-out=*) to="${arg#-state-out=}" ;;
+          -*) ;;
+          *)
+            if [ -z "$src" ]; then src="$arg"; else dst="$arg"; fi
+            ;;
+        esac
+      done
+
+      if ! grep -qx "$src" "$from"; then
+        echo "Error: no resource $src in the state" >&2
+        exit 1
+      fi
+
+      if [ -n "$FAKE_TOFU_CONCURRENT_WRITE" ]; then
+        echo "aws_instance.concurrent" >> "$FAKE_TOFU_CONCURRENT_WRITE"
+      fi
+
+      grep -vx "$src" "$from" > "$from.tmp" || true
+      mv "$from.tmp" "$from"
+
+      # The pulled states have no trailing newline.
+      { cat "$to" 2>/dev/null; echo; echo "$dst"; } | grep -v '^$' > "$to.tmp"
+      mv "$to.tmp" "$to"
+      ;;
+    rm)
+      grep -vx "$1" fake-state > fake-state.tmp || true
+      mv fake-state.tmp fake-state
+      ;;
+  esac
+}
+
+case "$1" in
+  -version|version)
+    echo "Terraform v1.9.0"
+    ;;
+  state)
+    shift
+    state_cmd "$@"
+    ;;
+esac
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/sensitive"
 
 	"github.com/gruntwork-io/terragrunt/cli/commands/run"
 	"github.com/gruntwork-io/terragrunt/config"
@@ -29,13 +30,14 @@ type TerragruntInfoGroup struct {
 }
 
 func printTerragruntInfo(opts *options.TerragruntOptions) error {
+	// The values may be set from sensitive values of the configuration.
 	group := TerragruntInfoGroup{
-		ConfigPath:       opts.TerragruntConfigPath,
-		DownloadDir:      opts.DownloadDir,
-		IamRole:          opts.IAMRoleOptions.RoleARN,
-		TerraformBinary:  opts.TerraformPath,
+		ConfigPath:       sensitive.Redact(opts.TerragruntConfigPath),
+		DownloadDir:      sensitive.Redact(opts.DownloadDir),
+		IamRole:          redactSensitive(opts.IAMRoleOptions.RoleARN),
+		TerraformBinary:  redactSensitive(opts.TerraformPath),
 		TerraformCommand: opts.TerraformCommand,
-		WorkingDir:       opts.WorkingDir,
+		WorkingDir:       sensitive.Redact(opts.WorkingDir),
 	}
 
 	b, err := json.MarshalIndent(group, "", "  ")
@@ -52,6 +54,15 @@ func printTerragruntInfo(opts *options.TerragruntOptions) error {
 	return nil
 }
 
+// redactSensitive returns the redacted value in place of the given sensitive value.
+func redactSensitive(str string) string {
+	if sensitive.IsSensitive(str) {
+		return sensitive.Redacted
+	}
+
+	return sensitive.Redact(str)
+}
+
 func runTerragruntInfo(ctx context.Context, opts *options.TerragruntOptions, cfg *config.TerragruntConfig) error {
 	return printTerragruntInfo(opts)
 }
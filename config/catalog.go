@@ -77,7 +77,7 @@ func ReadCatalogConfig(parentCtx context.Context, opts *options.TerragruntOption
 	opts.TerragruntConfigPath = configPath
 
 	ctx := NewParsingContext(parentCtx, opts)
-	ctx.ParserOptions = append(ctx.ParserOptions, hclparse.WithHaltOnErrorOnlyForBlocks([]string{MetadataCatalog}))
+	ctx = ctx.WithParseOption(append(ctx.ParserOptions, hclparse.WithHaltOnErrorOnlyForBlocks([]string{MetadataCatalog})))
 	ctx.ConvertToTerragruntConfigFunc = convertToTerragruntCatalogConfig
 
 	// TODO: Resolve lint error
@@ -28,6 +28,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
 
 	"maps"
 
@@ -80,6 +81,8 @@ const (
 	MetadataFeatureFlag                 = "feature"
 	MetadataExclude                     = "exclude"
 	MetadataErrors                      = "errors"
+	MetadataScheduling                  = "scheduling"
+	MetadataPolicy                      = "policy"
 	MetadataRetry                       = "retry"
 	MetadataIgnore                      = "ignore"
 	MetadataValues                      = "values"
@@ -110,6 +113,7 @@ type DecodedBaseBlocks struct {
 	TrackInclude *TrackInclude
 	Locals       *cty.Value
 	FeatureFlags *cty.Value
+	Functions    map[string]function.Function
 }
 
 // TerragruntConfig represents a parsed and expanded configuration
@@ -127,6 +131,8 @@ type TerragruntConfig struct {
 	RemoteState                 *remote.RemoteState
 	Dependencies                *ModuleDependencies
 	Exclude                     *ExcludeConfig
+	Scheduling                  *SchedulingConfig
+	Policy                      *PolicyConfig
 	PreventDestroy              *bool
 	Skip                        *bool
 	Catalog                     *CatalogConfig
@@ -213,6 +219,8 @@ type terragruntConfigFile struct {
 	FeatureFlags             []*FeatureFlag      `hcl:"feature,block"`
 	Exclude                  *ExcludeConfig      `hcl:"exclude,block"`
 	Errors                   *ErrorsConfig       `hcl:"errors,block"`
+	Scheduling               *SchedulingConfig   `hcl:"scheduling,block"`
+	Policy                   *PolicyConfig       `hcl:"policy,block"`
 
 	// We allow users to configure code generation via blocks:
 	//
@@ -241,8 +249,9 @@ type terragruntConfigFile struct {
 	// referencing other elements in the same block.
 	// We don't want to use the special Remain keyword here, as that would cause the checker to support parsing config
 	// that have extraneous, unsupported blocks and attributes.
-	Locals  *terragruntLocal          `hcl:"locals,block"`
-	Include []terragruntIncludeIgnore `hcl:"include,block"`
+	Locals    *terragruntLocal           `hcl:"locals,block"`
+	Include   []terragruntIncludeIgnore  `hcl:"include,block"`
+	Functions []terragruntFunctionIgnore `hcl:"function,block"`
 }
 
 // We use a struct designed to not parse the block, as locals and includes are parsed and decoded using a special
@@ -698,7 +707,7 @@ func FindConfigFilesInPath(rootPath string, opts *options.TerragruntOptions) ([]
 			return nil
 		}
 
-		if ok, err := isTerragruntModuleDir(path, opts); err != nil {
+		if ok, err := IsTerragruntModuleDir(path, opts); err != nil {
 			return err
 		} else if !ok {
 			return filepath.SkipDir
@@ -721,9 +730,9 @@ func FindConfigFilesInPath(rootPath string, opts *options.TerragruntOptions) ([]
 	return configFiles, err
 }
 
-// isTerragruntModuleDir returns true if the given path contains a Terragrunt module and false otherwise. The path
+// IsTerragruntModuleDir returns true if the given path may contain a Terragrunt module and false otherwise. The path
 // can not contain a cache, data, or download dir.
-func isTerragruntModuleDir(path string, terragruntOptions *options.TerragruntOptions) (bool, error) {
+func IsTerragruntModuleDir(path string, terragruntOptions *options.TerragruntOptions) (bool, error) {
 	// Skip the Terragrunt cache dir
 	if util.ContainsPath(path, util.TerragruntCacheDir) {
 		return false, nil
@@ -917,6 +926,7 @@ func ParseConfig(ctx *ParsingContext, file *hclparse.File, includeFromChild *Inc
 	ctx = ctx.WithTrackInclude(baseBlocks.TrackInclude)
 	ctx = ctx.WithFeatures(baseBlocks.FeatureFlags)
 	ctx = ctx.WithLocals(baseBlocks.Locals)
+	ctx = ctx.WithFunctions(baseBlocks.Functions)
 
 	if ctx.DecodedDependencies == nil {
 		// Decode just the `dependency` blocks, retrieving the outputs from the target terragrunt config in the
@@ -1251,6 +1261,16 @@ func convertToTerragruntConfig(ctx *ParsingContext, configPath string, terragrun
 		terragruntConfig.SetFieldMetadata(MetadataEngine, defaultMetadata)
 	}
 
+	if terragruntConfigFromFile.Scheduling != nil {
+		terragruntConfig.Scheduling = terragruntConfigFromFile.Scheduling
+		terragruntConfig.SetFieldMetadata(MetadataScheduling, defaultMetadata)
+	}
+
+	if terragruntConfigFromFile.Policy != nil {
+		terragruntConfig.Policy = terragruntConfigFromFile.Policy
+		terragruntConfig.SetFieldMetadata(MetadataPolicy, defaultMetadata)
+	}
+
 	if terragruntConfigFromFile.FeatureFlags != nil {
 		terragruntConfig.FeatureFlags = terragruntConfigFromFile.FeatureFlags
 		for _, flag := range terragruntConfig.FeatureFlags {
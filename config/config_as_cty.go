@@ -50,6 +50,24 @@ func TerragruntConfigAsCty(config *TerragruntConfig) (cty.Value, error) {
 		output[MetadataEngine] = engineConfigCty
 	}
 
+	schedulingConfigCty, err := schedulingConfigAsCty(config.Scheduling)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	if schedulingConfigCty != cty.NilVal {
+		output[MetadataScheduling] = schedulingConfigCty
+	}
+
+	policyConfigCty, err := policyConfigAsCty(config.Policy)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	if policyConfigCty != cty.NilVal {
+		output[MetadataPolicy] = policyConfigCty
+	}
+
 	excludeConfigCty, err := excludeConfigAsCty(config.Exclude)
 	if err != nil {
 		return cty.NilVal, err
@@ -529,6 +547,24 @@ func engineConfigAsCty(config *EngineConfig) (cty.Value, error) {
 	return goTypeToCty(configCty)
 }
 
+// schedulingConfigAsCty serialize scheduling configuration to a cty Value.
+func schedulingConfigAsCty(config *SchedulingConfig) (cty.Value, error) {
+	if config == nil {
+		return cty.NilVal, nil
+	}
+
+	return goTypeToCty(config)
+}
+
+// policyConfigAsCty serialize policy configuration to a cty Value.
+func policyConfigAsCty(config *PolicyConfig) (cty.Value, error) {
+	if config == nil {
+		return cty.NilVal, nil
+	}
+
+	return goTypeToCty(config)
+}
+
 // excludeConfigAsCty serialize exclude configuration to a cty Value.
 func excludeConfigAsCty(config *ExcludeConfig) (cty.Value, error) {
 	if config == nil {
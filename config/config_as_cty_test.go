@@ -25,6 +25,8 @@ func TestTerragruntConfigAsCtyDrift(t *testing.T) {
 	mockOutputs := cty.Zero
 	mockOutputsAllowedTerraformCommands := []string{"init"}
 	dependentModulesPath := []*string{&testSource}
+	schedulingGroup := "eks"
+	schedulingWeight := 2
 	testConfig := config.TerragruntConfig{
 		Engine: &config.EngineConfig{
 			Source: "github.com/acme/terragrunt-plugin-custom-opentofu",
@@ -126,6 +128,15 @@ func TestTerragruntConfigAsCtyDrift(t *testing.T) {
 				},
 			},
 		},
+		Scheduling: &config.SchedulingConfig{
+			Group:  &schedulingGroup,
+			Weight: &schedulingWeight,
+		},
+		Policy: &config.PolicyConfig{
+			DenyDestroyTagged: map[string]string{"protected": "true"},
+			DenyDestroyTypes:  []string{"aws_db_instance"},
+			Command:           []string{"./check-plan.sh"},
+		},
 		GenerateConfigs: map[string]codegen.GenerateConfig{
 			"provider": {
 				Path:          "foo",
@@ -283,6 +294,10 @@ func terragruntConfigStructFieldToMapKey(t *testing.T, fieldName string) (string
 		return "exclude", true
 	case "Errors":
 		return "errors", true
+	case "Scheduling":
+		return "scheduling", true
+	case "Policy":
+		return "policy", true
 	default:
 		t.Fatalf("Unknown struct property: %s", fieldName)
 		// This should not execute
@@ -11,6 +11,7 @@ import (
 	"runtime"
 	"slices"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/getsops/sops/v3/cmd/sops/formats"
@@ -21,6 +22,7 @@ import (
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/function"
 	"github.com/zclconf/go-cty/cty/gocty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
 
 	"github.com/gruntwork-io/terragrunt/awshelper"
 	"github.com/gruntwork-io/terragrunt/config/hclparse"
@@ -29,6 +31,8 @@ import (
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/internal/experiment"
 	"github.com/gruntwork-io/terragrunt/internal/locks"
+	"github.com/gruntwork-io/terragrunt/internal/secrets"
+	"github.com/gruntwork-io/terragrunt/internal/sensitive"
 	"github.com/gruntwork-io/terragrunt/internal/strict/controls"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
@@ -48,6 +52,7 @@ const (
 	FuncNamePathRelativeFromInclude                 = "path_relative_from_include"
 	FuncNameGetEnv                                  = "get_env"
 	FuncNameRunCmd                                  = "run_cmd"
+	FuncNameRunCmdJSON                              = "run_cmd_json"
 	FuncNameReadTerragruntConfig                    = "read_terragrunt_config"
 	FuncNameGetPlatform                             = "get_platform"
 	FuncNameGetRepoRoot                             = "get_repo_root"
@@ -76,8 +81,18 @@ const (
 	FuncNameStrContains                             = "strcontains"
 	FuncNameTimeCmp                                 = "timecmp"
 	FuncNameMarkAsRead                              = "mark_as_read"
+	FuncNameSensitive                               = "sensitive"
+	FuncNameGetSecret                               = "get_secret"
 
 	sopsCacheName = "sopsCache"
+
+	// runCmdQuietFlag is the option of `run_cmd` that suppresses the output of the command, which marks the output as
+	// sensitive.
+	runCmdQuietFlag       = "--terragrunt-quiet"
+	runCmdGlobalCacheFlag = "--terragrunt-global-cache"
+	// runCmdCacheTTLFlag is the option of `run_cmd` that persists the output of the command in the on-disk cache for
+	// the given duration, e.g. `--terragrunt-cache-ttl=1h`.
+	runCmdCacheTTLFlag = "--terragrunt-cache-ttl"
 )
 
 // TerraformCommandsNeedLocking is a list of terraform commands that accept -lock-timeout
@@ -136,6 +151,9 @@ type TrackInclude struct {
 	// TerragruntConfig is returned. This preserves the order of the blocks as they appear in the config, so that we can
 	// merge the included config in the right order.
 	CurrentList IncludeConfigs
+	// CurrentFiles are the parsed files of the configs of CurrentList, in the same order, so that the blocks decoded
+	// along with the base blocks, such as the `function` blocks, are read without parsing the included configs again.
+	CurrentFiles []*hclparse.File
 }
 
 // Create an EvalContext for the HCL2 parser. We can define functions and variables in this ctx that the HCL2 parser
@@ -150,7 +168,8 @@ func createTerragruntEvalContext(ctx *ParsingContext, configPath string) (*hcl.E
 		FuncNamePathRelativeToInclude:                   wrapStringSliceToStringAsFuncImpl(ctx, PathRelativeToInclude),
 		FuncNamePathRelativeFromInclude:                 wrapStringSliceToStringAsFuncImpl(ctx, PathRelativeFromInclude),
 		FuncNameGetEnv:                                  wrapStringSliceToStringAsFuncImpl(ctx, getEnvironmentVariable),
-		FuncNameRunCmd:                                  wrapStringSliceToStringAsFuncImpl(ctx, RunCommand),
+		FuncNameRunCmd:                                  wrapSensitiveFuncImpl(wrapStringSliceToStringAsFuncImpl(ctx, RunCommand), isQuietRunCmd),
+		FuncNameRunCmdJSON:                              wrapSensitiveFuncImpl(runCommandJSONFuncImpl(ctx), isQuietRunCmd),
 		FuncNameReadTerragruntConfig:                    readTerragruntConfigAsFuncImpl(ctx),
 		FuncNameGetPlatform:                             wrapVoidToStringAsFuncImpl(ctx, getPlatform),
 		FuncNameGetRepoRoot:                             wrapVoidToStringAsFuncImpl(ctx, getRepoRoot),
@@ -169,12 +188,14 @@ func createTerragruntEvalContext(ctx *ParsingContext, configPath string) (*hcl.E
 		FuncNameGetTerraformCommandsThatNeedLocking:     wrapStaticValueToStringSliceAsFuncImpl(TerraformCommandsNeedLocking),
 		FuncNameGetTerraformCommandsThatNeedInput:       wrapStaticValueToStringSliceAsFuncImpl(TerraformCommandsNeedInput),
 		FuncNameGetTerraformCommandsThatNeedParallelism: wrapStaticValueToStringSliceAsFuncImpl(TerraformCommandsNeedParallelism),
-		FuncNameSopsDecryptFile:                         wrapStringSliceToStringAsFuncImpl(ctx, sopsDecryptFile),
+		FuncNameSopsDecryptFile:                         wrapSensitiveFuncImpl(wrapStringSliceToStringAsFuncImpl(ctx, sopsDecryptFile), nil),
 		FuncNameGetTerragruntSourceCLIFlag:              wrapVoidToStringAsFuncImpl(ctx, getTerragruntSourceCliFlag),
 		FuncNameGetDefaultRetryableErrors:               wrapVoidToStringSliceAsFuncImpl(ctx, getDefaultRetryableErrors),
 		FuncNameReadTfvarsFile:                          wrapStringSliceToStringAsFuncImpl(ctx, readTFVarsFile),
 		FuncNameGetWorkingDir:                           wrapVoidToStringAsFuncImpl(ctx, getWorkingDir),
 		FuncNameMarkAsRead:                              wrapStringSliceToStringAsFuncImpl(ctx, markAsRead),
+		FuncNameSensitive:                               sensitiveFuncImpl(),
+		FuncNameGetSecret:                               wrapSensitiveFuncImpl(wrapStringSliceToStringAsFuncImpl(ctx, getSecret), nil),
 
 		// Map with HCL functions introduced in Terraform after v0.15.3, since upgrade to a later version is not supported
 		// https://github.com/gruntwork-io/terragrunt/blob/master/go.mod#L22
@@ -342,22 +363,39 @@ func RunCommand(ctx *ParsingContext, args []string) (string, error) {
 	currentPath := filepath.Dir(ctx.TerragruntOptions.TerragruntConfigPath)
 	cachePath := currentPath
 
+	var cacheTTL time.Duration
+
 	checkOptions := true
 	for checkOptions && len(args) > 0 {
-		switch args[0] {
-		case "--terragrunt-quiet":
+		switch {
+		case args[0] == runCmdQuietFlag:
 			suppressOutput = true
 
 			args = slices.Delete(args, 0, 1)
-		case "--terragrunt-global-cache":
+		case args[0] == runCmdGlobalCacheFlag:
 			cachePath = "_global_"
 
+			args = slices.Delete(args, 0, 1)
+		case strings.HasPrefix(args[0], runCmdCacheTTLFlag+"="):
+			value := strings.TrimPrefix(args[0], runCmdCacheTTLFlag+"=")
+
+			ttl, err := time.ParseDuration(value)
+			if err != nil {
+				return "", errors.New(InvalidRunCmdCacheTTLError{Value: value, Err: err})
+			}
+
+			cacheTTL = ttl
+
 			args = slices.Delete(args, 0, 1)
 		default:
 			checkOptions = false
 		}
 	}
 
+	if len(args) == 0 {
+		return "", errors.New(EmptyStringNotAllowedError("command of the run_cmd function"))
+	}
+
 	// To avoid re-run of the same run_cmd command, is used in memory cache for command results, with caching key path + arguments
 	// see: https://github.com/gruntwork-io/terragrunt/issues/1427
 	cacheKey := fmt.Sprintf("%v-%v", cachePath, args)
@@ -373,6 +411,19 @@ func RunCommand(ctx *ParsingContext, args []string) (string, error) {
 		return cachedValue, nil
 	}
 
+	// The output of quiet commands is usually a secret, so it is never written to the on-disk cache.
+	persistOutput := cacheTTL > 0 && !suppressOutput
+
+	if persistOutput {
+		if value, ok := getPersistentRunCmdCache(ctx, cacheKey, cacheTTL); ok {
+			ctx.TerragruntOptions.Logger.Debugf("run_cmd, output from on-disk cache: [%s]", value)
+
+			runCommandCache.Put(ctx, cacheKey, value)
+
+			return value, nil
+		}
+	}
+
 	cmdOutput, err := shell.RunCommandWithOutput(ctx, ctx.TerragruntOptions, currentPath, suppressOutput, false, args[0], args[1:]...)
 	if err != nil {
 		return "", errors.New(err)
@@ -390,9 +441,55 @@ func RunCommand(ctx *ParsingContext, args []string) (string, error) {
 	// see: https://github.com/gruntwork-io/terragrunt/issues/1427
 	runCommandCache.Put(ctx, cacheKey, value)
 
+	if persistOutput {
+		if err := putPersistentRunCmdCache(ctx, cacheKey, value); err != nil {
+			ctx.TerragruntOptions.Logger.Warnf("Unable to write the output of run_cmd to the on-disk cache: %v", err)
+		}
+	}
+
 	return value, nil
 }
 
+// RunCommandJSON runs a command the same way as RunCommand and decodes its stdout as JSON, for each `run_cmd_json`
+// in the config.
+func RunCommandJSON(ctx *ParsingContext, args []string) (cty.Value, error) {
+	out, err := RunCommand(ctx, args)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	command := strings.Join(args, " ")
+
+	ctyType, err := ctyjson.ImpliedType([]byte(out))
+	if err != nil {
+		return cty.NilVal, errors.New(InvalidRunCmdJSONOutputError{Command: command, Err: err})
+	}
+
+	val, err := ctyjson.Unmarshal([]byte(out), ctyType)
+	if err != nil {
+		return cty.NilVal, errors.New(InvalidRunCmdJSONOutputError{Command: command, Err: err})
+	}
+
+	return val, nil
+}
+
+// runCommandJSONFuncImpl returns the `run_cmd_json` function, which takes the same parameters as `run_cmd` and returns
+// the stdout of the command decoded as JSON.
+func runCommandJSONFuncImpl(ctx *ParsingContext) function.Function {
+	return function.New(&function.Spec{
+		VarParam: &function.Parameter{Type: cty.String},
+		Type:     function.StaticReturnType(cty.DynamicPseudoType),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			params, err := ctySliceToStringSlice(args)
+			if err != nil {
+				return cty.NilVal, err
+			}
+
+			return RunCommandJSON(ctx, params)
+		},
+	})
+}
+
 func getEnvironmentVariable(ctx *ParsingContext, parameters []string) (string, error) {
 	parameterMap, err := parseGetEnvParameters(parameters)
 
@@ -905,6 +1002,33 @@ func sopsDecryptFile(ctx *ParsingContext, params []string) (string, error) {
 	return "", errors.New(InvalidSopsFormatError{SourceFilePath: sourceFile})
 }
 
+// getSecret returns the value of the given reference to a secret, read by the secret provider of its scheme, e.g.
+// `get_secret("vault://secret/data/db#password")`. Secrets are cached by working dir and IAM role, like the output of
+// `run_cmd`, so that they are read once per run.
+func getSecret(ctx *ParsingContext, params []string) (string, error) {
+	if len(params) != 1 {
+		return "", errors.New(WrongNumberOfParamsError{Func: FuncNameGetSecret, Expected: "1", Actual: len(params)})
+	}
+
+	reference := params[0]
+
+	secretCache := cache.ContextCache[string](ctx, SecretCacheContextKey)
+	cacheKey := fmt.Sprintf("%v-%v-%v", ctx.TerragruntOptions.WorkingDir, ctx.TerragruntOptions.IAMRoleOptions.RoleARN, reference)
+
+	if value, ok := secretCache.Get(ctx, cacheKey); ok {
+		return value, nil
+	}
+
+	value, err := secrets.ProvidersFromContext(ctx).Get(ctx, ctx.TerragruntOptions, reference)
+	if err != nil {
+		return "", err
+	}
+
+	secretCache.Put(ctx, cacheKey, value)
+
+	return value, nil
+}
+
 // Mapping of SOPS format to string
 var sopsFormatToString = map[formats.Format]string{
 	formats.Binary: "binary",
@@ -1174,7 +1298,7 @@ func ParseAndDecodeVarFile(opts *options.TerragruntOptions, varFile string, file
 		return nil
 	}
 
-	return gocty.FromCtyValue(ctyVal, out)
+	return gocty.FromCtyValue(sensitive.Unmark(ctyVal), out)
 }
 
 // extractSopsErrors extracts the original errors from the sops library and returns them as a errors.MultiError.
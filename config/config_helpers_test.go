@@ -9,6 +9,8 @@ import (
 
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/secrets"
+	"github.com/gruntwork-io/terragrunt/internal/sensitive"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/test/helpers"
 	"github.com/stretchr/testify/assert"
@@ -212,6 +214,24 @@ func TestRunCommand(t *testing.T) {
 	}
 }
 
+func TestRunCommandJSON(t *testing.T) {
+	t.Parallel()
+
+	homeDir := os.Getenv("HOME")
+
+	ctx := config.NewParsingContext(context.Background(), terragruntOptionsForTest(t, homeDir))
+	actualOutput, err := config.RunCommandJSON(ctx, []string{"--terragrunt-quiet", "/bin/bash", "-c", `echo '{"name": "foo", "ports": [80, 443]}'`})
+	require.NoError(t, err)
+	assert.True(t, cty.ObjectVal(map[string]cty.Value{
+		"name":  cty.StringVal("foo"),
+		"ports": cty.TupleVal([]cty.Value{cty.NumberIntVal(80), cty.NumberIntVal(443)}),
+	}).Equals(actualOutput).True())
+
+	_, err = config.RunCommandJSON(ctx, []string{"/bin/bash", "-c", "echo foo"})
+	require.Error(t, err)
+	assert.ErrorAs(t, err, &config.InvalidRunCmdJSONOutputError{})
+}
+
 func absPath(t *testing.T, path string) string {
 	t.Helper()
 
@@ -1270,3 +1290,53 @@ func getTrackIncludeFromTestData(includeMap map[string]config.IncludeConfig, par
 	}
 	return trackInclude
 }
+
+type testSecretProvider struct {
+	secrets map[string]string
+	reads   int
+}
+
+func (provider *testSecretProvider) Scheme() string {
+	return "test"
+}
+
+func (provider *testSecretProvider) GetSecret(_ context.Context, _ *options.TerragruntOptions, ref *secrets.Reference) (string, error) {
+	provider.reads++
+
+	value, ok := provider.secrets[ref.Path]
+	if !ok {
+		return "", secrets.SecretNotFoundError{Reference: ref.String()}
+	}
+
+	return value, nil
+}
+
+func TestGetSecret(t *testing.T) {
+	t.Parallel()
+
+	provider := &testSecretProvider{secrets: map[string]string{"db": `{"user": "app", "password": "get-secret-test-password"}`}}
+
+	cfg := `
+locals {
+  user = get_secret("test://db#user")
+}
+
+inputs = {
+  user     = local.user
+  password = get_secret("test://db#password")
+}
+`
+
+	ctx := config.WithConfigValues(secrets.ContextWithProviders(context.Background(), secrets.NewProviders(provider)))
+	parsingCtx := config.NewParsingContext(ctx, terragruntOptionsForTest(t, config.DefaultTerragruntConfigPath))
+
+	terragruntConfig, err := config.ParseConfigString(parsingCtx, "mock-path-for-test.hcl", cfg, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{"user": "app", "password": "get-secret-test-password"}, terragruntConfig.Inputs)
+	assert.True(t, sensitive.IsSensitive("get-secret-test-password"))
+	assert.Equal(t, 2, provider.reads, "the secrets must be read once")
+
+	_, err = config.ParseConfigString(parsingCtx, "mock-path-for-test.hcl", `inputs = { password = get_secret("test://missing") }`, nil)
+	require.ErrorContains(t, err, "the secret test://missing was not found")
+}
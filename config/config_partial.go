@@ -38,6 +38,7 @@ const (
 	EngineBlock
 	ExcludeBlock
 	ErrorsBlock
+	SchedulingBlock
 )
 
 // terragruntIncludeMultiple is a struct that can be used to only decode the include block with labels.
@@ -119,6 +120,12 @@ type terragruntInputs struct {
 	Remain hcl.Body   `hcl:",remain"`
 }
 
+// terragruntScheduling is a struct that can only be used to decode the scheduling block.
+type terragruntScheduling struct {
+	Scheduling *SchedulingConfig `hcl:"scheduling,block"`
+	Remain     hcl.Body          `hcl:",remain"`
+}
+
 // terragruntEngine is a struct that can only be used to decode the engine block.
 type terragruntEngine struct {
 	Engine *EngineConfig `hcl:"engine,block"`
@@ -176,9 +183,15 @@ func DecodeBaseBlocks(ctx *ParsingContext, file *hclparse.File, includeFromChild
 		return nil, err
 	}
 
+	// Decode the functions defined in this config and the included configs, to be called like the built-in functions.
+	functions, err := decodeFunctionBlocks(ctx.WithTrackInclude(trackInclude), file)
+	if err != nil {
+		return nil, err
+	}
+
 	// Evaluate all the expressions in the locals block separately and generate the variables list to use in the
 	// evaluation ctx.
-	locals, err := EvaluateLocalsBlock(ctx.WithTrackInclude(trackInclude).WithFeatures(&flagsAsCtyVal), file)
+	locals, err := EvaluateLocalsBlock(ctx.WithTrackInclude(trackInclude).WithFeatures(&flagsAsCtyVal).WithFunctions(functions), file)
 	if err != nil {
 		return nil, err
 	}
@@ -192,6 +205,7 @@ func DecodeBaseBlocks(ctx *ParsingContext, file *hclparse.File, includeFromChild
 		TrackInclude: trackInclude,
 		Locals:       &localsAsCtyVal,
 		FeatureFlags: &flagsAsCtyVal,
+		Functions:    functions,
 	}, nil
 }
 
@@ -235,6 +249,12 @@ func cliFlagsToCty(ctx *ParsingContext, flagByName map[string]*FeatureFlag) (map
 		return make(map[string]cty.Value), nil
 	}
 
+	if ctx.TerragruntOptions.LoadFeatureFlags != nil {
+		if err := ctx.TerragruntOptions.LoadFeatureFlags(); err != nil {
+			return nil, err
+		}
+	}
+
 	evaluatedFlags := make(map[string]cty.Value)
 
 	var conversionErr error
@@ -269,6 +289,22 @@ func cliFlagsToCty(ctx *ParsingContext, flagByName map[string]*FeatureFlag) (map
 }
 
 func PartialParseConfigFile(ctx *ParsingContext, configPath string, include *IncludeConfig) (*TerragruntConfig, error) {
+	file, err := parseFileWithCache(ctx, configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return TerragruntConfigFromPartialConfig(ctx, file, include)
+}
+
+// parseFileWithCache parses the given config file, or returns the file already parsed if it has not changed since.
+// Files parsed with custom parser options, e.g. discarding diagnostics, are neither cached nor taken from the cache,
+// as they would handle the diagnostics of later parsing the same way.
+func parseFileWithCache(ctx *ParsingContext, configPath string) (*hclparse.File, error) {
+	if ctx.customParserOptions {
+		return hclparse.NewParser(ctx.ParserOptions...).ParseFromFile(configPath)
+	}
+
 	hclCache := cache.ContextCache[*hclparse.File](ctx, HclCacheContextKey)
 
 	fileInfo, err := os.Stat(configPath)
@@ -276,21 +312,20 @@ func PartialParseConfigFile(ctx *ParsingContext, configPath string, include *Inc
 		return nil, errors.New(err)
 	}
 
-	var (
-		file     *hclparse.File
-		cacheKey = fmt.Sprintf("configPath-%v-modTime-%v", configPath, fileInfo.ModTime().UnixMicro())
-	)
+	cacheKey := fmt.Sprintf("configPath-%v-modTime-%v", configPath, fileInfo.ModTime().UnixMicro())
 
-	if cacheConfig, found := hclCache.Get(ctx, cacheKey); found {
-		file = cacheConfig
-	} else {
-		file, err = hclparse.NewParser(ctx.ParserOptions...).ParseFromFile(configPath)
-		if err != nil {
-			return nil, err
-		}
+	if file, found := hclCache.Get(ctx, cacheKey); found {
+		return file, nil
 	}
 
-	return TerragruntConfigFromPartialConfig(ctx, file, include)
+	file, err := hclparse.NewParser(ctx.ParserOptions...).ParseFromFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	hclCache.Put(ctx, cacheKey, file)
+
+	return file, nil
 }
 
 // TerragruntConfigFromPartialConfig is a wrapper of PartialParseConfigString which checks for cached configs.
@@ -338,6 +373,7 @@ func TerragruntConfigFromPartialConfig(ctx *ParsingContext, file *hclparse.File,
 //   - FeatureFlagsBlock: Parses the `feature` block in the config
 //   - EngineBlock: Parses the `engine` block in the config
 //   - ExcludeBlock : Parses the `exclude` block in the config
+//   - SchedulingBlock: Parses the `scheduling` block in the config
 //
 // Note that the following blocks are always decoded:
 // - locals
@@ -376,6 +412,7 @@ func PartialParseConfig(ctx *ParsingContext, file *hclparse.File, includeFromChi
 	ctx = ctx.WithTrackInclude(baseBlocks.TrackInclude)
 	ctx = ctx.WithFeatures(baseBlocks.FeatureFlags)
 	ctx = ctx.WithLocals(baseBlocks.Locals)
+	ctx = ctx.WithFunctions(baseBlocks.Functions)
 
 	// Set parsed Locals on the parsed config
 	output, err := convertToTerragruntConfig(ctx, file.ConfigPath, &terragruntConfigFile{})
@@ -462,6 +499,16 @@ func PartialParseConfig(ctx *ParsingContext, file *hclparse.File, includeFromChi
 
 			output.Engine = decoded.Engine
 
+		case SchedulingBlock:
+			decoded := terragruntScheduling{}
+
+			err := file.Decode(&decoded, evalParsingContext)
+			if err != nil {
+				return nil, err
+			}
+
+			output.Scheduling = decoded.Scheduling
+
 		case TerragruntFlags:
 			decoded := terragruntFlags{}
 
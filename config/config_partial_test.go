@@ -1,10 +1,19 @@
 package config_test
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/config/hclparse"
+	"github.com/gruntwork-io/terragrunt/internal/featureflags"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/pkg/log/format"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/zclconf/go-cty/cty"
@@ -358,3 +367,105 @@ dependency "ec2" {
 	require.NoError(t, err)
 	assert.Len(t, terragruntConfig.Dependencies.Paths, 1)
 }
+
+func TestPartialParseLoadsFeatureFlags(t *testing.T) {
+	t.Parallel()
+
+	cfg := `
+feature "region" {
+  default = "us-east-1"
+}
+
+locals {
+  region = feature.region.value
+}
+`
+
+	opts := mockOptionsForTest(t)
+
+	loads := 0
+	opts.LoadFeatureFlags = func() error {
+		loads++
+		opts.FeatureFlags.Store("region", "eu-west-1")
+
+		return nil
+	}
+
+	ctx := config.NewParsingContext(context.Background(), opts).WithDecodeList(config.DependenciesBlock)
+	terragruntConfig, err := config.PartialParseConfigString(ctx, config.DefaultTerragruntConfigPath, cfg, nil)
+	require.NoError(t, err)
+
+	// The feature flags of the external sources are resolved when the configuration is parsed.
+	assert.Positive(t, loads)
+	assert.Equal(t, map[string]any{"region": "eu-west-1"}, terragruntConfig.Locals)
+}
+
+func TestPartialParseFeatureFlagPrecedence(t *testing.T) {
+	t.Parallel()
+
+	cfg := `
+feature "region" {
+  default = "us-east-1"
+}
+
+feature "size" {
+  default = "small"
+}
+
+locals {
+  region = feature.region.value
+  size   = feature.size.value
+}
+`
+
+	opts := mockOptionsForTest(t)
+	opts.FeatureFlagFile = filepath.Join(t.TempDir(), "flags.yaml")
+	require.NoError(t, os.WriteFile(opts.FeatureFlagFile, []byte("region: eu-west-1\nsize: large\n"), 0600))
+
+	// The flag set with --feature before the external sources are loaded, as when parsing the CLI args.
+	opts.FeatureFlags.Store("region", "ap-south-1")
+	opts.LoadFeatureFlags = sync.OnceValue(func() error {
+		return featureflags.Load(context.Background(), opts)
+	})
+
+	ctx := config.NewParsingContext(context.Background(), opts).WithDecodeList(config.DependenciesBlock)
+	terragruntConfig, err := config.PartialParseConfigString(ctx, config.DefaultTerragruntConfigPath, cfg, nil)
+	require.NoError(t, err)
+
+	// --feature wins over the file, which wins over the default.
+	assert.Equal(t, map[string]any{"region": "ap-south-1", "size": "large"}, terragruntConfig.Locals)
+}
+
+func TestPartialParseDoesNotCacheFilesParsedWithCustomOptions(t *testing.T) {
+	t.Parallel()
+
+	configPath := filepath.Join(t.TempDir(), config.DefaultTerragruntConfigPath)
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+dependencies {
+  paths = [unknown_function()]
+}
+`), 0644))
+
+	ctx := config.WithConfigValues(context.Background())
+
+	// The file is first parsed discarding the diagnostics, as when reading the outputs of a dependency.
+	opts := mockOptionsForTestWithConfigPath(t, configPath)
+	parsingCtx := config.NewParsingContext(ctx, opts).
+		WithParseOption([]hclparse.Option{hclparse.WithDiagnosticsWriter(io.Discard, true)}).
+		WithDecodeList(config.DependenciesBlock)
+	_, err := config.PartialParseConfigFile(parsingCtx, configPath, nil)
+	require.Error(t, err)
+
+	// Parsing it again with the default options must not reuse the file discarding the diagnostics.
+	output := new(bytes.Buffer)
+	formatter := format.NewFormatter(format.NewKeyValueFormatPlaceholders())
+	formatter.SetDisabledColors(true)
+
+	opts = mockOptionsForTestWithConfigPath(t, configPath)
+	opts.Logger = log.New(log.WithOutput(output), log.WithFormatter(formatter))
+	parsingCtx = config.NewParsingContext(ctx, opts).WithDecodeList(config.DependenciesBlock)
+	_, err = config.PartialParseConfigFile(parsingCtx, configPath, nil)
+	require.Error(t, err)
+
+	assert.Contains(t, output.String(), "unknown_function")
+}
@@ -13,6 +13,7 @@ import (
 	"maps"
 
 	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/sensitive"
 )
 
 // Create a cty Function that takes as input parameters a slice of strings (var args, so this slice could be of any
@@ -230,7 +231,7 @@ func deepMergeCtyMapsMapOnly(target cty.Value, source cty.Value, opts ...func(*m
 // we convert the given value to JSON using cty's JSON library and then convert the JSON back to a
 // map[string]interface{} using the Go json library.
 func ParseCtyValueToMap(value cty.Value) (map[string]any, error) {
-	updatedValue, err := UpdateUnknownCtyValValues(value)
+	updatedValue, err := UpdateUnknownCtyValValues(sensitive.Unmark(value))
 	if err != nil {
 		return nil, err
 	}
@@ -397,6 +398,8 @@ func UpdateUnknownCtyValValues(value cty.Value) (cty.Value, error) {
 
 // CtyToStruct converts a cty.Value to a go struct.
 func CtyToStruct(ctyValue cty.Value, target any) error {
+	ctyValue = sensitive.Unmark(ctyValue)
+
 	jsonBytes, err := ctyjson.Marshal(ctyValue, ctyValue.Type())
 	if err != nil {
 		return errors.New(err)
@@ -411,6 +414,8 @@ func CtyToStruct(ctyValue cty.Value, target any) error {
 
 // CtyValueAsString converts a cty.Value to a string.
 func CtyValueAsString(val cty.Value) (string, error) {
+	val = sensitive.Unmark(val)
+
 	jsonBytes, err := ctyjson.Marshal(val, val.Type())
 
 	if err != nil {
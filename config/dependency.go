@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -16,8 +15,6 @@ import (
 	"github.com/gruntwork-io/terragrunt/internal/cache"
 	"github.com/gruntwork-io/terragrunt/internal/experiment"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/go-getter"
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/gocty"
@@ -30,6 +27,7 @@ import (
 	"github.com/gruntwork-io/terragrunt/codegen"
 	"github.com/gruntwork-io/terragrunt/config/hclparse"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/sensitive"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/remote"
 	"github.com/gruntwork-io/terragrunt/tf"
@@ -616,6 +614,38 @@ func getOutputJSONWithCaching(ctx *ParsingContext, targetConfig string) ([]byte,
 		return rawJSONBytes.([]byte), nil
 	}
 
+	// When the persistent output cache is enabled, look up outputs stored by previous invocations, verified against
+	// the current state version. If the backend can't tell the version, or it can't be read, the cache is skipped.
+	var stateVersion *dependencyStateVersion
+
+	usePersistentCache := ctx.TerragruntOptions.DependencyOutputCache
+
+	if usePersistentCache {
+		var err error
+
+		stateVersion, err = getDependencyStateVersion(ctx, targetConfig)
+
+		switch {
+		case err != nil:
+			ctx.TerragruntOptions.Logger.Warnf("Unable to read the state version of %s, ignoring persistent output cache: %v", targetConfig, err)
+
+			usePersistentCache = false
+		case stateVersion == nil:
+			ctx.TerragruntOptions.Logger.Debugf("The backend of %s can't tell the state version, ignoring persistent output cache", targetConfig)
+
+			usePersistentCache = false
+		}
+	}
+
+	if usePersistentCache {
+		if cachedJSONBytes, found := getPersistentOutputCache(ctx, targetConfig, stateVersion); found {
+			ctx.TerragruntOptions.Logger.Debugf("Using persistent output cache for %s.", targetConfig)
+			jsonOutputCache.Store(targetConfig, []byte(cachedJSONBytes))
+
+			return cachedJSONBytes, nil
+		}
+	}
+
 	// Cache miss, so look up the output and store in cache
 	newJSONBytes, err := getTerragruntOutputJSON(ctx, targetConfig)
 	if err != nil {
@@ -631,6 +661,12 @@ func getOutputJSONWithCaching(ctx *ParsingContext, targetConfig string) ([]byte,
 
 	jsonOutputCache.Store(targetConfig, newJSONBytes)
 
+	if usePersistentCache {
+		if err := putPersistentOutputCache(ctx, targetConfig, stateVersion, newJSONBytes); err != nil {
+			ctx.TerragruntOptions.Logger.Warnf("Failed to store outputs of %s in persistent output cache: %v", targetConfig, err)
+		}
+	}
+
 	return newJSONBytes, nil
 }
 
@@ -794,6 +830,7 @@ func getTerragruntOutputJSON(ctx *ParsingContext, targetConfig string) ([]byte,
 	return getTerragruntOutputJSONFromRemoteState(
 		ctx,
 		targetConfig,
+		workingDir,
 		remoteStateTGConfig.RemoteState,
 		remoteStateTGConfig.GetIAMRoleOptions(),
 	)
@@ -885,6 +922,7 @@ func getTerragruntOutputJSONFromInitFolder(ctx *ParsingContext, terraformWorking
 func getTerragruntOutputJSONFromRemoteState(
 	ctx *ParsingContext,
 	targetConfigPath string,
+	terraformWorkingDir string,
 	remoteState *remote.RemoteState,
 	iamRoleOpts options.IAMRoleOptions,
 ) ([]byte, error) {
@@ -919,21 +957,21 @@ func getTerragruntOutputJSONFromRemoteState(
 
 	// To speed up dependencies processing it is possible to retrieve its output directly from the backend without init dependencies
 	if ctx.TerragruntOptions.FetchDependencyOutputFromState {
-		switch backend := remoteState.Backend; backend {
-		case "s3":
-			jsonBytes, err := getTerragruntOutputJSONFromRemoteStateS3(
-				targetTGOptions,
-				remoteState,
-			)
+		if remoteState.CanReadState() {
+			jsonBytes, err := getTerragruntOutputJSONFromRemoteStateReader(ctx, targetTGOptions, terraformWorkingDir, remoteState)
 			if err != nil {
 				return nil, err
 			}
 
-			ctx.TerragruntOptions.Logger.Debugf("Retrieved output from %s as json: %s using s3 bucket", targetTGOptions.TerragruntConfigPath, jsonBytes)
+			if jsonBytes != nil {
+				ctx.TerragruntOptions.Logger.Debugf("Retrieved output from %s as json: %s using %s backend", targetTGOptions.TerragruntConfigPath, jsonBytes, remoteState.Backend)
+
+				return jsonBytes, nil
+			}
 
-			return jsonBytes, nil
-		default:
-			ctx.TerragruntOptions.Logger.Errorf("FetchDependencyOutputFromState is not supported for backend %s, falling back to normal method", backend)
+			ctx.TerragruntOptions.Logger.Debugf("No state of %s found in %s backend, falling back to normal method", targetTGOptions.TerragruntConfigPath, remoteState.Backend)
+		} else {
+			ctx.TerragruntOptions.Logger.Errorf("FetchDependencyOutputFromState is not supported for backend %s, falling back to normal method", remoteState.Backend)
 		}
 	}
 
@@ -978,54 +1016,37 @@ func getTerragruntOutputJSONFromRemoteState(
 	return jsonBytes, nil
 }
 
-// getTerragruntOutputJSONFromRemoteStateS3 pulls the output directly from an S3 bucket without calling Terraform
-func getTerragruntOutputJSONFromRemoteStateS3(terragruntOptions *options.TerragruntOptions, remoteState *remote.RemoteState) ([]byte, error) {
-	terragruntOptions.Logger.Debugf("Fetching outputs directly from s3://%s/%s", remoteState.Config["bucket"], remoteState.Config["key"])
+// getTerragruntOutputJSONFromRemoteStateReader pulls the state directly from the backend storage without calling
+// Terraform, and extracts the outputs from it. Relative state paths are resolved against the given Terraform working
+// dir of the dependency. Returns nil if there is no state, so that the caller can fall back to `terraform output`.
+func getTerragruntOutputJSONFromRemoteStateReader(ctx *ParsingContext, terragruntOptions *options.TerragruntOptions, terraformWorkingDir string, remoteState *remote.RemoteState) ([]byte, error) {
+	readOptions := terragruntOptions.Clone()
+	readOptions.WorkingDir = terraformWorkingDir
 
-	s3ConfigExtended, err := remote.ParseExtendedS3Config(remoteState.Config)
+	stateBody, err := remoteState.ReadState(ctx, readOptions)
 	if err != nil {
 		return nil, err
 	}
 
-	sessionConfig := s3ConfigExtended.GetAwsSessionConfig()
-
-	s3Client, err := remote.CreateS3Client(sessionConfig, terragruntOptions)
-	if err != nil {
-		return nil, err
+	if len(bytes.TrimSpace(stateBody)) == 0 {
+		return nil, nil
 	}
 
-	result, err := s3Client.GetObject(&s3.GetObjectInput{
-		Bucket: aws.String(fmt.Sprintf("%s", remoteState.Config["bucket"])),
-		Key:    aws.String(fmt.Sprintf("%s", remoteState.Config["key"])),
-	})
-
-	if err != nil {
-		return nil, err
+	var state struct {
+		Outputs map[string]any `json:"outputs"`
 	}
 
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			terragruntOptions.Logger.Warnf("Failed to close remote state response %v", err)
-		}
-	}(result.Body)
-
-	steateBody, err := io.ReadAll(result.Body)
-	if err != nil {
-		return nil, err
+	if err := json.Unmarshal(stateBody, &state); err != nil {
+		return nil, errors.New(err)
 	}
 
-	jsonState := string(steateBody)
-	jsonMap := make(map[string]any)
-
-	err = json.Unmarshal([]byte(jsonState), &jsonMap)
-	if err != nil {
-		return nil, err
+	if state.Outputs == nil {
+		state.Outputs = make(map[string]any)
 	}
 
-	jsonOutputs, err := json.Marshal(jsonMap["outputs"])
+	jsonOutputs, err := json.Marshal(state.Outputs)
 	if err != nil {
-		return nil, err
+		return nil, errors.New(err)
 	}
 
 	return jsonOutputs, nil
@@ -1126,6 +1147,11 @@ func TerraformOutputJSONToCtyValueMap(targetConfigPath string, jsonBytes []byte)
 			return nil, errors.New(TerragruntOutputParsingError{Path: targetConfigPath, Err: err})
 		}
 
+		// Keep track of the sensitive outputs, so that they are redacted wherever they are used.
+		if v.Sensitive {
+			outputVal = sensitive.MarkValue(outputVal)
+		}
+
 		flattenedOutput[k] = outputVal
 	}
 
@@ -0,0 +1,241 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/config/hclparse"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// dependencyOutputCacheDirName is the name of the dir within the Terragrunt cache dir that holds the persistent
+// dependency output cache.
+const dependencyOutputCacheDirName = "dependency-outputs"
+
+// persistentOutputCacheEntry is a single entry of the persistent dependency output cache, stored as a JSON file.
+type persistentOutputCacheEntry struct {
+	CreatedAt    time.Time       `json:"created_at"`
+	ConfigPath   string          `json:"config_path"`
+	StateVersion string          `json:"state_version"`
+	Outputs      json.RawMessage `json:"outputs"`
+}
+
+// dependencyStateVersion identifies a version of the state of a dependency, as returned by the backend, e.g. the
+// generation of the state object.
+type dependencyStateVersion struct {
+	Version string
+}
+
+// getPersistentOutputCacheDir returns the directory of the persistent dependency output cache.
+func getPersistentOutputCacheDir(opts *options.TerragruntOptions) (string, error) {
+	if opts.DependencyOutputCacheDir != "" {
+		return opts.DependencyOutputCacheDir, nil
+	}
+
+	cacheDir, err := util.GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(cacheDir, dependencyOutputCacheDirName), nil
+}
+
+// dependencyOutputCacheEnvPrefixes are the prefixes of the environment variables that can change the outputs read
+// from a dependency, such as `TF_WORKSPACE`, `TG_*` inputs, and the credentials of the backend. They are part of the
+// key of the cache entries, along with the IAM role, so that outputs read in a different environment aren't reused.
+var dependencyOutputCacheEnvPrefixes = []string{"TF_", "TG_", "TERRAGRUNT_", "AWS_", "GOOGLE_", "ARM_"}
+
+// getPersistentOutputCacheConfigDir returns the directory holding the cache entries of the given dependency config.
+func getPersistentOutputCacheConfigDir(opts *options.TerragruntOptions, targetConfig string) (string, error) {
+	cacheDir, err := getPersistentOutputCacheDir(opts)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256([]byte(filepath.Clean(targetConfig)))
+
+	return filepath.Join(cacheDir, hex.EncodeToString(hash[:])), nil
+}
+
+// getPersistentOutputCachePath returns the path of the cache entry for the given dependency config, read with the
+// workspace, `--iam-role` and environment of the given options.
+func getPersistentOutputCachePath(opts *options.TerragruntOptions, targetConfig string) (string, error) {
+	configDir, err := getPersistentOutputCacheConfigDir(opts, targetConfig)
+	if err != nil {
+		return "", err
+	}
+
+	keys := []string{
+		"iam_role=" + opts.OriginalIAMRoleOptions.RoleARN,
+		"iam_assume_role_session_name=" + opts.OriginalIAMRoleOptions.AssumeRoleSessionName,
+	}
+
+	for name, value := range opts.Env {
+		for _, prefix := range dependencyOutputCacheEnvPrefixes {
+			if strings.HasPrefix(name, prefix) {
+				keys = append(keys, "env."+name+"="+value)
+				break
+			}
+		}
+	}
+
+	sort.Strings(keys)
+
+	hash := sha256.Sum256([]byte(strings.Join(keys, "\n")))
+
+	return filepath.Join(configDir, hex.EncodeToString(hash[:])+".json"), nil
+}
+
+// getPersistentOutputCache returns the cached outputs of the given dependency config if there is an entry that has
+// not expired and that matches the given state version.
+func getPersistentOutputCache(ctx *ParsingContext, targetConfig string, stateVersion *dependencyStateVersion) ([]byte, bool) {
+	logger := ctx.TerragruntOptions.Logger
+
+	cachePath, err := getPersistentOutputCachePath(ctx.TerragruntOptions, targetConfig)
+	if err != nil {
+		logger.Debugf("Unable to locate persistent output cache for %s: %v", targetConfig, err)
+		return nil, false
+	}
+
+	if !util.FileExists(cachePath) {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		logger.Debugf("Unable to read persistent output cache %s: %v", cachePath, err)
+		return nil, false
+	}
+
+	var entry persistentOutputCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		logger.Debugf("Ignoring invalid persistent output cache %s: %v", cachePath, err)
+		return nil, false
+	}
+
+	if entry.ConfigPath != filepath.Clean(targetConfig) {
+		return nil, false
+	}
+
+	if time.Since(entry.CreatedAt) > ctx.TerragruntOptions.DependencyOutputCacheTTL {
+		logger.Debugf("Persistent output cache for %s has expired", targetConfig)
+		return nil, false
+	}
+
+	if entry.StateVersion != stateVersion.Version {
+		logger.Debugf("Persistent output cache for %s is stale: state version %q does not match cached version %q", targetConfig, stateVersion.Version, entry.StateVersion)
+		return nil, false
+	}
+
+	return entry.Outputs, true
+}
+
+// putPersistentOutputCache stores the outputs of the given dependency config along with the state version they were
+// read from.
+func putPersistentOutputCache(ctx *ParsingContext, targetConfig string, stateVersion *dependencyStateVersion, jsonBytes []byte) error {
+	if !json.Valid(jsonBytes) {
+		return nil
+	}
+
+	cachePath, err := getPersistentOutputCachePath(ctx.TerragruntOptions, targetConfig)
+	if err != nil {
+		return err
+	}
+
+	entry := persistentOutputCacheEntry{
+		ConfigPath:   filepath.Clean(targetConfig),
+		CreatedAt:    time.Now(),
+		StateVersion: stateVersion.Version,
+		Outputs:      jsonBytes,
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if err := util.EnsureDirectory(filepath.Dir(cachePath)); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that concurrent readers never see a partially written entry.
+	tmpFile, err := os.CreateTemp(filepath.Dir(cachePath), filepath.Base(cachePath)+".*")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()           //nolint:errcheck
+		os.Remove(tmpFile.Name()) //nolint:errcheck
+
+		return errors.New(err)
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return errors.New(err)
+	}
+
+	if err := os.Rename(tmpFile.Name(), cachePath); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// InvalidateDependencyOutputCache removes the persistent output cache entries of the given config, whatever the
+// environment they were read in. This is called whenever a command that can change the state of the unit is run.
+func InvalidateDependencyOutputCache(opts *options.TerragruntOptions, configPath string) error {
+	configDir, err := getPersistentOutputCacheConfigDir(opts, configPath)
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(configDir); err != nil {
+		return errors.New(err)
+	}
+
+	opts.Logger.Debugf("Invalidated persistent output cache for %s", configPath)
+
+	return nil
+}
+
+// getDependencyStateVersion returns the version of the current state of the given dependency config, from the
+// metadata of the state in the backend, so that the state doesn't have to be downloaded. Returns nil if the backend
+// can't tell the version, in which case the cache is skipped, and an error if reading the version failed.
+func getDependencyStateVersion(ctx *ParsingContext, targetConfig string) (*dependencyStateVersion, error) {
+	targetTGOptions, err := cloneTerragruntOptionsForDependencyOutput(ctx, targetConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = ctx.WithTerragruntOptions(targetTGOptions)
+
+	parseOptions := append(ctx.ParserOptions, hclparse.WithDiagnosticsWriter(io.Discard, true))
+
+	remoteStateTGConfig, err := PartialParseConfigFile(ctx.WithParseOption(parseOptions).WithDecodeList(
+		RemoteStateBlock, TerragruntFlags), targetConfig, nil)
+	if err != nil || !canGetRemoteState(remoteStateTGConfig.RemoteState) || !remoteStateTGConfig.RemoteState.CanReadStateVersion() {
+		return nil, nil
+	}
+
+	stateOpts, err := setupTerragruntOptionsForBareTerraform(ctx, targetTGOptions.WorkingDir, targetConfig, remoteStateTGConfig.GetIAMRoleOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := remoteStateTGConfig.RemoteState.ReadStateVersion(ctx, stateOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dependencyStateVersion{Version: version}, nil
+}
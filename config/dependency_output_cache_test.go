@@ -0,0 +1,166 @@
+package config_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistentDependencyOutputCache(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	depDir := filepath.Join(tmpDir, "dep")
+	appDir := filepath.Join(tmpDir, "app")
+	cacheDir := filepath.Join(tmpDir, "cache")
+
+	require.NoError(t, os.MkdirAll(depDir, os.ModePerm))
+	require.NoError(t, os.MkdirAll(appDir, os.ModePerm))
+
+	depConfigPath := filepath.Join(depDir, config.DefaultTerragruntConfigPath)
+	require.NoError(t, os.WriteFile(depConfigPath, []byte(`
+remote_state {
+  backend = "local"
+  config = {
+    path = "terraform.tfstate"
+  }
+}
+`), 0644))
+
+	writeState := func(serial int, value string) {
+		state := fmt.Sprintf(`{"version": 4, "serial": %d, "lineage": "test-lineage", "outputs": {"name": {"value": %q, "type": "string"}}}`, serial, value)
+		require.NoError(t, os.WriteFile(filepath.Join(depDir, "terraform.tfstate"), []byte(state), 0644))
+	}
+
+	appConfigPath := filepath.Join(appDir, config.DefaultTerragruntConfigPath)
+	require.NoError(t, os.WriteFile(appConfigPath, []byte(`
+dependency "dep" {
+  config_path = "../dep"
+}
+
+inputs = {
+  name = dependency.dep.outputs.name
+}
+`), 0644))
+
+	parseName := func() string {
+		t.Helper()
+
+		config.ClearOutputCache()
+
+		opts := mockOptionsForTestWithConfigPath(t, appConfigPath)
+		opts.FetchDependencyOutputFromState = true
+		opts.DependencyOutputCache = true
+		opts.DependencyOutputCacheDir = cacheDir
+
+		ctx := config.NewParsingContext(context.Background(), opts)
+
+		cfg, err := config.ParseConfigFile(ctx, appConfigPath, nil)
+		require.NoError(t, err)
+
+		return cfg.Inputs["name"].(string)
+	}
+
+	writeState(1, "first")
+	assert.Equal(t, "first", parseName())
+
+	entries, err := os.ReadDir(cacheDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	// The serial did not change, so the cached outputs are used.
+	writeState(1, "changed-without-serial")
+	assert.Equal(t, "first", parseName())
+
+	// The serial changed, so the cache entry is stale.
+	writeState(2, "second")
+	assert.Equal(t, "second", parseName())
+
+	// Invalidation removes the entry, so the outputs are read again.
+	writeState(2, "third")
+
+	opts := mockOptionsForTestWithConfigPath(t, depConfigPath)
+	opts.DependencyOutputCacheDir = cacheDir
+	require.NoError(t, config.InvalidateDependencyOutputCache(opts, depConfigPath))
+	assert.Equal(t, "third", parseName())
+}
+
+func TestPersistentDependencyOutputCacheKey(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	depDir := filepath.Join(tmpDir, "dep")
+	appDir := filepath.Join(tmpDir, "app")
+	cacheDir := filepath.Join(tmpDir, "cache")
+
+	require.NoError(t, os.MkdirAll(depDir, os.ModePerm))
+	require.NoError(t, os.MkdirAll(appDir, os.ModePerm))
+
+	depConfigPath := filepath.Join(depDir, config.DefaultTerragruntConfigPath)
+	require.NoError(t, os.WriteFile(depConfigPath, []byte(`
+remote_state {
+  backend = "local"
+  config = {
+    path = "terraform.tfstate"
+  }
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(depDir, "terraform.tfstate"), []byte(`{"version": 4, "serial": 1, "lineage": "test-lineage", "outputs": {"name": {"value": "dep", "type": "string"}}}`), 0644))
+
+	appConfigPath := filepath.Join(appDir, config.DefaultTerragruntConfigPath)
+	require.NoError(t, os.WriteFile(appConfigPath, []byte(`
+dependency "dep" {
+  config_path = "../dep"
+}
+`), 0644))
+
+	parse := func(env map[string]string) {
+		t.Helper()
+
+		config.ClearOutputCache()
+
+		opts := mockOptionsForTestWithConfigPath(t, appConfigPath)
+		opts.FetchDependencyOutputFromState = true
+		opts.DependencyOutputCache = true
+		opts.DependencyOutputCacheDir = cacheDir
+		opts.Env = env
+
+		_, err := config.ParseConfigFile(config.NewParsingContext(context.Background(), opts), appConfigPath, nil)
+		require.NoError(t, err)
+	}
+
+	countEntries := func() int {
+		t.Helper()
+
+		entries, err := filepath.Glob(filepath.Join(cacheDir, "*", "*.json"))
+		require.NoError(t, err)
+
+		return len(entries)
+	}
+
+	parse(map[string]string{"HOME": "/home/first"})
+	assert.Equal(t, 1, countEntries())
+
+	// Variables unrelated to the outputs don't change the key.
+	parse(map[string]string{"HOME": "/home/second"})
+	assert.Equal(t, 1, countEntries())
+
+	// The workspace and the inputs are part of the key.
+	parse(map[string]string{"TF_WORKSPACE": "staging"})
+	assert.Equal(t, 2, countEntries())
+
+	parse(map[string]string{"TG_INPUTS": "other"})
+	assert.Equal(t, 3, countEntries())
+
+	// Invalidation removes the entries of every environment.
+	opts := mockOptionsForTestWithConfigPath(t, depConfigPath)
+	opts.DependencyOutputCacheDir = cacheDir
+	require.NoError(t, config.InvalidateDependencyOutputCache(opts, depConfigPath))
+	assert.Equal(t, 0, countEntries())
+}
@@ -137,6 +137,32 @@ func (err EmptyStringNotAllowedError) Error() string {
 	return "Empty string value is not allowed for " + string(err)
 }
 
+type InvalidRunCmdCacheTTLError struct {
+	Err   error
+	Value string
+}
+
+func (err InvalidRunCmdCacheTTLError) Error() string {
+	return fmt.Sprintf("Invalid value %q for the %s option of run_cmd: %v", err.Value, runCmdCacheTTLFlag, err.Err)
+}
+
+func (err InvalidRunCmdCacheTTLError) Unwrap() error {
+	return err.Err
+}
+
+type InvalidRunCmdJSONOutputError struct {
+	Err     error
+	Command string
+}
+
+func (err InvalidRunCmdJSONOutputError) Error() string {
+	return fmt.Sprintf("The output of %q is not valid JSON: %v", err.Command, err.Err)
+}
+
+func (err InvalidRunCmdJSONOutputError) Unwrap() error {
+	return err.Err
+}
+
 type TerragruntConfigNotFoundError struct {
 	Path string
 }
@@ -267,6 +293,30 @@ https://terragrunt.gruntwork.io/docs/reference/config-blocks-and-attributes/#dep
 	)
 }
 
+type FunctionCycleError []string
+
+func (err FunctionCycleError) Error() string {
+	return "Found a cycle between functions: " + strings.Join([]string(err), " -> ")
+}
+
+type DuplicateFunctionError struct {
+	Name       string
+	ConfigPath string
+}
+
+func (err DuplicateFunctionError) Error() string {
+	return fmt.Sprintf("The function %s is defined more than once in %s", err.Name, err.ConfigPath)
+}
+
+type FunctionRedefinesBuiltInError struct {
+	Name       string
+	ConfigPath string
+}
+
+func (err FunctionRedefinesBuiltInError) Error() string {
+	return fmt.Sprintf("The function %s defined in %s has the name of a built-in function", err.Name, err.ConfigPath)
+}
+
 type DependencyCycleError []string
 
 func (err DependencyCycleError) Error() string {
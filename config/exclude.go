@@ -6,6 +6,7 @@ import (
 
 	"github.com/gruntwork-io/terragrunt/config/hclparse"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/sensitive"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -106,7 +107,7 @@ func evaluateExcludeBlocks(ctx *ParsingContext, file *hclparse.File) (*ExcludeCo
 			return nil, err
 		}
 
-		evaluatedAttrs[attr.Name] = value
+		evaluatedAttrs[attr.Name] = sensitive.Unmark(value)
 	}
 
 	for _, boolFlag := range boolFlagValues {
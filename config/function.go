@@ -0,0 +1,206 @@
+package config
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/userfunc"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty/function"
+
+	"github.com/gruntwork-io/terragrunt/config/hclparse"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// MetadataFunction is the type of the blocks defining functions.
+const MetadataFunction = "function"
+
+// terragruntFunctionIgnore is used to not decode the `function` blocks with the rest of the configuration, as they are
+// decoded along with the locals.
+type terragruntFunctionIgnore struct {
+	Remain hcl.Body `hcl:",remain"`
+	Name   string   `hcl:"name,label"`
+}
+
+// userFunction is a function defined by a `function` block:
+//
+//	function "resource_name" {
+//	  params = [env, name]
+//	  result = "${env}-${name}"
+//	}
+type userFunction struct {
+	function.Function
+
+	// result is the expression of the result of the function, used to detect the cycles between functions.
+	result hcl.Expression
+}
+
+// decodeFunctionBlocks decodes the functions defined by the `function` blocks of the included configurations and of
+// the given file, which take precedence. The functions are pure: their result is evaluated with their parameters and
+// the same functions as the locals, including the other user functions, but no variables.
+func decodeFunctionBlocks(ctx *ParsingContext, file *hclparse.File) (map[string]function.Function, error) {
+	files := []*hclparse.File{}
+
+	if ctx.TrackInclude != nil {
+		files = append(files, ctx.TrackInclude.CurrentFiles...)
+	}
+
+	files = append(files, file)
+
+	// The functions of the function table are set once all the user functions are decoded, as they may call each other.
+	functionTable := map[string]function.Function{}
+	evalCtx := &hcl.EvalContext{Functions: functionTable}
+
+	filesFunctions := make([]map[string]*userFunction, 0, len(files))
+	hasFunctions := false
+
+	for _, file := range files {
+		functions, err := decodeFileFunctionBlocks(file, func() *hcl.EvalContext { return evalCtx })
+		if err != nil {
+			return nil, err
+		}
+
+		filesFunctions = append(filesFunctions, functions)
+		hasFunctions = hasFunctions || len(functions) > 0
+	}
+
+	if !hasFunctions {
+		return nil, nil
+	}
+
+	// The user functions can't redefine the built-in functions, but can redefine the user functions of the child.
+	builtinCtx := *ctx
+	builtinCtx.PredefinedFunctions = nil
+
+	builtinEvalCtx, err := createTerragruntEvalContext(&builtinCtx, file.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	userFunctions := map[string]*userFunction{}
+
+	for i, functions := range filesFunctions {
+		for name, fn := range functions {
+			if _, ok := builtinEvalCtx.Functions[name]; ok {
+				return nil, errors.New(FunctionRedefinesBuiltInError{Name: name, ConfigPath: files[i].ConfigPath})
+			}
+
+			userFunctions[name] = fn
+		}
+	}
+
+	if err := checkForFunctionCycles(userFunctions); err != nil {
+		return nil, err
+	}
+
+	functions := make(map[string]function.Function, len(userFunctions))
+
+	for name, fn := range userFunctions {
+		functions[name] = fn.Function
+	}
+
+	maps.Copy(functionTable, builtinEvalCtx.Functions)
+	maps.Copy(functionTable, ctx.PredefinedFunctions)
+	maps.Copy(functionTable, functions)
+
+	return functions, nil
+}
+
+// decodeFileFunctionBlocks decodes the `function` blocks of the given file.
+func decodeFileFunctionBlocks(file *hclparse.File, contextFunc userfunc.ContextFunc) (map[string]*userFunction, error) {
+	schema := &hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: MetadataFunction, LabelNames: []string{"name"}}},
+	}
+
+	content, _, diags := file.Body.PartialContent(schema)
+	if err := file.HandleDiagnostics(diags); err != nil {
+		return nil, errors.New(err)
+	}
+
+	if len(content.Blocks) == 0 {
+		return nil, nil
+	}
+
+	functions, _, diags := userfunc.DecodeUserFunctions(file.Body, MetadataFunction, contextFunc)
+	if err := file.HandleDiagnostics(diags); err != nil {
+		return nil, errors.New(err)
+	}
+
+	userFunctions := make(map[string]*userFunction, len(functions))
+
+	for _, block := range content.Blocks {
+		name := block.Labels[0]
+
+		if _, ok := userFunctions[name]; ok {
+			return nil, errors.New(DuplicateFunctionError{Name: name, ConfigPath: file.ConfigPath})
+		}
+
+		attrs, diags := block.Body.JustAttributes()
+		if err := file.HandleDiagnostics(diags); err != nil {
+			return nil, errors.New(err)
+		}
+
+		userFunctions[name] = &userFunction{Function: functions[name], result: attrs["result"].Expr}
+	}
+
+	return userFunctions, nil
+}
+
+// checkForFunctionCycles checks that the user functions do not call themselves, directly or through other functions,
+// which would never end.
+func checkForFunctionCycles(functions map[string]*userFunction) error {
+	calls := make(map[string][]string, len(functions))
+
+	for name, fn := range functions {
+		body, ok := fn.result.(hclsyntax.Expression)
+		if !ok {
+			continue
+		}
+
+		_ = hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+			if call, ok := node.(*hclsyntax.FunctionCallExpr); ok {
+				if _, ok := functions[call.Name]; ok && !slices.Contains(calls[name], call.Name) {
+					calls[name] = append(calls[name], call.Name)
+				}
+			}
+
+			return nil
+		})
+	}
+
+	visited := []string{}
+
+	var visit func(name string, path []string) error
+
+	visit = func(name string, path []string) error {
+		if slices.Contains(path, name) {
+			return errors.New(FunctionCycleError(append(slices.Clone(path), name)))
+		}
+
+		if slices.Contains(visited, name) {
+			return nil
+		}
+
+		path = append(path, name)
+
+		for _, callee := range calls[name] {
+			if err := visit(callee, path); err != nil {
+				return err
+			}
+		}
+
+		visited = append(visited, name)
+
+		return nil
+	}
+
+	// Visit the functions in order, so that the same cycle is reported.
+	for _, name := range slices.Sorted(maps.Keys(functions)) {
+		if err := visit(name, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFunctionBlocks(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	childDir := filepath.Join(tmpDir, "app")
+	childConfigPath := filepath.Join(childDir, config.DefaultTerragruntConfigPath)
+
+	require.NoError(t, os.MkdirAll(childDir, os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "root.hcl"), []byte(`
+function "resource_name" {
+  params = [env, name]
+  result = lower("${env}-${name}")
+}
+
+function "tags" {
+  params         = [env]
+  variadic_param = extra
+  result         = merge({ Environment = env, Name = resource_name(env, "tags") }, extra...)
+}
+
+inputs = {
+  root_name = resource_name("Root", "App")
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(childConfigPath, []byte(`
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+function "subnet" {
+  params = [cidr, index]
+  result = cidrsubnet(cidr, 8, index)
+}
+
+locals {
+  env = "Prod"
+}
+
+inputs = {
+  name   = resource_name(local.env, "Web")
+  tags   = tags(local.env, { Team = "platform" })
+  subnet = subnet("10.0.0.0/16", 2)
+}
+`), 0644))
+
+	ctx := config.NewParsingContext(context.Background(), terragruntOptionsForTest(t, childConfigPath))
+
+	terragruntConfig, err := config.ParseConfigFile(ctx, childConfigPath, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"root_name": "root-app",
+		"name":      "prod-web",
+		"tags":      map[string]any{"Environment": "Prod", "Name": "prod-tags", "Team": "platform"},
+		"subnet":    "10.0.2.0/24",
+	}, terragruntConfig.Inputs)
+}
+
+func TestFunctionBlocksErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		cfg           string
+		expectedError string
+	}{
+		{
+			name: "cycle",
+			cfg: `
+function "a" {
+  params = [x]
+  result = b(x)
+}
+
+function "b" {
+  params = [x]
+  result = x > 0 ? a(x - 1) : x
+}
+
+inputs = { value = a(1) }
+`,
+			expectedError: "Found a cycle between functions: a -> b -> a",
+		},
+		{
+			name: "recursion",
+			cfg: `
+function "a" {
+  params = [x]
+  result = a(x)
+}
+`,
+			expectedError: "Found a cycle between functions: a -> a",
+		},
+		{
+			name: "variables",
+			cfg: `
+locals {
+  prefix = "app"
+}
+
+function "name" {
+  params = [x]
+  result = "${local.prefix}-${x}"
+}
+
+inputs = { value = name("web") }
+`,
+			expectedError: `There is no variable named "local"`,
+		},
+		{
+			name: "built-in",
+			cfg: `
+function "upper" {
+  params = [x]
+  result = x
+}
+`,
+			expectedError: "The function upper defined in mock-path-for-test.hcl has the name of a built-in function",
+		},
+		{
+			name: "duplicate",
+			cfg: `
+function "name" {
+  params = [x]
+  result = x
+}
+
+function "name" {
+  params = [x]
+  result = "${x}-${x}"
+}
+`,
+			expectedError: "The function name is defined more than once in mock-path-for-test.hcl",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := config.NewParsingContext(context.Background(), terragruntOptionsForTest(t, config.DefaultTerragruntConfigPath))
+
+			_, err := config.ParseConfigString(ctx, "mock-path-for-test.hcl", tc.cfg, nil)
+			require.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
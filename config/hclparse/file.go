@@ -65,7 +65,7 @@ func (file *File) Decode(out any, evalContext *hcl.EvalContext) (err error) {
 		}
 	}
 
-	diags := gohcl.DecodeBody(file.Body, evalContext, out)
+	diags := gohcl.DecodeBody(unmarkedBody{file.Body}, evalContext, out)
 	if err := file.HandleDiagnostics(diags); err != nil {
 		return errors.New(err)
 	}
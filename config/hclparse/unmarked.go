@@ -0,0 +1,79 @@
+package hclparse
+
+import (
+	"github.com/gruntwork-io/terragrunt/internal/sensitive"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// unmarkedBody is a body whose attribute values are unmarked when evaluated, since marked values, such as the sensitive
+// values, can not be decoded into Go values. The sensitive values are registered to be redacted.
+type unmarkedBody struct {
+	hcl.Body
+}
+
+func (body unmarkedBody) Content(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Diagnostics) {
+	content, diags := body.Body.Content(schema)
+
+	return unmarkedContent(content), diags
+}
+
+func (body unmarkedBody) PartialContent(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Body, hcl.Diagnostics) {
+	content, remain, diags := body.Body.PartialContent(schema)
+	if remain != nil {
+		remain = unmarkedBody{remain}
+	}
+
+	return unmarkedContent(content), remain, diags
+}
+
+func (body unmarkedBody) JustAttributes() (hcl.Attributes, hcl.Diagnostics) {
+	attrs, diags := body.Body.JustAttributes()
+
+	return unmarkedAttributes(attrs), diags
+}
+
+func unmarkedContent(content *hcl.BodyContent) *hcl.BodyContent {
+	if content == nil {
+		return nil
+	}
+
+	unmarked := *content
+	unmarked.Attributes = unmarkedAttributes(content.Attributes)
+	unmarked.Blocks = make(hcl.Blocks, len(content.Blocks))
+
+	for i, block := range content.Blocks {
+		unmarkedBlock := *block
+		unmarkedBlock.Body = unmarkedBody{block.Body}
+		unmarked.Blocks[i] = &unmarkedBlock
+	}
+
+	return &unmarked
+}
+
+func unmarkedAttributes(attrs hcl.Attributes) hcl.Attributes {
+	if attrs == nil {
+		return nil
+	}
+
+	unmarked := make(hcl.Attributes, len(attrs))
+
+	for name, attr := range attrs {
+		unmarkedAttr := *attr
+		unmarkedAttr.Expr = unmarkedExpression{attr.Expr}
+		unmarked[name] = &unmarkedAttr
+	}
+
+	return unmarked
+}
+
+// unmarkedExpression is an expression whose value is unmarked.
+type unmarkedExpression struct {
+	hcl.Expression
+}
+
+func (expr unmarkedExpression) Value(ctx *hcl.EvalContext) (cty.Value, hcl.Diagnostics) {
+	val, diags := expr.Expression.Value(ctx)
+
+	return sensitive.Unmark(val), diags
+}
@@ -265,6 +265,14 @@ func (cfg *TerragruntConfig) Merge(sourceConfig *TerragruntConfig, terragruntOpt
 		cfg.Engine = sourceConfig.Engine.Clone()
 	}
 
+	if sourceConfig.Scheduling != nil {
+		cfg.Scheduling = sourceConfig.Scheduling.Clone()
+	}
+
+	if sourceConfig.Policy != nil {
+		cfg.Policy = sourceConfig.Policy.Clone()
+	}
+
 	if sourceConfig.Skip != nil {
 		cfg.Skip = sourceConfig.Skip
 	}
@@ -399,6 +407,22 @@ func (cfg *TerragruntConfig) DeepMerge(sourceConfig *TerragruntConfig, terragrun
 		cfg.Engine.Merge(sourceConfig.Engine)
 	}
 
+	if sourceConfig.Scheduling != nil {
+		if cfg.Scheduling == nil {
+			cfg.Scheduling = &SchedulingConfig{}
+		}
+
+		cfg.Scheduling.Merge(sourceConfig.Scheduling)
+	}
+
+	if sourceConfig.Policy != nil {
+		if cfg.Policy == nil {
+			cfg.Policy = &PolicyConfig{}
+		}
+
+		cfg.Policy.Merge(sourceConfig.Policy)
+	}
+
 	if sourceConfig.Exclude != nil {
 		if cfg.Exclude == nil {
 			cfg.Exclude = &ExcludeConfig{}
@@ -787,10 +811,16 @@ func getTrackInclude(ctx *ParsingContext, terragruntIncludeList IncludeConfigs,
 		return nil, err
 	case hasInclude && includeFromChild == nil:
 		// Current parsing ctx where there is no included config already loaded.
+		files, err := parseIncludedFiles(ctx, terragruntIncludeList)
+		if err != nil {
+			return nil, err
+		}
+
 		trackInc = TrackInclude{
-			CurrentList: terragruntIncludeList,
-			CurrentMap:  terragruntIncludeMap,
-			Original:    nil,
+			CurrentList:  terragruntIncludeList,
+			CurrentMap:   terragruntIncludeMap,
+			CurrentFiles: files,
+			Original:     nil,
 		}
 	case !hasInclude:
 		// Parsing ctx where there is an included config already loaded.
@@ -1018,3 +1048,25 @@ type IncludeIsNotABlockErr struct {
 func (err IncludeIsNotABlockErr) Error() string {
 	return fmt.Sprintf("Parsed include is not a block: %v", err.parsed)
 }
+
+// parseIncludedFiles parses the files of the given included configs, through the HCL cache, so that they are parsed
+// once when the included configs are merged.
+func parseIncludedFiles(ctx *ParsingContext, includes IncludeConfigs) ([]*hclparse.File, error) {
+	files := make([]*hclparse.File, 0, len(includes))
+
+	for _, include := range includes {
+		includePath := include.Path
+		if !filepath.IsAbs(includePath) {
+			includePath = util.JoinPath(filepath.Dir(ctx.TerragruntOptions.TerragruntConfigPath), includePath)
+		}
+
+		file, err := parseFileWithCache(ctx, includePath)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, file)
+	}
+
+	return files, nil
+}
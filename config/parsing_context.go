@@ -2,6 +2,7 @@ package config
 
 import (
 	"context"
+	"maps"
 
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/function"
@@ -49,6 +50,10 @@ type ParsingContext struct {
 
 	// `ParserOptions` is used to configure hcl Parser.
 	ParserOptions []hclparse.Option
+
+	// customParserOptions is set when `ParserOptions` may differ from the default ones. Since the parsed files keep
+	// their parser, and with it the way diagnostics are handled, such files are not shared through the HCL cache.
+	customParserOptions bool
 }
 
 func NewParsingContext(ctx context.Context, opts *options.TerragruntOptions) *ParsingContext {
@@ -92,7 +97,22 @@ func (ctx ParsingContext) WithTrackInclude(trackInclude *TrackInclude) *ParsingC
 	return &ctx
 }
 
+// WithFunctions adds the given functions to the predefined functions to be used in evaluation context.
+func (ctx ParsingContext) WithFunctions(functions map[string]function.Function) *ParsingContext {
+	predefinedFunctions := maps.Clone(ctx.PredefinedFunctions)
+	if predefinedFunctions == nil {
+		predefinedFunctions = make(map[string]function.Function, len(functions))
+	}
+
+	maps.Copy(predefinedFunctions, functions)
+	ctx.PredefinedFunctions = predefinedFunctions
+
+	return &ctx
+}
+
 func (ctx ParsingContext) WithParseOption(parserOptions []hclparse.Option) *ParsingContext {
 	ctx.ParserOptions = parserOptions
+	ctx.customParserOptions = true
+
 	return &ctx
 }
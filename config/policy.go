@@ -0,0 +1,43 @@
+package config
+
+import "maps"
+
+// PolicyConfig represents the `policy` block, which checks the plan of the unit against policies before it is applied.
+// A plan violating any of the policies is not applied, and neither are the units depending on the unit when running all
+// units.
+type PolicyConfig struct {
+	// DenyDestroyTagged denies destroying, or replacing, resources with any of these tags. A tag set to "*" matches any
+	// value of the tag.
+	DenyDestroyTagged map[string]string `hcl:"deny_destroy_tagged,optional" cty:"deny_destroy_tagged"`
+	// DenyDestroyTypes denies destroying, or replacing, resources of these types.
+	DenyDestroyTypes []string `hcl:"deny_destroy_types,optional" cty:"deny_destroy_types"`
+	// Command is an external evaluator, run with the path of the JSON plan as its last argument.
+	Command []string `hcl:"command,optional" cty:"command"`
+}
+
+// Clone returns a copy of the PolicyConfig used in deep copy
+func (c *PolicyConfig) Clone() *PolicyConfig {
+	return &PolicyConfig{
+		DenyDestroyTagged: maps.Clone(c.DenyDestroyTagged),
+		DenyDestroyTypes:  append([]string(nil), c.DenyDestroyTypes...),
+		Command:           append([]string(nil), c.Command...),
+	}
+}
+
+// Merge merges the PolicyConfig with another PolicyConfig. The tags and types denied by both are combined, and the
+// command of the other config replaces this one.
+func (c *PolicyConfig) Merge(policy *PolicyConfig) {
+	if policy.DenyDestroyTagged != nil {
+		if c.DenyDestroyTagged == nil {
+			c.DenyDestroyTagged = map[string]string{}
+		}
+
+		maps.Copy(c.DenyDestroyTagged, policy.DenyDestroyTagged)
+	}
+
+	c.DenyDestroyTypes = append(c.DenyDestroyTypes, policy.DenyDestroyTypes...)
+
+	if policy.Command != nil {
+		c.Command = policy.Command
+	}
+}
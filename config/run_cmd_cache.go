@@ -0,0 +1,124 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// runCmdCacheDirName is the name of the dir within the Terragrunt cache dir that holds the on-disk cache of `run_cmd`
+// outputs.
+const runCmdCacheDirName = "run-cmd"
+
+// runCmdCacheEntry is a single entry of the on-disk `run_cmd` cache, stored as a JSON file.
+type runCmdCacheEntry struct {
+	CreatedAt time.Time `json:"created_at"`
+	Key       string    `json:"key"`
+	Output    string    `json:"output"`
+}
+
+// getRunCmdCacheKey returns the key of the on-disk cache entry for the given in-memory cache key. Entries of commands
+// cached with `--terragrunt-global-cache` are scoped to the root working dir, so that relative commands of different
+// projects do not share entries.
+func getRunCmdCacheKey(ctx *ParsingContext, cacheKey string) string {
+	return ctx.TerragruntOptions.RootWorkingDir + "-" + cacheKey
+}
+
+// getRunCmdCachePath returns the path of the on-disk cache entry for the given key.
+func getRunCmdCachePath(key string) (string, error) {
+	cacheDir, err := util.GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256([]byte(key))
+
+	return filepath.Join(cacheDir, runCmdCacheDirName, hex.EncodeToString(hash[:])+".json"), nil
+}
+
+// getPersistentRunCmdCache returns the cached output of the `run_cmd` invocation with the given cache key, if there
+// is an entry that is not older than the given TTL.
+func getPersistentRunCmdCache(ctx *ParsingContext, cacheKey string, ttl time.Duration) (string, bool) {
+	logger := ctx.TerragruntOptions.Logger
+	key := getRunCmdCacheKey(ctx, cacheKey)
+
+	cachePath, err := getRunCmdCachePath(key)
+	if err != nil {
+		logger.Debugf("Unable to locate run_cmd cache: %v", err)
+		return "", false
+	}
+
+	if !util.FileExists(cachePath) {
+		return "", false
+	}
+
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		logger.Debugf("Unable to read run_cmd cache %s: %v", cachePath, err)
+		return "", false
+	}
+
+	var entry runCmdCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		logger.Debugf("Ignoring invalid run_cmd cache %s: %v", cachePath, err)
+		return "", false
+	}
+
+	if entry.Key != key || time.Since(entry.CreatedAt) > ttl {
+		return "", false
+	}
+
+	return entry.Output, true
+}
+
+// putPersistentRunCmdCache stores the output of the `run_cmd` invocation with the given cache key.
+func putPersistentRunCmdCache(ctx *ParsingContext, cacheKey, output string) error {
+	key := getRunCmdCacheKey(ctx, cacheKey)
+
+	cachePath, err := getRunCmdCachePath(key)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(runCmdCacheEntry{
+		CreatedAt: time.Now(),
+		Key:       key,
+		Output:    output,
+	})
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if err := util.EnsureDirectory(filepath.Dir(cachePath)); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that concurrent readers never see a partially written entry.
+	tmpFile, err := os.CreateTemp(filepath.Dir(cachePath), filepath.Base(cachePath)+".*")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()           //nolint:errcheck
+		os.Remove(tmpFile.Name()) //nolint:errcheck
+
+		return errors.New(err)
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return errors.New(err)
+	}
+
+	if err := os.Rename(tmpFile.Name(), cachePath); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
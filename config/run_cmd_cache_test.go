@@ -0,0 +1,72 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest
+func TestRunCommandCacheTTL(t *testing.T) {
+	// The on-disk cache is stored in the user cache dir, which is overridden for the test.
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	tmpDir := t.TempDir()
+	counterPath := filepath.Join(tmpDir, "counter")
+	configPath := filepath.Join(tmpDir, config.DefaultTerragruntConfigPath)
+
+	runCount := func() int {
+		t.Helper()
+
+		data, err := os.ReadFile(counterPath)
+		if os.IsNotExist(err) {
+			return 0
+		}
+
+		require.NoError(t, err)
+
+		return strings.Count(string(data), "\n")
+	}
+
+	runCmd := func(options ...string) string {
+		t.Helper()
+
+		// Every parsing context gets a new in-memory cache, so only the on-disk cache can skip the command.
+		ctx := config.NewParsingContext(context.Background(), terragruntOptionsForTest(t, configPath))
+
+		args := append(options, "/bin/bash", "-c", "echo run >> "+counterPath+"; echo -n foo")
+
+		out, err := config.RunCommand(ctx, args)
+		require.NoError(t, err)
+
+		return out
+	}
+
+	assert.Equal(t, "foo", runCmd("--terragrunt-cache-ttl=1h"))
+	assert.Equal(t, "foo", runCmd("--terragrunt-cache-ttl=1h"))
+	assert.Equal(t, 1, runCount())
+
+	// Entries older than the TTL are ignored.
+	assert.Equal(t, "foo", runCmd("--terragrunt-cache-ttl=1ns"))
+	assert.Equal(t, 2, runCount())
+
+	// The output of quiet commands is never written to disk.
+	assert.Equal(t, "foo", runCmd("--terragrunt-quiet", "--terragrunt-cache-ttl=1h"))
+	assert.Equal(t, "foo", runCmd("--terragrunt-quiet", "--terragrunt-cache-ttl=1h"))
+	assert.Equal(t, 4, runCount())
+
+	// Without the TTL option the command is always run.
+	assert.Equal(t, "foo", runCmd())
+	assert.Equal(t, 5, runCount())
+
+	ctx := config.NewParsingContext(context.Background(), terragruntOptionsForTest(t, configPath))
+	_, err := config.RunCommand(ctx, []string{"--terragrunt-cache-ttl=forever", "/bin/bash", "-c", "echo -n foo"})
+	require.Error(t, err)
+	assert.ErrorAs(t, err, &config.InvalidRunCmdCacheTTLError{})
+}
@@ -0,0 +1,51 @@
+package config
+
+const (
+	// DefaultSchedulingWeight is the weight of the units that do not set one in their scheduling block.
+	DefaultSchedulingWeight = 1
+)
+
+// SchedulingConfig represents the `scheduling` block, which controls how the unit is scheduled when running all units.
+type SchedulingConfig struct {
+	// Group is the concurrency group of the unit, whose limit is set with --parallelism-group.
+	Group *string `hcl:"group,attr" cty:"group"`
+	// Weight is the number of parallelism slots the unit takes while running, both globally and in its group.
+	Weight *int `hcl:"weight,attr" cty:"weight"`
+}
+
+// Clone returns a copy of the SchedulingConfig used in deep copy
+func (c *SchedulingConfig) Clone() *SchedulingConfig {
+	return &SchedulingConfig{
+		Group:  c.Group,
+		Weight: c.Weight,
+	}
+}
+
+// Merge merges the SchedulingConfig with another SchedulingConfig
+func (c *SchedulingConfig) Merge(scheduling *SchedulingConfig) {
+	if scheduling.Group != nil {
+		c.Group = scheduling.Group
+	}
+
+	if scheduling.Weight != nil {
+		c.Weight = scheduling.Weight
+	}
+}
+
+// GetGroup returns the concurrency group of the unit, or an empty string if the unit is not in a group.
+func (c *SchedulingConfig) GetGroup() string {
+	if c == nil || c.Group == nil {
+		return ""
+	}
+
+	return *c.Group
+}
+
+// GetWeight returns the weight of the unit, which is DefaultSchedulingWeight if not set.
+func (c *SchedulingConfig) GetWeight() int {
+	if c == nil || c.Weight == nil {
+		return DefaultSchedulingWeight
+	}
+
+	return *c.Weight
+}
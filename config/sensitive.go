@@ -0,0 +1,68 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/sensitive"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// sensitiveFuncImpl returns the `sensitive` function, which marks its argument as sensitive, so that it is redacted
+// from the logs and the outputs of Terragrunt, e.g. `sensitive(get_env("DB_PASSWORD"))`. It replaces the function of
+// the same name of OpenTofu/Terraform, which marks the value the same way, to register the value as soon as it is
+// marked.
+func sensitiveFuncImpl() function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{{
+			Name:             "value",
+			Type:             cty.DynamicPseudoType,
+			AllowUnknown:     true,
+			AllowNull:        true,
+			AllowMarked:      true,
+			AllowDynamicType: true,
+		}},
+		Type: func(args []cty.Value) (cty.Type, error) {
+			return args[0].Type(), nil
+		},
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			return sensitive.MarkValue(args[0]), nil
+		},
+	})
+}
+
+// wrapSensitiveFuncImpl wraps the given function to mark its result as sensitive, when isSensitive is nil or returns
+// true for its arguments.
+func wrapSensitiveFuncImpl(toWrap function.Function, isSensitive func(args []cty.Value) bool) function.Function {
+	return function.New(&function.Spec{
+		Params:   toWrap.Params(),
+		VarParam: toWrap.VarParam(),
+		Type: func(args []cty.Value) (cty.Type, error) {
+			return toWrap.ReturnTypeForValues(args)
+		},
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			val, err := toWrap.Call(args)
+			if err != nil || (isSensitive != nil && !isSensitive(args)) {
+				return val, err
+			}
+
+			return sensitive.MarkValue(val), nil
+		},
+	})
+}
+
+// isQuietRunCmd returns true if the output of `run_cmd` with the given arguments is suppressed.
+func isQuietRunCmd(args []cty.Value) bool {
+	for _, arg := range args {
+		switch arg := arg.AsString(); {
+		case arg == runCmdQuietFlag:
+			return true
+		case arg == runCmdGlobalCacheFlag, strings.HasPrefix(arg, runCmdCacheTTLFlag+"="):
+			continue
+		default:
+			return false
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,54 @@
+package config_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/sensitive"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSensitiveValues(t *testing.T) {
+	t.Parallel()
+
+	cfg := `
+locals {
+  password = sensitive("sensitive-test-password")
+  token    = run_cmd("--terragrunt-quiet", "echo", "sensitive-test-token")
+  region   = run_cmd("echo", "sensitive-test-region")
+  database = run_cmd_json("--terragrunt-cache-ttl=1h", "--terragrunt-quiet", "echo", "{\"key\": \"sensitive-test-key\"}")
+}
+
+inputs = {
+  password = local.password
+  url      = "postgres://app:${local.password}@db"
+  token    = local.token
+  region   = local.region
+  key      = local.database.key
+}
+`
+
+	ctx := config.NewParsingContext(context.Background(), terragruntOptionsForTest(t, config.DefaultTerragruntConfigPath))
+	terragruntConfig, err := config.ParseConfigString(ctx, "mock-path-for-test.hcl", cfg, nil)
+	require.NoError(t, err)
+
+	// The sensitive values are decoded as they are, but registered to be redacted.
+	assert.Equal(t, map[string]any{
+		"password": "sensitive-test-password",
+		"url":      "postgres://app:sensitive-test-password@db",
+		"token":    "sensitive-test-token",
+		"region":   "sensitive-test-region",
+		"key":      "sensitive-test-key",
+	}, terragruntConfig.Inputs)
+
+	assert.True(t, sensitive.IsSensitive("sensitive-test-password"))
+	assert.True(t, sensitive.IsSensitive("postgres://app:sensitive-test-password@db"))
+	assert.True(t, sensitive.IsSensitive("sensitive-test-token"))
+	assert.True(t, sensitive.IsSensitive("sensitive-test-key"))
+	assert.False(t, sensitive.IsSensitive("sensitive-test-region"))
+
+	_, err = config.TerragruntConfigAsCty(terragruntConfig)
+	require.NoError(t, err)
+}
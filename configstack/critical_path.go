@@ -0,0 +1,164 @@
+package configstack
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	durationsDirName  = "run-durations"
+	durationsFilePerm = 0600
+
+	// defaultUnitDuration is the duration, in seconds, of the units that never ran, when no unit ever ran.
+	defaultUnitDuration = 1
+)
+
+// UnitDurations are the durations of the units in previous runs of all units, used to weight the critical path of the
+// queue with --queue-critical-path.
+type UnitDurations struct {
+	// Units maps the path of each unit, relative to the working dir, to its duration in seconds in the last run it
+	// completed in.
+	Units map[string]float64 `json:"units"`
+
+	path string
+}
+
+// getDurationsFile returns the file the durations of the units are recorded in, which is either set explicitly with
+// --queue-durations-file, or derived from the working dir and the OpenTofu/Terraform command, so that the durations of
+// applies are not mixed up with the durations of plans.
+func getDurationsFile(terragruntOptions *options.TerragruntOptions) (string, error) {
+	if terragruntOptions.QueueDurationsFile != "" {
+		if filepath.IsAbs(terragruntOptions.QueueDurationsFile) {
+			return terragruntOptions.QueueDurationsFile, nil
+		}
+
+		return filepath.Join(terragruntOptions.WorkingDir, terragruntOptions.QueueDurationsFile), nil
+	}
+
+	cacheDir, err := util.GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256([]byte(strings.Join([]string{terragruntOptions.WorkingDir, terragruntOptions.TerraformCommand}, "\x00")))
+
+	return filepath.Join(cacheDir, durationsDirName, hex.EncodeToString(hash[:])[:defaultRunIDLength]+".json"), nil
+}
+
+// loadUnitDurations loads the durations of the units recorded by the previous runs, if any.
+func loadUnitDurations(terragruntOptions *options.TerragruntOptions) (*UnitDurations, error) {
+	path, err := getDurationsFile(terragruntOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	durations := &UnitDurations{
+		Units: map[string]float64{},
+		path:  path,
+	}
+
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return durations, nil
+	} else if err != nil {
+		return nil, errors.New(err)
+	}
+
+	if err := json.Unmarshal(content, durations); err != nil {
+		return nil, errors.New(err)
+	}
+
+	if durations.Units == nil {
+		durations.Units = map[string]float64{}
+	}
+
+	return durations, nil
+}
+
+// Record records the durations of the given modules that ran successfully and persists them.
+func (durations *UnitDurations) Record(terragruntOptions *options.TerragruntOptions, modules RunningModules) error {
+	for _, module := range modules {
+		if module.Err != nil || module.StartTime.IsZero() {
+			continue
+		}
+
+		durations.Units[unitRelPath(terragruntOptions, module.Module.Path)] = module.EndTime.Sub(module.StartTime).Seconds()
+	}
+
+	content, err := json.MarshalIndent(durations, "", "  ")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(durations.path), os.ModePerm); err != nil {
+		return errors.New(err)
+	}
+
+	if err := os.WriteFile(durations.path, content, durationsFilePerm); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// duration returns the expected duration of the module in seconds. Units that never ran are expected to last as long
+// as the average unit.
+func (durations *UnitDurations) duration(terragruntOptions *options.TerragruntOptions, module *RunningModule) float64 {
+	if module.Resumed || module.Module.AssumeAlreadyApplied {
+		return 0
+	}
+
+	if duration, ok := durations.Units[unitRelPath(terragruntOptions, module.Module.Path)]; ok {
+		return duration
+	}
+
+	if len(durations.Units) == 0 {
+		return defaultUnitDuration
+	}
+
+	total := 0.0
+	for _, duration := range durations.Units {
+		total += duration
+	}
+
+	return total / float64(len(durations.Units))
+}
+
+// prioritizeCriticalPath sets the priority of each module to the expected duration of the longest chain of modules
+// waiting for it, including itself, so that the modules holding back the most work run first.
+func (modules RunningModules) prioritizeCriticalPath(terragruntOptions *options.TerragruntOptions, durations *UnitDurations) {
+	done := make(map[string]bool, len(modules))
+
+	var prioritize func(module *RunningModule) float64
+	prioritize = func(module *RunningModule) float64 {
+		if done[module.Module.Path] {
+			return module.priority
+		}
+
+		longestChain := 0.0
+
+		for _, toNotify := range module.NotifyWhenDone {
+			// The modules to notify are linked before the excluded modules are removed, so look up the ones that run.
+			if dependent, ok := modules[toNotify.Module.Path]; ok {
+				longestChain = max(longestChain, prioritize(dependent))
+			}
+		}
+
+		module.priority = durations.duration(terragruntOptions, module) + longestChain
+		done[module.Module.Path] = true
+
+		return module.priority
+	}
+
+	for _, module := range modules {
+		prioritize(module)
+	}
+}
@@ -0,0 +1,111 @@
+package configstack_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runCriticalPathTestStack runs, one at a time, a stack with the independent units "a" and "b", and the chain of units
+// "z1" <- "z2" <- "z3". It returns the order the units ran in.
+func runCriticalPathTestStack(t *testing.T, opts *options.TerragruntOptions) []string {
+	t.Helper()
+
+	var (
+		order []string
+		mu    sync.Mutex
+	)
+
+	modules := map[string]*configstack.TerraformModule{}
+
+	for _, name := range []string{"a", "b", "z1", "z2", "z3"} {
+		moduleOpts, err := opts.CloneWithConfigPath(filepath.Join(opts.WorkingDir, name, config.DefaultTerragruntConfigPath))
+		require.NoError(t, err)
+
+		moduleOpts.RunTerragrunt = func(_ context.Context, _ *options.TerragruntOptions) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			order = append(order, name)
+
+			return nil
+		}
+
+		modules[name] = &configstack.TerraformModule{
+			Stack:             &configstack.Stack{},
+			Path:              filepath.Join(opts.WorkingDir, name),
+			Dependencies:      configstack.TerraformModules{},
+			TerragruntOptions: moduleOpts,
+		}
+	}
+
+	modules["z2"].Dependencies = configstack.TerraformModules{modules["z1"]}
+	modules["z3"].Dependencies = configstack.TerraformModules{modules["z2"]}
+
+	opts.Parallelism = 1
+
+	stack := configstack.NewStack(opts)
+	stack.Modules = configstack.TerraformModules{modules["a"], modules["b"], modules["z1"], modules["z2"], modules["z3"]}
+
+	require.NoError(t, stack.Run(context.Background(), opts))
+
+	return order
+}
+
+func TestStackRunQueueCriticalPath(t *testing.T) {
+	t.Parallel()
+
+	opts := newFailFastTestOptions(t)
+	opts.QueueCriticalPath = true
+	opts.QueueDurationsFile = "durations.json"
+
+	order := runCriticalPathTestStack(t, opts)
+
+	// Units of the same priority run in alphabetical order.
+	assert.Equal(t, []string{"z1", "z2", "a", "b", "z3"}, order)
+
+	content, err := os.ReadFile(filepath.Join(opts.WorkingDir, opts.QueueDurationsFile))
+	require.NoError(t, err)
+
+	var durations configstack.UnitDurations
+	require.NoError(t, json.Unmarshal(content, &durations))
+	assert.Len(t, durations.Units, 5)
+	assert.Contains(t, durations.Units, "z3")
+}
+
+func TestStackRunQueueCriticalPathWithDurations(t *testing.T) {
+	t.Parallel()
+
+	opts := newFailFastTestOptions(t)
+	opts.QueueCriticalPath = true
+	opts.QueueDurationsFile = filepath.Join(opts.WorkingDir, "durations.json")
+
+	content, err := json.Marshal(configstack.UnitDurations{Units: map[string]float64{"a": 100, "b": 1, "z1": 1, "z2": 1, "z3": 1}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(opts.QueueDurationsFile, content, 0600))
+
+	order := runCriticalPathTestStack(t, opts)
+
+	assert.Equal(t, []string{"a", "z1", "z2", "b", "z3"}, order)
+}
+
+func TestStackRunWithoutQueueCriticalPath(t *testing.T) {
+	t.Parallel()
+
+	opts := newFailFastTestOptions(t)
+	opts.QueueDurationsFile = "durations.json"
+
+	order := runCriticalPathTestStack(t, opts)
+
+	assert.Equal(t, []string{"a", "b", "z1", "z2", "z3"}, order)
+	assert.NoFileExists(t, filepath.Join(opts.WorkingDir, opts.QueueDurationsFile))
+}
@@ -0,0 +1,186 @@
+package configstack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/policy"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	// UnitDrifted is the drift status of the units whose plan has changes.
+	UnitDrifted UnitDriftStatus = "drifted"
+	// UnitInSync is the drift status of the units whose plan has no changes.
+	UnitInSync UnitDriftStatus = "in-sync"
+	// UnitDriftUnknown is the drift status of the units that could not be planned.
+	UnitDriftUnknown UnitDriftStatus = "unknown"
+)
+
+// UnitDriftStatus is whether the infrastructure of a unit drifted from its configuration.
+type UnitDriftStatus string
+
+// DriftReport is the outcome of detecting the drift of every unit of a stack.
+type DriftReport struct {
+	Units []*UnitDrift `json:"units"`
+
+	Drifted int `json:"drifted"`
+	InSync  int `json:"in_sync"`
+	Unknown int `json:"unknown"`
+}
+
+// UnitDrift is the drift of a single unit.
+type UnitDrift struct {
+	// Path is the path of the unit, relative to the working dir.
+	Path   string          `json:"path"`
+	Status UnitDriftStatus `json:"status"`
+	// Error is set when the drift of the unit is unknown, e.g. because the plan failed.
+	Error string `json:"error,omitempty"`
+	// Resources are the drifted resources, read from the JSON plan of the unit.
+	Resources []*DriftedResource `json:"resources,omitempty"`
+}
+
+// DriftedResource is a resource that changed outside of OpenTofu/Terraform, or that the plan changes.
+type DriftedResource struct {
+	Address string   `json:"address"`
+	Actions []string `json:"actions"`
+}
+
+// DetectDrift plans every unit of the stack, and reports the units whose plan has changes, as told by the detailed
+// exit code of the plan, along with the drifted resources read from their JSON plan. The command of the options must
+// be `plan` with -detailed-exitcode, and the JSON plans must be written to --json-out-dir. The report is returned
+// along with the error of the run, so that the drift of the units that were planned is known even if others failed.
+func (stack *Stack) DetectDrift(ctx context.Context, terragruntOptions *options.TerragruntOptions) (*DriftReport, error) {
+	if terragruntOptions.TerraformCommand != tf.CommandNamePlan || !util.ListContainsElement(terragruntOptions.TerraformCliArgs, tf.FlagNameDetailedExitCode) {
+		return nil, errors.Errorf("drift is detected with `plan %s`, not with %v", tf.FlagNameDetailedExitCode, terragruntOptions.TerraformCliArgs)
+	}
+
+	if terragruntOptions.JSONOutputFolder == "" {
+		return nil, errors.New("drift detection reads the JSON plans of the units, the folder to write them to must be set")
+	}
+
+	var report *DriftReport
+
+	stack.onRunFinished = func(runningModules RunningModules) {
+		report = newDriftReport(terragruntOptions, stack.Modules, runningModules)
+	}
+	defer func() { stack.onRunFinished = nil }()
+
+	err := stack.Run(ctx, terragruntOptions)
+
+	return report, err
+}
+
+// newDriftReport reports the drift of the given modules that ran.
+func newDriftReport(terragruntOptions *options.TerragruntOptions, modules TerraformModules, runningModules RunningModules) *DriftReport {
+	report := &DriftReport{Units: []*UnitDrift{}}
+
+	for _, module := range modules {
+		runningModule, ok := runningModules[module.Path]
+		if !ok || module.FlagExcluded || module.AssumeAlreadyApplied {
+			continue
+		}
+
+		unit := &UnitDrift{Path: unitRelPath(terragruntOptions, module.Path), Status: UnitInSync}
+
+		switch {
+		case runningModule.Err != nil || runningModule.StartTime.IsZero():
+			unit.Status = UnitDriftUnknown
+			unit.Error = fmt.Sprintf("the plan did not succeed: %s", runningModule.runStatus())
+		case runningModule.DetailedExitCode == tf.DetailedExitCodeChanges:
+			unit.Status = UnitDrifted
+
+			if err := unit.read(module.outputJSONFile(terragruntOptions)); err != nil {
+				unit.Error = err.Error()
+			}
+		}
+
+		switch unit.Status {
+		case UnitDrifted:
+			report.Drifted++
+		case UnitInSync:
+			report.InSync++
+		case UnitDriftUnknown:
+			report.Unknown++
+		}
+
+		report.Units = append(report.Units, unit)
+	}
+
+	slices.SortFunc(report.Units, func(a, b *UnitDrift) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return report
+}
+
+// read reads the drifted resources of the given JSON plan.
+func (unit *UnitDrift) read(path string) error {
+	plan, err := policy.ReadPlan(path)
+	if err != nil {
+		return err
+	}
+
+	// The objects changed outside of OpenTofu/Terraform are in resource_drift, and the changes the plan makes to
+	// reconcile the configuration in resource_changes, which `plan -refresh-only` leaves empty.
+	for _, resource := range append(plan.ResourceDrift, plan.ResourceChanges...) {
+		// Data sources are read, not changed.
+		if resource.Mode == "data" || slices.Equal(resource.Change.Actions, []string{"no-op"}) {
+			continue
+		}
+
+		// A resource that drifted and is changed by the plan is only reported once.
+		if slices.ContainsFunc(unit.Resources, func(drifted *DriftedResource) bool { return drifted.Address == resource.Address }) {
+			continue
+		}
+
+		unit.Resources = append(unit.Resources, &DriftedResource{Address: resource.Address, Actions: resource.Change.Actions})
+	}
+
+	return nil
+}
+
+// Print prints the drift status of every unit, followed by its drifted resources.
+func (report *DriftReport) Print(w io.Writer) error {
+	lines := make([]string, 0, len(report.Units)+1)
+
+	for _, unit := range report.Units {
+		line := fmt.Sprintf("%s\t%s", unit.Path, unit.Status)
+		if unit.Error != "" {
+			line += "\terror: " + unit.Error
+		}
+
+		for _, resource := range unit.Resources {
+			line += fmt.Sprintf("\n  %s\t%s", resource.Address, strings.Join(resource.Actions, ", "))
+		}
+
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, fmt.Sprintf("\n%d drifted, %d in sync, %d unknown", report.Drifted, report.InSync, report.Unknown))
+
+	if _, err := fmt.Fprintln(w, strings.Join(lines, "\n")); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// WriteJSON writes the report as JSON.
+func (report *DriftReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(report); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package configstack_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDriftTestModule returns a module whose plan exits with the given detailed exit code, and whose JSON plan is the
+// given one.
+func newDriftTestModule(t *testing.T, opts *options.TerragruntOptions, name string, exitCode int, plan map[string]any) *configstack.TerraformModule {
+	t.Helper()
+
+	content, err := json.Marshal(plan)
+	require.NoError(t, err)
+
+	return newRunnerTestModule(t, opts, name, func(ctx context.Context, opts *options.TerragruntOptions) error {
+		if opts.TerraformCommand == tf.CommandNameShow {
+			_, err := opts.Writer.Write(content)
+			return err
+		}
+
+		tf.DetailedExitCodeFromContext(ctx).Set(exitCode)
+
+		return nil
+	})
+}
+
+func TestStackDetectDrift(t *testing.T) {
+	t.Parallel()
+
+	opts := newFailFastTestOptions(t)
+	opts.TerraformCommand = tf.CommandNamePlan
+	opts.TerraformCliArgs = []string{tf.CommandNamePlan, tf.FlagNameDetailedExitCode, "-refresh-only"}
+	opts.JSONOutputFolder = filepath.Join(opts.WorkingDir, "plans")
+
+	resource := func(address, mode string, actions ...string) map[string]any {
+		return map[string]any{"address": address, "mode": mode, "change": map[string]any{"actions": actions}}
+	}
+
+	app := newDriftTestModule(t, opts, "app", tf.DetailedExitCodeChanges, map[string]any{
+		"resource_drift": []any{
+			resource("aws_instance.web", "managed", "update"),
+			resource("aws_security_group.web", "managed", "delete"),
+		},
+		"resource_changes": []any{
+			resource("aws_instance.web", "managed", "update"),
+			resource("aws_s3_bucket.logs", "managed", "no-op"),
+			resource("data.aws_ami.ubuntu", "data", "read"),
+		},
+	})
+	vpc := newDriftTestModule(t, opts, "vpc", 0, map[string]any{})
+	broken := newRunnerTestModule(t, opts, "broken", func(_ context.Context, _ *options.TerragruntOptions) error {
+		return errors.New("plan failed")
+	})
+
+	stack := configstack.NewStack(opts)
+	stack.Modules = configstack.TerraformModules{app, vpc, broken}
+
+	report, err := stack.DetectDrift(context.Background(), opts)
+	require.Error(t, err)
+	require.NotNil(t, report, "the drift of the units that were planned must be reported when others fail")
+
+	assert.Equal(t, 1, report.Drifted)
+	assert.Equal(t, 1, report.InSync)
+	assert.Equal(t, 1, report.Unknown)
+	require.Len(t, report.Units, 3)
+
+	assert.Equal(t, "app", report.Units[0].Path)
+	assert.Equal(t, configstack.UnitDrifted, report.Units[0].Status)
+	assert.Equal(t, []*configstack.DriftedResource{
+		{Address: "aws_instance.web", Actions: []string{"update"}},
+		{Address: "aws_security_group.web", Actions: []string{"delete"}},
+	}, report.Units[0].Resources)
+
+	assert.Equal(t, "broken", report.Units[1].Path)
+	assert.Equal(t, configstack.UnitDriftUnknown, report.Units[1].Status)
+	assert.NotEmpty(t, report.Units[1].Error)
+
+	assert.Equal(t, "vpc", report.Units[2].Path)
+	assert.Equal(t, configstack.UnitInSync, report.Units[2].Status)
+	assert.Empty(t, report.Units[2].Resources)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, report.Print(out))
+	assert.Contains(t, out.String(), "app\tdrifted\n  aws_instance.web\tupdate\n  aws_security_group.web\tdelete")
+	assert.Contains(t, out.String(), "1 drifted, 1 in sync, 1 unknown")
+}
+
+func TestStackDetectDriftWithoutDetailedExitCode(t *testing.T) {
+	t.Parallel()
+
+	opts := newFailFastTestOptions(t)
+	opts.TerraformCommand = tf.CommandNamePlan
+	opts.TerraformCliArgs = []string{tf.CommandNamePlan}
+	opts.JSONOutputFolder = filepath.Join(opts.WorkingDir, "plans")
+
+	stack := configstack.NewStack(opts)
+	stack.Modules = configstack.TerraformModules{newDriftTestModule(t, opts, "app", 0, nil)}
+
+	report, err := stack.DetectDrift(context.Background(), opts)
+	require.Error(t, err)
+	assert.Nil(t, report)
+}
@@ -34,15 +34,6 @@ func (err ProcessingModuleError) Unwrap() error {
 	return err.UnderlyingError
 }
 
-type InfiniteRecursionError struct {
-	Modules        map[string]*TerraformModule
-	RecursionLevel int
-}
-
-func (err InfiniteRecursionError) Error() string {
-	return fmt.Sprintf("Hit what seems to be an infinite recursion after going %d levels deep. Please check for a circular dependency! Modules involved: %v", err.RecursionLevel, err.Modules)
-}
-
 var ErrNoTerraformModulesFound = errors.New("could not find any subfolders with Terragrunt configuration files")
 
 type DependencyCycleError []string
@@ -73,6 +64,33 @@ func (err ProcessingModuleDependencyError) Unwrap() error {
 	return err.Err
 }
 
+// UnitCancelledError is the error of the units cancelled by --fail-fast, because another unit failed, or because the
+// run was cancelled.
+type UnitCancelledError struct {
+	Module *TerraformModule
+	// FailedModule is the module that failed, or nil if the run was cancelled.
+	FailedModule *TerraformModule
+	// Err is the error the unit finished with, if it was interrupted while running.
+	Err error
+}
+
+func (err UnitCancelledError) Error() string {
+	reason := "the run was cancelled"
+	if err.FailedModule != nil {
+		reason = fmt.Sprintf("module %s failed", err.FailedModule)
+	}
+
+	if err.Err != nil {
+		return fmt.Sprintf("Module %s was interrupted because %s: %s", err.Module, reason, err.Err)
+	}
+
+	return fmt.Sprintf("Module %s was cancelled because %s", err.Module, reason)
+}
+
+func (err UnitCancelledError) Unwrap() error {
+	return err.Err
+}
+
 type DependencyNotFoundWhileCrossLinkingError struct {
 	Module     *RunningModule
 	Dependency *TerraformModule
@@ -81,3 +99,55 @@ type DependencyNotFoundWhileCrossLinkingError struct {
 func (err DependencyNotFoundWhileCrossLinkingError) Error() string {
 	return fmt.Sprintf("Module %v specifies a dependency on module %v, but could not find that module while cross-linking dependencies. This is most likely a bug in Terragrunt. Please report it.", err.Module, err.Dependency)
 }
+
+type InvalidRunReportFormatError string
+
+func (format InvalidRunReportFormatError) Error() string {
+	return fmt.Sprintf("Invalid run report format %q, valid formats are %s and %s", string(format), RunReportFormatJSON, RunReportFormatJUnit)
+}
+
+type InvalidParallelismGroupError struct {
+	Group string
+	Limit int
+}
+
+func (err InvalidParallelismGroupError) Error() string {
+	return fmt.Sprintf("Invalid parallelism %d of the concurrency group %q, the parallelism must be at least 1", err.Limit, err.Group)
+}
+
+type InvalidSchedulingWeightError struct {
+	ModulePath string
+	Weight     int
+}
+
+func (err InvalidSchedulingWeightError) Error() string {
+	return fmt.Sprintf("Invalid scheduling weight %d of module %s, the weight must be at least 1", err.Weight, err.ModulePath)
+}
+
+type RunStateMismatchError struct {
+	RunID      string
+	WorkingDir string
+	Args       []string
+}
+
+func (err RunStateMismatchError) Error() string {
+	return fmt.Sprintf("Run %s was started in %s with the arguments %v, it can only be resumed with the same working dir and arguments", err.RunID, err.WorkingDir, err.Args)
+}
+
+type InvalidPlanSummaryFormatError string
+
+func (format InvalidPlanSummaryFormatError) Error() string {
+	return fmt.Sprintf("Invalid plan summary format %q, valid formats are %s and %s", string(format), PlanSummaryFormatJSON, PlanSummaryFormatMarkdown)
+}
+
+type PlanSummaryWithoutJSONOutDirError struct{}
+
+func (err PlanSummaryWithoutJSONOutDirError) Error() string {
+	return "The plan summary is read from the JSON plans of the units, set the folder to write them to with --json-out-dir"
+}
+
+type FromPlanDirWithoutApplyError string
+
+func (command FromPlanDirWithoutApplyError) Error() string {
+	return fmt.Sprintf("Saved plans are applied from --from-plan-dir with the apply command, not with %s", string(command))
+}
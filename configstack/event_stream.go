@@ -0,0 +1,126 @@
+package configstack
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+const eventStreamFilePerm = 0644
+
+// EventStream writes the events of the units of a run of all units as newline-delimited JSON, one event per line, to a
+// file or a unix socket.
+type EventStream struct {
+	terragruntOptions *options.TerragruntOptions
+	out               io.WriteCloser
+	encoder           *json.Encoder
+	mu                sync.Mutex
+}
+
+// EventRecord is the JSON representation of a UnitEvent in an event stream.
+type EventRecord struct {
+	Time time.Time     `json:"time"`
+	Type UnitEventType `json:"type"`
+	// Unit is the path of the unit, relative to the working dir.
+	Unit   string        `json:"unit"`
+	Status UnitRunStatus `json:"status,omitempty"`
+	Stream OutputStream  `json:"stream,omitempty"`
+	Output string        `json:"output,omitempty"`
+	Error  string        `json:"error,omitempty"`
+	// WaitingFor are the paths of the units the unit waits for, relative to the working dir.
+	WaitingFor []string `json:"waiting_for,omitempty"`
+	Attempt    int      `json:"attempt,omitempty"`
+	// ExitCode is only set for finished events, where 0 is meaningful.
+	ExitCode *int `json:"exit_code,omitempty"`
+}
+
+// OpenEventStream opens the event stream set with --event-stream-file or --event-stream-socket. It returns nil if
+// neither is set.
+func OpenEventStream(terragruntOptions *options.TerragruntOptions) (*EventStream, error) {
+	stream := &EventStream{terragruntOptions: terragruntOptions}
+
+	switch {
+	case terragruntOptions.EventStreamSocket != "":
+		conn, err := net.Dial("unix", terragruntOptions.EventStreamSocket)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		stream.out = conn
+	case terragruntOptions.EventStreamFile != "":
+		path := terragruntOptions.EventStreamFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(terragruntOptions.WorkingDir, path)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			return nil, errors.New(err)
+		}
+
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, eventStreamFilePerm)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		stream.out = file
+	default:
+		return nil, nil
+	}
+
+	stream.encoder = json.NewEncoder(stream.out)
+
+	return stream, nil
+}
+
+// Write writes the given event as a line of JSON.
+func (stream *EventStream) Write(event UnitEvent) error {
+	record := &EventRecord{
+		Time:   event.Time.UTC(),
+		Type:   event.Type,
+		Unit:   unitRelPath(stream.terragruntOptions, event.Path),
+		Status: event.Status,
+		Stream: event.Stream,
+		Output: event.Output,
+	}
+
+	for _, path := range event.WaitingFor {
+		record.WaitingFor = append(record.WaitingFor, unitRelPath(stream.terragruntOptions, path))
+	}
+
+	switch event.Type { //nolint:exhaustive
+	case UnitRetrying:
+		record.Attempt = event.Attempt
+	case UnitFinished:
+		exitCode := event.ExitCode
+		record.ExitCode = &exitCode
+
+		if event.Err != nil {
+			record.Error = event.Err.Error()
+		}
+	}
+
+	stream.mu.Lock()
+	defer stream.mu.Unlock()
+
+	if err := stream.encoder.Encode(record); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// Close closes the file or the socket of the event stream.
+func (stream *EventStream) Close() error {
+	if err := stream.out.Close(); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
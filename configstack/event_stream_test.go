@@ -0,0 +1,133 @@
+package configstack_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestEvents(t *testing.T, opts *options.TerragruntOptions) {
+	t.Helper()
+
+	stream, err := configstack.OpenEventStream(opts)
+	require.NoError(t, err)
+	require.NotNil(t, stream)
+
+	now := time.Now()
+	unitPath := filepath.Join(opts.WorkingDir, "app")
+
+	require.NoError(t, stream.Write(configstack.UnitEvent{Type: configstack.UnitWaiting, Path: unitPath, Time: now, WaitingFor: []string{filepath.Join(opts.WorkingDir, "vpc")}}))
+	require.NoError(t, stream.Write(configstack.UnitEvent{Type: configstack.UnitOutput, Path: unitPath, Time: now, Stream: configstack.OutputStdout, Output: "done\n"}))
+	require.NoError(t, stream.Write(configstack.UnitEvent{Type: configstack.UnitFinished, Path: unitPath, Time: now, Status: configstack.UnitFailed, Err: errors.New("boom"), ExitCode: 1}))
+	require.NoError(t, stream.Close())
+}
+
+func readTestEvents(t *testing.T, lines []string) []map[string]any {
+	t.Helper()
+
+	records := make([]map[string]any, 0, len(lines))
+
+	for _, line := range lines {
+		record := map[string]any{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		delete(record, "time")
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+var expectedTestEvents = []map[string]any{
+	{"type": "waiting", "unit": "app", "waiting_for": []any{"vpc"}},
+	{"type": "output", "unit": "app", "stream": "stdout", "output": "done\n"},
+	{"type": "finished", "unit": "app", "status": "failed", "exit_code": float64(1), "error": "boom"},
+}
+
+func TestEventStreamFile(t *testing.T) {
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(t.TempDir(), config.DefaultTerragruntConfigPath))
+	require.NoError(t, err)
+
+	opts.EventStreamFile = filepath.Join("events", "run.ndjson")
+
+	writeTestEvents(t, opts)
+
+	file, err := os.Open(filepath.Join(opts.WorkingDir, "events", "run.ndjson"))
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	lines := []string{}
+	for scanner := bufio.NewScanner(file); scanner.Scan(); {
+		lines = append(lines, scanner.Text())
+	}
+
+	assert.Equal(t, expectedTestEvents, readTestEvents(t, lines))
+}
+
+func TestEventStreamSocket(t *testing.T) {
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(t.TempDir(), config.DefaultTerragruntConfigPath))
+	require.NoError(t, err)
+
+	// Unix socket paths are limited in length, so keep it short rather than nested in the test temp dir.
+	socketDir, err := os.MkdirTemp("", "tg")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { os.RemoveAll(socketDir) })
+
+	opts.EventStreamSocket = filepath.Join(socketDir, "events.sock")
+
+	listener, err := net.Listen("unix", opts.EventStreamSocket)
+	require.NoError(t, err)
+
+	defer listener.Close()
+
+	lines := make(chan []string)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			close(lines)
+			return
+		}
+
+		defer conn.Close()
+
+		read := []string{}
+		for scanner := bufio.NewScanner(conn); scanner.Scan(); {
+			read = append(read, scanner.Text())
+		}
+
+		lines <- read
+	}()
+
+	writeTestEvents(t, opts)
+
+	assert.Equal(t, expectedTestEvents, readTestEvents(t, <-lines))
+}
+
+func TestEventStreamNotConfigured(t *testing.T) {
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(t.TempDir(), config.DefaultTerragruntConfigPath))
+	require.NoError(t, err)
+
+	stream, err := configstack.OpenEventStream(opts)
+	require.NoError(t, err)
+	assert.Nil(t, stream)
+}
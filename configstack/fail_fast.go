@@ -0,0 +1,81 @@
+package configstack
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+// failFastState cancels the rest of a run of all units as soon as one of the units fails, with --fail-fast.
+type failFastState struct {
+	// failed is the first module that failed.
+	failed *RunningModule
+	// cancel cancels the context of the running modules, to interrupt them with --fail-fast-interrupt.
+	cancel    context.CancelCauseFunc
+	mu        sync.Mutex
+	interrupt bool
+}
+
+// newFailFastState returns the fail-fast state of a run of all units, along with the context the units must run with,
+// or nil if --fail-fast is not set.
+func newFailFastState(ctx context.Context, opts *options.TerragruntOptions) (context.Context, *failFastState) {
+	if !opts.FailFast && !opts.FailFastInterrupt {
+		return ctx, nil
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	return ctx, &failFastState{cancel: cancel, interrupt: opts.FailFastInterrupt}
+}
+
+// failedModule returns the first module that failed, or nil if no module failed yet.
+func (state *failFastState) failedModule() *RunningModule {
+	if state == nil {
+		return nil
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	return state.failed
+}
+
+// fail records that the given module finished with the given error, and cancels the rest of the run if it is the
+// first module that failed. A module failing after the running modules were interrupted is assumed to have failed
+// because of the interruption, so the error is turned into a UnitCancelledError.
+func (state *failFastState) fail(module *RunningModule, err error) error {
+	if state == nil {
+		return err
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if state.failed != nil {
+		if state.interrupt {
+			return errors.New(UnitCancelledError{Module: module.Module, FailedModule: state.failed.Module, Err: err})
+		}
+
+		return err
+	}
+
+	state.failed = module
+
+	if state.interrupt {
+		module.Module.TerragruntOptions.Logger.Errorf("Module %s failed, interrupting the running units and cancelling the units that did not start yet because of --fail-fast-interrupt", module.Module.Path)
+		state.cancel(errors.Errorf("module %s failed", module.Module.Path))
+	} else {
+		module.Module.TerragruntOptions.Logger.Errorf("Module %s failed, cancelling the units that did not start yet because of --fail-fast", module.Module.Path)
+	}
+
+	return err
+}
+
+// stop releases the resources of the context of the run.
+func (state *failFastState) stop() {
+	if state != nil {
+		state.cancel(nil)
+	}
+}
@@ -0,0 +1,154 @@
+package configstack_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runFailFastTestStack runs a stack with the units "failing", which fails once "slow" started, "slow", which runs until
+// runSlow returns, and "dependent", which depends on "slow". It returns the statuses of the units from the run report, along with the
+// number of runs of "dependent".
+func runFailFastTestStack(t *testing.T, opts *options.TerragruntOptions, runSlow func(ctx context.Context, failed <-chan struct{}) error) (map[string]configstack.UnitRunStatus, int32) {
+	t.Helper()
+
+	slowStarted := make(chan struct{})
+	failed := make(chan struct{})
+	dependentRuns := &atomic.Int32{}
+
+	newModule := func(name string, runTerragrunt func(ctx context.Context) error) *configstack.TerraformModule {
+		moduleOpts, err := opts.CloneWithConfigPath(filepath.Join(opts.WorkingDir, name, config.DefaultTerragruntConfigPath))
+		require.NoError(t, err)
+
+		moduleOpts.RunTerragrunt = func(ctx context.Context, _ *options.TerragruntOptions) error {
+			return runTerragrunt(ctx)
+		}
+
+		return &configstack.TerraformModule{
+			Stack:             &configstack.Stack{},
+			Path:              filepath.Join(opts.WorkingDir, name),
+			Dependencies:      configstack.TerraformModules{},
+			TerragruntOptions: moduleOpts,
+		}
+	}
+
+	failing := newModule("failing", func(_ context.Context) error {
+		<-slowStarted
+		close(failed)
+		return errors.New("apply failed")
+	})
+	slow := newModule("slow", func(ctx context.Context) error {
+		close(slowStarted)
+		return runSlow(ctx, failed)
+	})
+	dependent := newModule("dependent", func(_ context.Context) error {
+		dependentRuns.Add(1)
+		return nil
+	})
+	dependent.Dependencies = configstack.TerraformModules{slow}
+
+	stack := configstack.NewStack(opts)
+	stack.Modules = configstack.TerraformModules{failing, slow, dependent}
+
+	opts.RunReportFile = "report.json"
+	require.Error(t, stack.Run(context.Background(), opts))
+
+	content, err := os.ReadFile(filepath.Join(opts.WorkingDir, opts.RunReportFile))
+	require.NoError(t, err)
+
+	var report configstack.RunReport
+	require.NoError(t, json.Unmarshal(content, &report))
+
+	statuses := map[string]configstack.UnitRunStatus{}
+	for _, unit := range report.Units {
+		statuses[unit.Path] = unit.Status
+	}
+
+	return statuses, dependentRuns.Load()
+}
+
+func newFailFastTestOptions(t *testing.T) *options.TerragruntOptions {
+	t.Helper()
+
+	workingDir := t.TempDir()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, config.DefaultTerragruntConfigPath))
+	require.NoError(t, err)
+
+	opts.WorkingDir = workingDir
+	opts.TerraformCommand = tf.CommandNameApply
+	opts.TerraformCliArgs = []string{tf.CommandNameApply}
+
+	return opts
+}
+
+func TestStackRunFailFast(t *testing.T) {
+	t.Parallel()
+
+	opts := newFailFastTestOptions(t)
+	opts.FailFast = true
+
+	statuses, dependentRuns := runFailFastTestStack(t, opts, func(ctx context.Context, failed <-chan struct{}) error {
+		<-failed
+		// Give the failing unit the time to cancel the run, the slow unit must still complete.
+		time.Sleep(100 * time.Millisecond)
+
+		return ctx.Err()
+	})
+
+	assert.Equal(t, map[string]configstack.UnitRunStatus{
+		"failing":   configstack.UnitFailed,
+		"slow":      configstack.UnitSucceeded,
+		"dependent": configstack.UnitCancelled,
+	}, statuses)
+	assert.Equal(t, int32(0), dependentRuns)
+}
+
+func TestStackRunFailFastInterrupt(t *testing.T) {
+	t.Parallel()
+
+	opts := newFailFastTestOptions(t)
+	opts.FailFastInterrupt = true
+
+	statuses, dependentRuns := runFailFastTestStack(t, opts, func(ctx context.Context, _ <-chan struct{}) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	assert.Equal(t, map[string]configstack.UnitRunStatus{
+		"failing":   configstack.UnitFailed,
+		"slow":      configstack.UnitCancelled,
+		"dependent": configstack.UnitCancelled,
+	}, statuses)
+	assert.Equal(t, int32(0), dependentRuns)
+}
+
+func TestStackRunWithoutFailFast(t *testing.T) {
+	t.Parallel()
+
+	opts := newFailFastTestOptions(t)
+
+	statuses, dependentRuns := runFailFastTestStack(t, opts, func(_ context.Context, failed <-chan struct{}) error {
+		<-failed
+		return nil
+	})
+
+	assert.Equal(t, map[string]configstack.UnitRunStatus{
+		"failing":   configstack.UnitFailed,
+		"slow":      configstack.UnitSucceeded,
+		"dependent": configstack.UnitSucceeded,
+	}, statuses)
+	assert.Equal(t, int32(1), dependentRuns)
+}
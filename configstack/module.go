@@ -9,15 +9,12 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/gruntwork-io/terragrunt/internal/cache"
+	"github.com/gruntwork-io/terragrunt/internal/discovery"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 	"github.com/gruntwork-io/terragrunt/tf"
 
 	"slices"
 
-	"maps"
-
-	"github.com/gruntwork-io/go-commons/files"
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/options"
@@ -26,7 +23,6 @@ import (
 )
 
 const maxLevelsOfRecursion = 20
-const existingModulesCacheName = "existingModules"
 
 // TerraformModule represents a single module (i.e. folder with Terraform templates), including the Terragrunt configuration for that
 // module and the list of other modules that this module depends on
@@ -101,6 +97,11 @@ func (module *TerraformModule) checkForCyclesUsingDepthFirstSearch(visitedPaths
 
 // planFile - return plan file location, if output folder is set
 func (module *TerraformModule) planFile(opts *options.TerragruntOptions) string {
+	// apply the plan saved in the folder the plans are applied from
+	if opts.FromPlanDir != "" && module.TerragruntOptions.TerraformCommand == tf.CommandNameApply {
+		return module.getPlanFilePath(opts, opts.FromPlanDir, tf.TerraformPlanFile)
+	}
+
 	var planFile string
 
 	// set plan file location if output folder is set
@@ -178,30 +179,20 @@ func (module *TerraformModule) confirmShouldApplyExternalDependency(ctx context.
 	return shell.PromptUserForYesNo(ctx, "Should Terragrunt apply the external dependency?", opts)
 }
 
-// Get the list of modules this module depends on
-func (module *TerraformModule) getDependenciesForModule(modulesMap TerraformModulesMap, terragruntConfigPaths []string) (TerraformModules, error) {
+// Get the list of modules this module depends on, discovered along with the given discovered configuration of the module
+func (module *TerraformModule) getDependenciesForModule(modulesMap TerraformModulesMap, cfg *discovery.DiscoveredConfig, terragruntConfigPaths []string) (TerraformModules, error) {
 	dependencies := TerraformModules{}
 
-	if module.Config.Dependencies == nil || len(module.Config.Dependencies.Paths) == 0 {
+	if cfg == nil {
 		return dependencies, nil
 	}
 
-	for _, dependencyPath := range module.Config.Dependencies.Paths {
-		dependencyModulePath, err := util.CanonicalPath(dependencyPath, module.Path)
-		if err != nil {
-			// TODO: Remove lint suppression
-			return dependencies, nil //nolint:nilerr
-		}
-
-		if files.FileExists(dependencyModulePath) && !files.IsDir(dependencyModulePath) {
-			dependencyModulePath = filepath.Dir(dependencyModulePath)
-		}
-
-		dependencyModule, foundModule := modulesMap[dependencyModulePath]
+	for _, dependency := range cfg.Dependencies {
+		dependencyModule, foundModule := modulesMap[dependency.Path]
 		if !foundModule {
 			err := UnrecognizedDependencyError{
 				ModulePath:            module.Path,
-				DependencyPath:        dependencyPath,
+				DependencyPath:        dependency.Path,
 				TerragruntConfigPaths: terragruntConfigPaths,
 			}
 
@@ -343,7 +334,7 @@ func (modules TerraformModules) RunModules(ctx context.Context, opts *options.Te
 		return err
 	}
 
-	return runningModules.runModules(ctx, opts, parallelism)
+	return runningModules.runModules(ctx, opts, parallelism, nil)
 }
 
 // RunModulesReverseOrder runs the given map of module path to runningModule. To "run" a module, execute the RunTerragrunt command in its
@@ -355,7 +346,7 @@ func (modules TerraformModules) RunModulesReverseOrder(ctx context.Context, opts
 		return err
 	}
 
-	return runningModules.runModules(ctx, opts, parallelism)
+	return runningModules.runModules(ctx, opts, parallelism, nil)
 }
 
 // RunModulesIgnoreOrder runs the given map of module path to runningModule. To "run" a module, execute the RunTerragrunt command in its
@@ -366,7 +357,7 @@ func (modules TerraformModules) RunModulesIgnoreOrder(ctx context.Context, opts
 		return err
 	}
 
-	return runningModules.runModules(ctx, opts, parallelism)
+	return runningModules.runModules(ctx, opts, parallelism, nil)
 }
 
 // ToRunningModules converts the list of modules to a map from module path to a runningModule struct. This struct contains information
@@ -546,6 +537,43 @@ func (modules TerraformModules) flagUnitsThatRead(opts *options.TerragruntOption
 	return modules
 }
 
+// flagUnitsChangedSince iterates over a module slice and flags all modules affected by the files changed since the git
+// ref in the TerragruntOptions QueueIncludeChangedSince attribute, along with all the modules that depend on them. The
+// affected units are found by discovering the files read by the units, see discovery.DiscoveredConfigs.AffectedBy.
+func (modules TerraformModules) flagUnitsChangedSince(ctx context.Context, opts *options.TerragruntOptions) (TerraformModules, error) {
+	// If no QueueIncludeChangedSince is specified return the modules list instantly
+	if opts.QueueIncludeChangedSince == "" {
+		return modules, nil
+	}
+
+	changedFiles, err := shell.GitChangedFiles(ctx, opts, opts.WorkingDir, opts.QueueIncludeChangedSince)
+	if err != nil {
+		return nil, err
+	}
+
+	discovered, err := newStackDiscovery(opts).
+		WithDiscoverExternalDependencies().
+		WithReadFiles().
+		Discover(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	affected := make(map[string]bool, len(discovered))
+	for _, unit := range discovered.AffectedBy(changedFiles) {
+		affected[unit.Path] = true
+	}
+
+	for _, module := range modules {
+		if affected[module.Path] {
+			opts.Logger.Debugf("Module %s is affected by the files changed since %s", module.Path, opts.QueueIncludeChangedSince)
+			module.FlagExcluded = false
+		}
+	}
+
+	return modules, nil
+}
+
 // flagExcludedDirs iterates over a module slice and flags all entries as excluded listed in the terragrunt-exclude-dir CLI flag.
 func (modules TerraformModules) flagExcludedDirs(opts *options.TerragruntOptions) TerraformModules {
 	// If we don't have any excludes, we don't need to do anything.
@@ -570,32 +598,26 @@ func (modules TerraformModules) flagExcludedDirs(opts *options.TerragruntOptions
 	return modules
 }
 
-var existingModules = cache.NewCache[*TerraformModulesMap](existingModulesCacheName)
-
 type TerraformModulesMap map[string]*TerraformModule
 
-// Merge the given external dependencies into the given map of modules if those dependencies aren't already in the
-// modules map
-func (modulesMap TerraformModulesMap) mergeMaps(externalDependencies TerraformModulesMap) TerraformModulesMap {
-	out := TerraformModulesMap{}
-
-	maps.Copy(out, externalDependencies)
-
-	maps.Copy(out, modulesMap)
+// Go through each module in the given map and cross-link its dependencies, discovered along with the given discovered
+// configurations, to the other modules in that same map. If a dependency is not in the given map, return an error.
+func (modulesMap TerraformModulesMap) crosslinkDependencies(configs discovery.DiscoveredConfigs) (TerraformModules, error) {
+	modules := TerraformModules{}
 
-	return out
-}
+	configsMap := make(map[string]*discovery.DiscoveredConfig, len(configs))
+	terragruntConfigPaths := make([]string, 0, len(configs))
 
-// Go through each module in the given map and cross-link its dependencies to the other modules in that same map. If
-// a dependency is referenced that is not in the given map, return an error.
-func (modulesMap TerraformModulesMap) crosslinkDependencies(canonicalTerragruntConfigPaths []string) (TerraformModules, error) {
-	modules := TerraformModules{}
+	for _, cfg := range configs {
+		configsMap[cfg.Path] = cfg
+		terragruntConfigPaths = append(terragruntConfigPaths, cfg.ConfigPath())
+	}
 
 	keys := modulesMap.getSortedKeys()
 	for _, key := range keys {
 		module := modulesMap[key]
 
-		dependencies, err := module.getDependenciesForModule(modulesMap, canonicalTerragruntConfigPaths)
+		dependencies, err := module.getDependenciesForModule(modulesMap, configsMap[key], terragruntConfigPaths)
 		if err != nil {
 			return modules, err
 		}
@@ -4,6 +4,10 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
 
@@ -1313,3 +1317,113 @@ func TestRunModulesReverseOrderMultipleModulesWithDependenciesLargeGraphPartialF
 	assert.True(t, eRan)
 	assert.True(t, fRan)
 }
+
+// createChangedSinceTestRepo creates a git repository with a committed stack of units, and returns its path.
+func createChangedSinceTestRepo(t *testing.T) string {
+	t.Helper()
+
+	repoDir := t.TempDir()
+
+	files := map[string]string{
+		"root.hcl":            ``,
+		"common.hcl":          `locals { region = "us-east-1" }`,
+		"modules/vpc/main.tf": ``,
+		"app/main.tf":         ``,
+		"db/main.tf":          ``,
+		"other/main.tf":       ``,
+		"vpc/" + config.DefaultTerragruntConfigPath: `
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+terraform {
+  source = "../modules//vpc"
+}
+`,
+		"app/" + config.DefaultTerragruntConfigPath: `
+dependencies {
+  paths = ["../vpc"]
+}
+`,
+		"db/" + config.DefaultTerragruntConfigPath: `
+locals {
+  common = read_terragrunt_config("../common.hcl")
+}
+`,
+		"other/" + config.DefaultTerragruntConfigPath: ``,
+	}
+
+	for path, content := range files {
+		path = filepath.Join(repoDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "--all"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "--message", "stack"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	return repoDir
+}
+
+func TestFlagUnitsChangedSince(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	testCases := []struct {
+		name          string
+		changedFile   string
+		expectedUnits []string
+	}{
+		{name: "unit file", changedFile: "other/main.tf", expectedUnits: []string{"other"}},
+		{name: "include", changedFile: "root.hcl", expectedUnits: []string{"app", "vpc"}},
+		{name: "read file", changedFile: "common.hcl", expectedUnits: []string{"db"}},
+		{name: "local source", changedFile: "modules/vpc/main.tf", expectedUnits: []string{"app", "vpc"}},
+		{name: "dependency config", changedFile: "vpc/" + config.DefaultTerragruntConfigPath, expectedUnits: []string{"app", "vpc"}},
+		{name: "unrelated file", changedFile: "README.md", expectedUnits: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repoDir := createChangedSinceTestRepo(t)
+
+			changedFile := filepath.Join(repoDir, tc.changedFile)
+			content, _ := os.ReadFile(changedFile)
+			require.NoError(t, os.WriteFile(changedFile, append(content, "\n# changed\n"...), 0644))
+
+			opts, err := options.NewTerragruntOptionsForTest(filepath.Join(repoDir, config.DefaultTerragruntConfigPath))
+			require.NoError(t, err)
+
+			opts.WorkingDir = repoDir
+			opts.QueueIncludeChangedSince = "HEAD"
+			opts.ExcludeByDefault = true
+
+			stack, err := configstack.FindStackInSubfolders(context.Background(), opts)
+			require.NoError(t, err)
+
+			var units []string
+
+			for _, module := range stack.Modules {
+				if !module.FlagExcluded {
+					units = append(units, filepath.Base(module.Path))
+				}
+			}
+
+			sort.Strings(units)
+			assert.Equal(t, tc.expectedUnits, units)
+		})
+	}
+}
@@ -19,3 +19,11 @@ func WithParseOptions(parserOptions []hclparse.Option) Option {
 		stack.parserOptions = parserOptions
 	}
 }
+
+// WithRunState makes the stack persist the completion state of its units when running, so that a failed run can be
+// resumed with --resume.
+func WithRunState() Option {
+	return func(stack *Stack) {
+		stack.persistRunState = true
+	}
+}
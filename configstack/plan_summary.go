@@ -0,0 +1,284 @@
+package configstack
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/policy"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+const (
+	PlanSummaryFormatJSON     = "json"
+	PlanSummaryFormatMarkdown = "markdown"
+
+	planSummaryFilePerm = 0644
+
+	// destroyMarker marks the units destroying resources in the summary table.
+	destroyMarker = "!"
+)
+
+// PlanSummary is the summary of the changes planned by `run --all plan` in every unit, read from the JSON plans
+// written to --json-out-dir.
+type PlanSummary struct {
+	Units []*UnitPlanSummary `json:"units"`
+	Total PlanChanges        `json:"total"`
+}
+
+// UnitPlanSummary is the summary of the changes planned in a single unit.
+type UnitPlanSummary struct {
+	// Path is the path of the unit, relative to the working dir.
+	Path string `json:"path"`
+	// Error is set when the plan of the unit could not be summarized, e.g. because the plan failed.
+	Error string `json:"error,omitempty"`
+	// Destroyed are the addresses of the resources the plan destroys, including the replaced resources.
+	Destroyed []string `json:"destroyed,omitempty"`
+	PlanChanges
+}
+
+// PlanChanges are the numbers of resources a plan changes, per kind of change.
+type PlanChanges struct {
+	Create  int `json:"create"`
+	Update  int `json:"update"`
+	Replace int `json:"replace"`
+	Destroy int `json:"destroy"`
+}
+
+// destroys returns true if the changes destroy resources, replaced resources being destroyed first or last.
+func (changes PlanChanges) destroys() bool {
+	return changes.Destroy > 0 || changes.Replace > 0
+}
+
+func (changes *PlanChanges) add(other PlanChanges) {
+	changes.Create += other.Create
+	changes.Update += other.Update
+	changes.Replace += other.Replace
+	changes.Destroy += other.Destroy
+}
+
+// newPlanSummary summarizes the JSON plans of the given modules that ran.
+func newPlanSummary(terragruntOptions *options.TerragruntOptions, modules TerraformModules, runningModules RunningModules) *PlanSummary {
+	summary := &PlanSummary{Units: []*UnitPlanSummary{}}
+
+	for _, module := range modules {
+		runningModule, ok := runningModules[module.Path]
+		if !ok || module.FlagExcluded || module.AssumeAlreadyApplied || runningModule.Resumed {
+			continue
+		}
+
+		unit := &UnitPlanSummary{Path: unitRelPath(terragruntOptions, module.Path)}
+
+		if runningModule.Err != nil || runningModule.StartTime.IsZero() {
+			unit.Error = fmt.Sprintf("the plan did not succeed: %s", runningModule.runStatus())
+		} else if err := unit.read(module.outputJSONFile(terragruntOptions)); err != nil {
+			unit.Error = err.Error()
+		}
+
+		summary.Total.add(unit.PlanChanges)
+		summary.Units = append(summary.Units, unit)
+	}
+
+	slices.SortFunc(summary.Units, func(a, b *UnitPlanSummary) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return summary
+}
+
+// read counts the changes of the given JSON plan.
+func (unit *UnitPlanSummary) read(path string) error {
+	plan, err := policy.ReadPlan(path)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range plan.ResourceChanges {
+		// Data sources are read, not changed.
+		if resource.Mode == "data" {
+			continue
+		}
+
+		actions := resource.Change.Actions
+
+		switch {
+		case slices.Contains(actions, "delete") && slices.Contains(actions, "create"):
+			unit.Replace++
+			unit.Destroyed = append(unit.Destroyed, resource.Address)
+		case slices.Contains(actions, "delete"):
+			unit.Destroy++
+			unit.Destroyed = append(unit.Destroyed, resource.Address)
+		case slices.Contains(actions, "create"):
+			unit.Create++
+		case slices.Contains(actions, "update"):
+			unit.Update++
+		}
+	}
+
+	return nil
+}
+
+// Print prints the summary as a table, marking the units destroying resources.
+func (summary *PlanSummary) Print(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "\nPlan summary:"); err != nil {
+		return errors.New(err)
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0) //nolint:mnd
+	rows := []string{"  UNIT\tCREATE\tUPDATE\tREPLACE\tDESTROY"}
+
+	for _, unit := range summary.Units {
+		marker := " "
+		if unit.destroys() {
+			marker = destroyMarker
+		}
+
+		counts := unit.counts()
+		if unit.Error != "" {
+			counts = []string{"-", "-", "-", "-"}
+		}
+
+		rows = append(rows, fmt.Sprintf("%s %s\t%s", marker, unit.Path, strings.Join(counts, "\t")))
+	}
+
+	rows = append(rows, "  TOTAL\t"+strings.Join(summary.Total.counts(), "\t"))
+
+	for _, row := range rows {
+		if _, err := fmt.Fprintln(table, row); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	if err := table.Flush(); err != nil {
+		return errors.New(err)
+	}
+
+	for _, unit := range summary.Units {
+		var message string
+
+		switch {
+		case unit.Error != "":
+			message = fmt.Sprintf("\n%s: %s", unit.Path, unit.Error)
+		case unit.destroys():
+			message = fmt.Sprintf("\n%s %s destroys: %s", destroyMarker, unit.Path, strings.Join(unit.Destroyed, ", "))
+		default:
+			continue
+		}
+
+		if _, err := fmt.Fprint(w, message); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	if _, err := fmt.Fprintln(w); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+func (changes PlanChanges) counts() []string {
+	return []string{
+		fmt.Sprint(changes.Create),
+		fmt.Sprint(changes.Update),
+		fmt.Sprint(changes.Replace),
+		fmt.Sprint(changes.Destroy),
+	}
+}
+
+// Write writes the summary to the given file, in the given format.
+func (summary *PlanSummary) Write(path, format string) error {
+	var content []byte
+
+	switch format {
+	case PlanSummaryFormatMarkdown:
+		content = []byte(summary.markdown())
+	default:
+		var err error
+		if content, err = json.MarshalIndent(summary, "", "  "); err != nil {
+			return errors.New(err)
+		}
+
+		content = append(content, '\n')
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return errors.New(err)
+	}
+
+	if err := os.WriteFile(path, content, planSummaryFilePerm); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// markdown renders the summary as Markdown, suitable for a pull request comment.
+func (summary *PlanSummary) markdown() string {
+	var sb strings.Builder
+
+	sb.WriteString("### Plan summary\n\n")
+	sb.WriteString("| Unit | Create | Update | Replace | Destroy |\n")
+	sb.WriteString("|------|-------:|-------:|--------:|--------:|\n")
+
+	for _, unit := range summary.Units {
+		name := fmt.Sprintf("`%s`", unit.Path)
+		if unit.destroys() {
+			name = ":warning: " + name
+		}
+
+		counts := unit.counts()
+		if unit.Error != "" {
+			counts = []string{"-", "-", "-", "-"}
+		}
+
+		fmt.Fprintf(&sb, "| %s | %s |\n", name, strings.Join(counts, " | "))
+	}
+
+	fmt.Fprintf(&sb, "| **Total** | %s |\n", strings.Join(summary.Total.counts(), " | "))
+
+	var details []string
+
+	for _, unit := range summary.Units {
+		switch {
+		case unit.Error != "":
+			details = append(details, fmt.Sprintf("- `%s`: %s", unit.Path, unit.Error))
+		case unit.destroys():
+			destroyed := make([]string, 0, len(unit.Destroyed))
+			for _, address := range unit.Destroyed {
+				destroyed = append(destroyed, fmt.Sprintf("`%s`", address))
+			}
+
+			details = append(details, fmt.Sprintf("- :warning: `%s` destroys %s", unit.Path, strings.Join(destroyed, ", ")))
+		}
+	}
+
+	if len(details) > 0 {
+		sb.WriteString("\n" + strings.Join(details, "\n") + "\n")
+	}
+
+	return sb.String()
+}
+
+// planSummaryFormat returns the format of the plan summary, derived from the extension of the summary file if not set.
+func planSummaryFormat(terragruntOptions *options.TerragruntOptions) (string, error) {
+	switch terragruntOptions.PlanSummaryFormat {
+	case PlanSummaryFormatJSON, PlanSummaryFormatMarkdown:
+		return terragruntOptions.PlanSummaryFormat, nil
+	case "":
+		switch strings.ToLower(filepath.Ext(terragruntOptions.PlanSummaryFile)) {
+		case ".md", ".markdown":
+			return PlanSummaryFormatMarkdown, nil
+		}
+
+		return PlanSummaryFormatJSON, nil
+	default:
+		return "", errors.New(InvalidPlanSummaryFormatError(terragruntOptions.PlanSummaryFormat))
+	}
+}
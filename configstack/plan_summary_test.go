@@ -0,0 +1,148 @@
+package configstack_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPlanTestModule returns a module whose JSON plan has the given resource changes, mapping addresses to actions.
+func newPlanTestModule(t *testing.T, opts *options.TerragruntOptions, name string, changes map[string][]string) *configstack.TerraformModule {
+	t.Helper()
+
+	type resourceChange struct {
+		Address string `json:"address"`
+		Mode    string `json:"mode"`
+		Change  struct {
+			Actions []string `json:"actions"`
+		} `json:"change"`
+	}
+
+	plan := struct {
+		ResourceChanges []resourceChange `json:"resource_changes"`
+	}{}
+
+	for address, actions := range changes {
+		change := resourceChange{Address: address, Mode: "managed"}
+		change.Change.Actions = actions
+		plan.ResourceChanges = append(plan.ResourceChanges, change)
+	}
+
+	content, err := json.Marshal(plan)
+	require.NoError(t, err)
+
+	return newRunnerTestModule(t, opts, name, func(_ context.Context, opts *options.TerragruntOptions) error {
+		if opts.TerraformCommand == tf.CommandNameShow {
+			_, err := opts.Writer.Write(content)
+			return err
+		}
+
+		return nil
+	})
+}
+
+func TestStackRunPlanSummary(t *testing.T) {
+	t.Parallel()
+
+	opts := newFailFastTestOptions(t)
+	opts.TerraformCommand = tf.CommandNamePlan
+	opts.TerraformCliArgs = []string{tf.CommandNamePlan}
+	opts.JSONOutputFolder = filepath.Join(opts.WorkingDir, "plans")
+	opts.PlanSummary = true
+	opts.PlanSummaryFile = "summary.json"
+
+	stdout := &bytes.Buffer{}
+	opts.Writer = stdout
+
+	app := newPlanTestModule(t, opts, "app", map[string][]string{
+		"aws_instance.web":       {"create"},
+		"aws_security_group.web": {"update"},
+		"aws_s3_bucket.logs":     {"no-op"},
+	})
+	db := newPlanTestModule(t, opts, "db", map[string][]string{
+		"aws_db_instance.main":    {"delete", "create"},
+		"aws_db_parameter_group":  {"delete"},
+		"aws_db_subnet_group.all": {"create"},
+	})
+	broken := newRunnerTestModule(t, opts, "broken", func(_ context.Context, _ *options.TerragruntOptions) error {
+		return errors.New("plan failed")
+	})
+
+	stack := configstack.NewStack(opts)
+	stack.Modules = configstack.TerraformModules{app, db, broken}
+
+	require.Error(t, stack.Run(context.Background(), opts))
+
+	content, err := os.ReadFile(filepath.Join(opts.WorkingDir, "summary.json"))
+	require.NoError(t, err)
+
+	var summary configstack.PlanSummary
+	require.NoError(t, json.Unmarshal(content, &summary))
+
+	assert.Equal(t, configstack.PlanChanges{Create: 2, Update: 1, Replace: 1, Destroy: 1}, summary.Total)
+	require.Len(t, summary.Units, 3)
+
+	assert.Equal(t, "app", summary.Units[0].Path)
+	assert.Equal(t, configstack.PlanChanges{Create: 1, Update: 1}, summary.Units[0].PlanChanges)
+	assert.Empty(t, summary.Units[0].Destroyed)
+
+	assert.Equal(t, "broken", summary.Units[1].Path)
+	assert.NotEmpty(t, summary.Units[1].Error)
+
+	assert.Equal(t, "db", summary.Units[2].Path)
+	assert.Equal(t, configstack.PlanChanges{Create: 1, Replace: 1, Destroy: 1}, summary.Units[2].PlanChanges)
+	assert.ElementsMatch(t, []string{"aws_db_instance.main", "aws_db_parameter_group"}, summary.Units[2].Destroyed)
+
+	assert.Contains(t, stdout.String(), "Plan summary:")
+	assert.Regexp(t, `!\s+db\s+1\s+0\s+1\s+1`, stdout.String())
+	assert.Regexp(t, `\s+app\s+1\s+1\s+0\s+0`, stdout.String())
+}
+
+func TestStackRunPlanSummaryMarkdown(t *testing.T) {
+	t.Parallel()
+
+	opts := newFailFastTestOptions(t)
+	opts.TerraformCommand = tf.CommandNamePlan
+	opts.TerraformCliArgs = []string{tf.CommandNamePlan}
+	opts.JSONOutputFolder = filepath.Join(opts.WorkingDir, "plans")
+	opts.PlanSummaryFile = "summary.md"
+
+	db := newPlanTestModule(t, opts, "db", map[string][]string{"aws_db_instance.main": {"delete"}})
+
+	stack := configstack.NewStack(opts)
+	stack.Modules = configstack.TerraformModules{db}
+
+	require.NoError(t, stack.Run(context.Background(), opts))
+
+	content, err := os.ReadFile(filepath.Join(opts.WorkingDir, "summary.md"))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(content), "| :warning: `db` | 0 | 0 | 0 | 1 |")
+	assert.Contains(t, string(content), fmt.Sprintf("- :warning: `db` destroys `%s`", "aws_db_instance.main"))
+}
+
+func TestStackRunPlanSummaryWithoutJSONOutDir(t *testing.T) {
+	t.Parallel()
+
+	opts := newFailFastTestOptions(t)
+	opts.TerraformCommand = tf.CommandNamePlan
+	opts.TerraformCliArgs = []string{tf.CommandNamePlan}
+	opts.PlanSummary = true
+
+	stack := configstack.NewStack(opts)
+	stack.Modules = configstack.TerraformModules{newPlanTestModule(t, opts, "db", nil)}
+
+	err := stack.Run(context.Background(), opts)
+	require.ErrorAs(t, err, &configstack.PlanSummaryWithoutJSONOutDirError{})
+}
@@ -0,0 +1,304 @@
+package configstack
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/policy"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	RunReportFormatJSON  = "json"
+	RunReportFormatJUnit = "junit"
+
+	runReportFilePerm = 0644
+
+	UnitSucceeded UnitRunStatus = "succeeded"
+	UnitFailed    UnitRunStatus = "failed"
+	UnitExcluded  UnitRunStatus = "excluded"
+	// UnitSkipped is the status of units that were not run because one of their dependencies failed.
+	UnitSkipped UnitRunStatus = "skipped"
+	// UnitCancelled is the status of units that were not run, or interrupted, by --fail-fast because another unit failed.
+	UnitCancelled UnitRunStatus = "cancelled"
+)
+
+// UnitRunStatus is the outcome of running a unit as part of a run of all units.
+type UnitRunStatus string
+
+// RunReport is a machine-readable summary of a run of all units of a stack.
+type RunReport struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+
+	Command string           `json:"command"`
+	Units   []*UnitRunReport `json:"units"`
+
+	DurationSeconds float64 `json:"duration_seconds"`
+
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	Excluded  int `json:"excluded"`
+	Skipped   int `json:"skipped"`
+	Cancelled int `json:"cancelled"`
+}
+
+// UnitRunReport is the outcome of running a single unit.
+type UnitRunReport struct {
+	StartTime *time.Time `json:"start_time,omitempty"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+
+	// ExitCode is the exit code of the run of the unit. It is not set for units that did not run.
+	ExitCode *int `json:"exit_code,omitempty"`
+
+	// DetailedExitCode is the exit code of `plan -detailed-exitcode`, if the unit was planned with it: 0 when there
+	// are no changes, 1 on errors and 2 when there are changes.
+	DetailedExitCode *int `json:"detailed_exit_code,omitempty"`
+
+	// Path is the path of the unit, relative to the working dir.
+	Path   string        `json:"path"`
+	Status UnitRunStatus `json:"status"`
+	Error  string        `json:"error,omitempty"`
+
+	// PolicyViolations are the violations of the policies of the unit by its plan, which blocked its apply.
+	PolicyViolations []policy.Violation `json:"policy_violations,omitempty"`
+
+	DurationSeconds float64 `json:"duration_seconds"`
+	Retries         int     `json:"retries"`
+
+	// HasChanges is true if the detailed exit code of the plan of the unit reports changes.
+	HasChanges bool `json:"has_changes"`
+
+	// Resumed is true if the unit was not run, because it succeeded in a previous attempt of the run.
+	Resumed bool `json:"resumed,omitempty"`
+}
+
+// newRunReport builds the report of the given run of the modules of the stack. Modules of the stack missing from
+// runningModules were excluded from the run.
+func newRunReport(terragruntOptions *options.TerragruntOptions, modules TerraformModules, runningModules RunningModules, startTime, endTime time.Time) *RunReport {
+	report := &RunReport{
+		StartTime:       startTime,
+		EndTime:         endTime,
+		DurationSeconds: endTime.Sub(startTime).Seconds(),
+		Command:         terragruntOptions.TerraformCommand,
+		Units:           make([]*UnitRunReport, 0, len(modules)),
+	}
+
+	detailedExitCode := util.ListContainsElement(terragruntOptions.TerraformCliArgs, tf.FlagNameDetailedExitCode)
+
+	for _, module := range modules {
+		unit := &UnitRunReport{Path: unitRelPath(terragruntOptions, module.Path), Status: UnitExcluded}
+
+		if runningModule, ok := runningModules[module.Path]; ok && !module.AssumeAlreadyApplied {
+			unit.fill(runningModule, detailedExitCode)
+		}
+
+		switch unit.Status {
+		case UnitSucceeded:
+			report.Succeeded++
+		case UnitFailed:
+			report.Failed++
+		case UnitExcluded:
+			report.Excluded++
+		case UnitSkipped:
+			report.Skipped++
+		case UnitCancelled:
+			report.Cancelled++
+		}
+
+		report.Units = append(report.Units, unit)
+	}
+
+	sort.Slice(report.Units, func(i, j int) bool {
+		return report.Units[i].Path < report.Units[j].Path
+	})
+
+	return report
+}
+
+func (unit *UnitRunReport) fill(module *RunningModule, detailedExitCode bool) {
+	unit.Status = module.runStatus()
+	unit.Resumed = module.Resumed
+
+	if module.Err != nil {
+		unit.Error = module.Err.Error()
+
+		// The errors of skipped units wrap the errors of their dependencies, only the failed unit violates policies.
+		var violationsErr policy.ViolationsError
+		if unit.Status == UnitFailed && errors.As(module.Err, &violationsErr) {
+			unit.PolicyViolations = violationsErr.Violations
+		}
+	}
+
+	if module.StartTime.IsZero() || module.Resumed {
+		return
+	}
+
+	startTime, endTime := module.StartTime, module.EndTime
+	unit.StartTime, unit.EndTime = &startTime, &endTime
+	unit.DurationSeconds = endTime.Sub(startTime).Seconds()
+	unit.Retries = module.Retries
+
+	exitCode := module.exitCode()
+	unit.ExitCode = &exitCode
+
+	if detailedExitCode {
+		code := module.DetailedExitCode
+		unit.DetailedExitCode = &code
+		unit.HasChanges = code == tf.DetailedExitCodeChanges
+	}
+}
+
+// runReportFormat returns the format of the run report, derived from the extension of the report file if not set.
+func runReportFormat(terragruntOptions *options.TerragruntOptions) (string, error) {
+	switch terragruntOptions.RunReportFormat {
+	case RunReportFormatJSON, RunReportFormatJUnit:
+		return terragruntOptions.RunReportFormat, nil
+	case "":
+		if strings.EqualFold(filepath.Ext(terragruntOptions.RunReportFile), ".xml") {
+			return RunReportFormatJUnit, nil
+		}
+
+		return RunReportFormatJSON, nil
+	default:
+		return "", errors.New(InvalidRunReportFormatError(terragruntOptions.RunReportFormat))
+	}
+}
+
+// Write writes the report to the given file, in the given format.
+func (report *RunReport) Write(path, format string) error {
+	var (
+		content []byte
+		err     error
+	)
+
+	switch format {
+	case RunReportFormatJUnit:
+		content, err = report.junit()
+	default:
+		content, err = json.MarshalIndent(report, "", "  ")
+	}
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return errors.New(err)
+	}
+
+	if err := os.WriteFile(path, append(content, '\n'), runReportFilePerm); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+}
+
+type junitTestCase struct {
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+// junit renders the report as JUnit XML, with a test case per unit.
+func (report *RunReport) junit() ([]byte, error) {
+	suite := junitTestSuite{
+		Name:      "terragrunt run --all " + report.Command,
+		Timestamp: report.StartTime.Format(time.RFC3339),
+		Time:      fmt.Sprintf("%.3f", report.DurationSeconds),
+		Tests:     len(report.Units),
+		Failures:  report.Failed,
+		Skipped:   report.Excluded + report.Skipped + report.Cancelled,
+	}
+
+	for _, unit := range report.Units {
+		testCase := junitTestCase{
+			Name:      unit.Path,
+			ClassName: report.Command,
+			Time:      fmt.Sprintf("%.3f", unit.DurationSeconds),
+		}
+
+		switch unit.Status {
+		case UnitFailed:
+			message := "unit failed"
+			if len(unit.PolicyViolations) > 0 {
+				message = "plan violates policies"
+			}
+
+			testCase.Failure = &junitMessage{Message: message, Content: unit.Error}
+		case UnitExcluded:
+			testCase.Skipped = &junitMessage{Message: "unit excluded"}
+		case UnitSkipped:
+			testCase.Skipped = &junitMessage{Message: "skipped due to dependency error", Content: unit.Error}
+		case UnitCancelled:
+			testCase.Skipped = &junitMessage{Message: "unit cancelled", Content: unit.Error}
+		case UnitSucceeded:
+		}
+
+		var details []string
+
+		if unit.ExitCode != nil {
+			details = append(details, fmt.Sprintf("exit_code=%d", *unit.ExitCode))
+		}
+
+		if unit.DetailedExitCode != nil {
+			details = append(details, fmt.Sprintf("detailed_exit_code=%d", *unit.DetailedExitCode))
+		}
+
+		if unit.Retries > 0 {
+			details = append(details, fmt.Sprintf("retries=%d", unit.Retries))
+		}
+
+		testCase.SystemOut = strings.Join(details, "\n")
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	content, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), content...), nil
+}
+
+// unitRelPath returns the path of the unit relative to the working dir.
+func unitRelPath(terragruntOptions *options.TerragruntOptions, unitPath string) string {
+	if relPath, err := filepath.Rel(terragruntOptions.WorkingDir, unitPath); err == nil {
+		return relPath
+	}
+
+	return unitPath
+}
@@ -0,0 +1,229 @@
+package configstack_test
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/policy"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createReportTestStack creates a stack with a unit that has changes and was retried, a failing unit, a unit
+// depending on the failing one and an excluded unit.
+func createReportTestStack(t *testing.T, workingDir string) (*configstack.Stack, *options.TerragruntOptions) {
+	t.Helper()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, config.DefaultTerragruntConfigPath))
+	require.NoError(t, err)
+
+	opts.WorkingDir = workingDir
+	opts.TerraformCommand = tf.CommandNamePlan
+	opts.TerraformCliArgs = []string{tf.CommandNamePlan, tf.FlagNameDetailedExitCode}
+
+	newModule := func(name string, runTerragrunt func(ctx context.Context) error) *configstack.TerraformModule {
+		moduleOpts, err := opts.CloneWithConfigPath(filepath.Join(workingDir, name, config.DefaultTerragruntConfigPath))
+		require.NoError(t, err)
+
+		moduleOpts.RunTerragrunt = func(ctx context.Context, _ *options.TerragruntOptions) error {
+			return runTerragrunt(ctx)
+		}
+
+		return &configstack.TerraformModule{
+			Stack:             &configstack.Stack{},
+			Path:              filepath.Join(workingDir, name),
+			Dependencies:      configstack.TerraformModules{},
+			TerragruntOptions: moduleOpts,
+		}
+	}
+
+	changed := newModule("changed", func(ctx context.Context) error {
+		tf.RetryCountFromContext(ctx).Inc()
+		tf.DetailedExitCodeFromContext(ctx).Set(tf.DetailedExitCodeChanges)
+
+		return nil
+	})
+	failed := newModule("failed", func(ctx context.Context) error {
+		return errors.New("plan failed")
+	})
+	dependent := newModule("dependent", func(ctx context.Context) error {
+		t.Error("the dependent unit must not run")
+		return nil
+	})
+	dependent.Dependencies = configstack.TerraformModules{failed}
+	excluded := newModule("excluded", func(ctx context.Context) error {
+		t.Error("the excluded unit must not run")
+		return nil
+	})
+	excluded.FlagExcluded = true
+
+	stack := configstack.NewStack(opts)
+	stack.Modules = configstack.TerraformModules{changed, failed, dependent, excluded}
+
+	return stack, opts
+}
+
+func TestStackRunReportJSON(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+	stack, opts := createReportTestStack(t, workingDir)
+	opts.RunReportFile = "report.json"
+
+	exitCode := &tf.DetailedExitCode{}
+	ctx := tf.ContextWithDetailedExitCode(context.Background(), exitCode)
+
+	require.Error(t, stack.Run(ctx, opts))
+	assert.Equal(t, tf.DetailedExitCodeChanges, exitCode.Get(), "the detailed exit codes of the units must still be reported to the caller")
+
+	content, err := os.ReadFile(filepath.Join(workingDir, "report.json"))
+	require.NoError(t, err)
+
+	var report configstack.RunReport
+	require.NoError(t, json.Unmarshal(content, &report))
+
+	assert.Equal(t, tf.CommandNamePlan, report.Command)
+	assert.Equal(t, 1, report.Succeeded)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Equal(t, 1, report.Excluded)
+	require.Len(t, report.Units, 4)
+
+	units := map[string]*configstack.UnitRunReport{}
+	for _, unit := range report.Units {
+		units[unit.Path] = unit
+	}
+
+	changed := units["changed"]
+	assert.Equal(t, configstack.UnitSucceeded, changed.Status)
+	assert.Equal(t, 1, changed.Retries)
+	require.NotNil(t, changed.ExitCode)
+	assert.Equal(t, 0, *changed.ExitCode)
+	require.NotNil(t, changed.DetailedExitCode)
+	assert.Equal(t, tf.DetailedExitCodeChanges, *changed.DetailedExitCode)
+	assert.True(t, changed.HasChanges)
+	assert.NotNil(t, changed.StartTime)
+
+	failed := units["failed"]
+	assert.Equal(t, configstack.UnitFailed, failed.Status)
+	assert.Contains(t, failed.Error, "plan failed")
+	require.NotNil(t, failed.ExitCode)
+	assert.Equal(t, 1, *failed.ExitCode)
+
+	dependent := units["dependent"]
+	assert.Equal(t, configstack.UnitSkipped, dependent.Status)
+	assert.Nil(t, dependent.ExitCode)
+	assert.Nil(t, dependent.StartTime)
+
+	assert.Equal(t, configstack.UnitExcluded, units["excluded"].Status)
+}
+
+func TestStackRunReportJUnit(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+	stack, opts := createReportTestStack(t, workingDir)
+	opts.RunReportFile = filepath.Join(workingDir, "reports", "report.xml")
+
+	require.Error(t, stack.Run(context.Background(), opts))
+
+	content, err := os.ReadFile(opts.RunReportFile)
+	require.NoError(t, err)
+
+	var suites struct {
+		Suites []struct {
+			TestCases []struct {
+				Failure *struct {
+					Message string `xml:"message,attr"`
+				} `xml:"failure"`
+				Skipped *struct {
+					Message string `xml:"message,attr"`
+				} `xml:"skipped"`
+				Name      string `xml:"name,attr"`
+				SystemOut string `xml:"system-out"`
+			} `xml:"testcase"`
+			Tests    int `xml:"tests,attr"`
+			Failures int `xml:"failures,attr"`
+			Skipped  int `xml:"skipped,attr"`
+		} `xml:"testsuite"`
+	}
+
+	require.NoError(t, xml.Unmarshal(content, &suites))
+	require.Len(t, suites.Suites, 1)
+
+	suite := suites.Suites[0]
+	assert.Equal(t, 4, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+	assert.Equal(t, 2, suite.Skipped)
+
+	require.Len(t, suite.TestCases, 4)
+	assert.Equal(t, "changed", suite.TestCases[0].Name)
+	assert.Contains(t, suite.TestCases[0].SystemOut, "detailed_exit_code=2")
+	assert.Equal(t, "dependent", suite.TestCases[1].Name)
+	require.NotNil(t, suite.TestCases[1].Skipped)
+	assert.Equal(t, "skipped due to dependency error", suite.TestCases[1].Skipped.Message)
+	assert.Equal(t, "excluded", suite.TestCases[2].Name)
+	require.NotNil(t, suite.TestCases[2].Skipped)
+	assert.Equal(t, "failed", suite.TestCases[3].Name)
+	require.NotNil(t, suite.TestCases[3].Failure)
+}
+
+func TestStackRunReportInvalidFormat(t *testing.T) {
+	t.Parallel()
+
+	stack, opts := createReportTestStack(t, t.TempDir())
+	opts.RunReportFile = "report.txt"
+	opts.RunReportFormat = "text"
+
+	err := stack.Run(context.Background(), opts)
+
+	var formatErr configstack.InvalidRunReportFormatError
+	require.True(t, errors.As(err, &formatErr), "unexpected error: %v", err)
+}
+
+func TestStackRunReportPolicyViolations(t *testing.T) {
+	t.Parallel()
+
+	opts := newFailFastTestOptions(t)
+	opts.RunReportFile = "report.json"
+
+	violation := policy.Violation{Policy: policy.DenyDestroyTypesName, Address: "aws_db_instance.main", Message: "must not be destroyed"}
+
+	db := newRunnerTestModule(t, opts, "db", func(_ context.Context, _ *options.TerragruntOptions) error {
+		return errors.New(policy.ViolationsError{Violations: []policy.Violation{violation}})
+	})
+	app := newRunnerTestModule(t, opts, "app", func(_ context.Context, _ *options.TerragruntOptions) error {
+		t.Error("the units depending on a unit violating policies must not be applied")
+		return nil
+	})
+	app.Dependencies = configstack.TerraformModules{db}
+
+	stack := configstack.NewStack(opts)
+	stack.Modules = configstack.TerraformModules{app, db}
+
+	require.Error(t, stack.Run(context.Background(), opts))
+
+	content, err := os.ReadFile(filepath.Join(opts.WorkingDir, "report.json"))
+	require.NoError(t, err)
+
+	var report configstack.RunReport
+	require.NoError(t, json.Unmarshal(content, &report))
+	require.Len(t, report.Units, 2)
+
+	assert.Equal(t, "app", report.Units[0].Path)
+	assert.Equal(t, configstack.UnitSkipped, report.Units[0].Status)
+	assert.Empty(t, report.Units[0].PolicyViolations)
+
+	assert.Equal(t, "db", report.Units[1].Path)
+	assert.Equal(t, configstack.UnitFailed, report.Units[1].Status)
+	assert.Equal(t, []policy.Violation{violation}, report.Units[1].PolicyViolations)
+}
@@ -0,0 +1,300 @@
+package configstack
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	runStateDirName  = "run-state"
+	runStateFilePerm = 0600
+
+	// defaultRunIDLength is the number of hex characters of the hash used as the default run ID.
+	defaultRunIDLength = 16
+)
+
+// RunState is the per-unit completion state of a run of all units, persisted after every unit finishes, so that a
+// failed run can be resumed with --resume.
+type RunState struct {
+	// Units maps the path of each unit, relative to the working dir, to its status in the run.
+	Units map[string]UnitRunStatus `json:"units"`
+	// Hashes maps the path of each unit, relative to the working dir, to the hash of its code and configuration when
+	// it was run, so that units changed since they succeeded are run again when resuming.
+	Hashes map[string]string `json:"hashes"`
+
+	RunID      string   `json:"run_id"`
+	WorkingDir string   `json:"working_dir"`
+	Args       []string `json:"args"`
+
+	path string
+	mu   sync.Mutex
+}
+
+// RunID returns the ID of the run of all units, which is either set explicitly with --run-id, or derived from the
+// working dir and the OpenTofu/Terraform command, so that re-running the same command resumes the same run.
+func RunID(terragruntOptions *options.TerragruntOptions) string {
+	if terragruntOptions.RunID != "" {
+		return terragruntOptions.RunID
+	}
+
+	hash := sha256.Sum256([]byte(strings.Join(append([]string{terragruntOptions.WorkingDir}, terragruntOptions.TerraformCliArgs...), "\x00")))
+
+	return hex.EncodeToString(hash[:])[:defaultRunIDLength]
+}
+
+// getRunStateDir returns the directory the run states are persisted in.
+func getRunStateDir(terragruntOptions *options.TerragruntOptions) (string, error) {
+	if terragruntOptions.RunStateDir != "" {
+		return terragruntOptions.RunStateDir, nil
+	}
+
+	cacheDir, err := util.GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(cacheDir, runStateDirName), nil
+}
+
+// newRunState returns the state of the run of all units. With --resume, the state persisted by the previous attempt
+// of the run is loaded, otherwise the run starts from scratch.
+func newRunState(terragruntOptions *options.TerragruntOptions) (*RunState, error) {
+	runStateDir, err := getRunStateDir(terragruntOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &RunState{
+		Units:      map[string]UnitRunStatus{},
+		Hashes:     map[string]string{},
+		RunID:      RunID(terragruntOptions),
+		WorkingDir: terragruntOptions.WorkingDir,
+		Args:       terragruntOptions.TerraformCliArgs,
+	}
+	state.path = filepath.Join(runStateDir, state.RunID+".json")
+
+	if !terragruntOptions.Resume {
+		return state, nil
+	}
+
+	content, err := os.ReadFile(state.path)
+	if os.IsNotExist(err) {
+		terragruntOptions.Logger.Infof("No previous attempt of run %s to resume, running every unit", state.RunID)
+		return state, nil
+	} else if err != nil {
+		return nil, errors.New(err)
+	}
+
+	var previous RunState
+	if err := json.Unmarshal(content, &previous); err != nil {
+		return nil, errors.New(err)
+	}
+
+	if previous.WorkingDir != state.WorkingDir || !slices.Equal(previous.Args, state.Args) {
+		return nil, errors.New(RunStateMismatchError{RunID: state.RunID, WorkingDir: previous.WorkingDir, Args: previous.Args})
+	}
+
+	if previous.Units != nil {
+		state.Units = previous.Units
+	}
+
+	if previous.Hashes != nil {
+		state.Hashes = previous.Hashes
+	}
+
+	return state, nil
+}
+
+// Status returns the status of the unit with the given path in the previous attempts of the run.
+func (state *RunState) Status(unitPath string) UnitRunStatus {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	return state.Units[unitPath]
+}
+
+// Succeeded returns true if the unit with the given path succeeded in a previous attempt of the run, with the code
+// and configuration of the given hash.
+func (state *RunState) Succeeded(unitPath, hash string) bool {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	return state.Units[unitPath] == UnitSucceeded && hash != "" && state.Hashes[unitPath] == hash
+}
+
+// Record records the status of the unit with the given path, run with the code and configuration of the given hash,
+// and persists the state.
+func (state *RunState) Record(unitPath, hash string, status UnitRunStatus) error {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	state.Units[unitPath] = status
+	state.Hashes[unitPath] = hash
+
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(state.path), os.ModePerm); err != nil {
+		return errors.New(err)
+	}
+
+	// Write to a temporary file first, so that the state is never left partially written if Terragrunt is interrupted.
+	tmpFile, err := os.CreateTemp(filepath.Dir(state.path), filepath.Base(state.path)+".*")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if _, err := tmpFile.Write(content); err != nil {
+		tmpFile.Close()           //nolint:errcheck
+		os.Remove(tmpFile.Name()) //nolint:errcheck
+
+		return errors.New(err)
+	}
+
+	if err := tmpFile.Chmod(runStateFilePerm); err != nil {
+		tmpFile.Close()           //nolint:errcheck
+		os.Remove(tmpFile.Name()) //nolint:errcheck
+
+		return errors.New(err)
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpFile.Name()) //nolint:errcheck
+
+		return errors.New(err)
+	}
+
+	if err := os.Rename(tmpFile.Name(), state.path); err != nil {
+		os.Remove(tmpFile.Name()) //nolint:errcheck
+
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// Remove removes the persisted state, once every unit of the run succeeded.
+func (state *RunState) Remove() error {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if err := os.Remove(state.path); err != nil && !os.IsNotExist(err) {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// unitHashFileExtensions are the extensions of the files of the code and configuration of a unit.
+var unitHashFileExtensions = []string{".hcl", ".tf", ".tofu", ".json", ".tfvars"}
+
+// hashUnit returns the hash of the code and configuration of the given unit: its configuration files, the files it
+// includes, and its OpenTofu/Terraform code, either in the unit dir or in its local source. Remote sources are hashed by
+// their URL, which usually pins a version.
+func hashUnit(module *TerraformModule) (string, error) {
+	hash := sha256.New()
+
+	var generatedFiles []string
+
+	for _, generateConfig := range module.Config.GenerateConfigs {
+		generatedFiles = append(generatedFiles, filepath.Join(module.Path, generateConfig.Path))
+	}
+
+	if module.Config.RemoteState != nil && module.Config.RemoteState.Generate != nil {
+		generatedFiles = append(generatedFiles, filepath.Join(module.Path, module.Config.RemoteState.Generate.Path))
+	}
+
+	if err := hashUnitDir(hash, module.Path, generatedFiles); err != nil {
+		return "", err
+	}
+
+	includePaths := make([]string, 0, len(module.Config.ProcessedIncludes))
+
+	for _, include := range module.Config.ProcessedIncludes {
+		includePath := include.Path
+		if !filepath.IsAbs(includePath) {
+			includePath = filepath.Join(module.Path, includePath)
+		}
+
+		includePaths = append(includePaths, filepath.Clean(includePath))
+	}
+
+	slices.Sort(includePaths)
+
+	for _, includePath := range includePaths {
+		if err := hashUnitFile(hash, includePath); err != nil {
+			return "", err
+		}
+	}
+
+	if module.Config.Terraform != nil && module.Config.Terraform.Source != nil {
+		source := *module.Config.Terraform.Source
+
+		sourceURL, err := tf.ToSourceURL(source, module.Path)
+		if err != nil {
+			return "", err
+		}
+
+		if tf.IsLocalSource(sourceURL) {
+			if err := hashUnitDir(hash, filepath.Clean(strings.ReplaceAll(sourceURL.Path, "//", "/")), nil); err != nil {
+				return "", err
+			}
+		} else {
+			hash.Write([]byte(source + "\x00")) //nolint:errcheck
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// hashUnitDir hashes the code and configuration files in the given dir and its subdirs, except for the given generated
+// files and the hidden files and dirs, such as the Terragrunt cache, the OpenTofu/Terraform data dir and lock file.
+func hashUnitDir(hash io.Writer, dir string, generatedFiles []string) error {
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path != dir && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if entry.IsDir() || !slices.Contains(unitHashFileExtensions, filepath.Ext(path)) || slices.Contains(generatedFiles, path) {
+			return nil
+		}
+
+		return hashUnitFile(hash, path)
+	})
+}
+
+// hashUnitFile hashes the path and the content of the given file.
+func hashUnitFile(hash io.Writer, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	hash.Write([]byte(path + "\x00")) //nolint:errcheck
+	hash.Write(content)               //nolint:errcheck
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package configstack_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createResumeTestStack creates a stack with the units "a", "b" and "c", where "c" depends on "b". The number of runs
+// of each unit is counted in runs, and "b" fails as long as failB is set.
+func createResumeTestStack(t *testing.T, opts *options.TerragruntOptions, runs map[string]*atomic.Int32, failB *atomic.Bool) *configstack.Stack {
+	t.Helper()
+
+	modules := map[string]*configstack.TerraformModule{}
+
+	for _, name := range []string{"a", "b", "c"} {
+		unitDir := filepath.Join(opts.WorkingDir, name)
+		require.NoError(t, os.MkdirAll(unitDir, os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(unitDir, config.DefaultTerragruntConfigPath), nil, 0644))
+
+		if _, err := os.Stat(filepath.Join(unitDir, "main.tf")); os.IsNotExist(err) {
+			require.NoError(t, os.WriteFile(filepath.Join(unitDir, "main.tf"), nil, 0644))
+		}
+
+		moduleOpts, err := opts.CloneWithConfigPath(filepath.Join(opts.WorkingDir, name, config.DefaultTerragruntConfigPath))
+		require.NoError(t, err)
+
+		moduleOpts.RunTerragrunt = func(_ context.Context, _ *options.TerragruntOptions) error {
+			runs[name].Add(1)
+
+			if name == "b" && failB.Load() {
+				return errors.New("apply failed")
+			}
+
+			return nil
+		}
+
+		modules[name] = &configstack.TerraformModule{
+			Stack:             &configstack.Stack{},
+			Path:              filepath.Join(opts.WorkingDir, name),
+			Dependencies:      configstack.TerraformModules{},
+			TerragruntOptions: moduleOpts,
+		}
+	}
+
+	modules["c"].Dependencies = configstack.TerraformModules{modules["b"]}
+
+	stack := configstack.NewStack(opts, configstack.WithRunState())
+	stack.Modules = configstack.TerraformModules{modules["a"], modules["b"], modules["c"]}
+
+	return stack
+}
+
+func newResumeTestOptions(t *testing.T, workingDir, runStateDir string) *options.TerragruntOptions {
+	t.Helper()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, config.DefaultTerragruntConfigPath))
+	require.NoError(t, err)
+
+	opts.WorkingDir = workingDir
+	opts.RunStateDir = runStateDir
+	opts.TerraformCommand = tf.CommandNameApply
+	opts.TerraformCliArgs = []string{tf.CommandNameApply}
+
+	return opts
+}
+
+func TestStackRunResume(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+	runStateDir := t.TempDir()
+
+	runs := map[string]*atomic.Int32{"a": {}, "b": {}, "c": {}}
+	failB := &atomic.Bool{}
+	failB.Store(true)
+
+	opts := newResumeTestOptions(t, workingDir, runStateDir)
+	require.Error(t, createResumeTestStack(t, opts, runs, failB).Run(context.Background(), opts))
+
+	assert.Equal(t, int32(1), runs["a"].Load())
+	assert.Equal(t, int32(1), runs["b"].Load())
+	assert.Equal(t, int32(0), runs["c"].Load())
+
+	statePath := filepath.Join(runStateDir, configstack.RunID(opts)+".json")
+
+	content, err := os.ReadFile(statePath)
+	require.NoError(t, err)
+
+	var state configstack.RunState
+	require.NoError(t, json.Unmarshal(content, &state))
+	assert.Equal(t, map[string]configstack.UnitRunStatus{
+		"a": configstack.UnitSucceeded,
+		"b": configstack.UnitFailed,
+		"c": configstack.UnitSkipped,
+	}, state.Units)
+
+	// Resuming only runs the unit that failed and the unit that did not run.
+	failB.Store(false)
+
+	opts = newResumeTestOptions(t, workingDir, runStateDir)
+	opts.Resume = true
+	require.NoError(t, createResumeTestStack(t, opts, runs, failB).Run(context.Background(), opts))
+
+	assert.Equal(t, int32(1), runs["a"].Load())
+	assert.Equal(t, int32(2), runs["b"].Load())
+	assert.Equal(t, int32(1), runs["c"].Load())
+
+	// Once every unit succeeded, the state is removed, so that resuming runs every unit again.
+	assert.NoFileExists(t, statePath)
+
+	opts = newResumeTestOptions(t, workingDir, runStateDir)
+	opts.Resume = true
+	require.NoError(t, createResumeTestStack(t, opts, runs, failB).Run(context.Background(), opts))
+
+	assert.Equal(t, int32(2), runs["a"].Load())
+}
+
+func TestStackRunResumeMismatch(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+	runStateDir := t.TempDir()
+
+	runs := map[string]*atomic.Int32{"a": {}, "b": {}, "c": {}}
+	failB := &atomic.Bool{}
+	failB.Store(true)
+
+	opts := newResumeTestOptions(t, workingDir, runStateDir)
+	opts.RunID = "rollout"
+	require.Error(t, createResumeTestStack(t, opts, runs, failB).Run(context.Background(), opts))
+
+	opts = newResumeTestOptions(t, workingDir, runStateDir)
+	opts.RunID = "rollout"
+	opts.Resume = true
+	opts.TerraformCommand = tf.CommandNameDestroy
+	opts.TerraformCliArgs = []string{tf.CommandNameDestroy}
+
+	err := createResumeTestStack(t, opts, runs, failB).Run(context.Background(), opts)
+
+	var mismatchErr configstack.RunStateMismatchError
+	require.True(t, errors.As(err, &mismatchErr), "unexpected error: %v", err)
+	assert.Equal(t, int32(1), runs["a"].Load())
+}
+
+func TestStackRunResumeChangedUnit(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+	runStateDir := t.TempDir()
+
+	runs := map[string]*atomic.Int32{"a": {}, "b": {}, "c": {}}
+	failB := &atomic.Bool{}
+	failB.Store(true)
+
+	opts := newResumeTestOptions(t, workingDir, runStateDir)
+	require.Error(t, createResumeTestStack(t, opts, runs, failB).Run(context.Background(), opts))
+
+	statePath := filepath.Join(runStateDir, configstack.RunID(opts)+".json")
+
+	content, err := os.ReadFile(statePath)
+	require.NoError(t, err)
+
+	var state configstack.RunState
+	require.NoError(t, json.Unmarshal(content, &state))
+	assert.Len(t, state.Hashes, 3)
+	assert.NotEmpty(t, state.Hashes["a"])
+
+	// The state is written atomically, through a temporary file that is renamed.
+	entries, err := os.ReadDir(runStateDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	// The code of "a" changed since it succeeded, so it is run again when resuming.
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, "a", "main.tf"), []byte(`output "changed" { value = true }`), 0644))
+
+	failB.Store(false)
+
+	opts = newResumeTestOptions(t, workingDir, runStateDir)
+	opts.Resume = true
+	require.NoError(t, createResumeTestStack(t, opts, runs, failB).Run(context.Background(), opts))
+
+	assert.Equal(t, int32(2), runs["a"].Load())
+	assert.Equal(t, int32(2), runs["b"].Load())
+	assert.Equal(t, int32(1), runs["c"].Load())
+}
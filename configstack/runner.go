@@ -0,0 +1,263 @@
+package configstack
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+const (
+	// UnitQueued is the event of a unit added to the run queue.
+	UnitQueued UnitEventType = "queued"
+	// UnitWaiting is the event of a unit waiting for its dependencies to finish.
+	UnitWaiting UnitEventType = "waiting"
+	// UnitStarted is the event of a unit that started running.
+	UnitStarted UnitEventType = "started"
+	// UnitRetrying is the event of a unit retrying OpenTofu/Terraform after a retryable error.
+	UnitRetrying UnitEventType = "retrying"
+	// UnitOutput is the event of a chunk of the output of a running unit.
+	UnitOutput UnitEventType = "output"
+	// UnitFinished is the event of a unit that finished, whether it ran or not.
+	UnitFinished UnitEventType = "finished"
+
+	// OutputStdout is the stream of the standard output of a unit.
+	OutputStdout OutputStream = "stdout"
+	// OutputStderr is the stream of the standard error of a unit.
+	OutputStderr OutputStream = "stderr"
+)
+
+// UnitEventType is the type of an event of a unit in a run of all units.
+type UnitEventType string
+
+// OutputStream is the stream of a chunk of the output of a unit.
+type OutputStream string
+
+// UnitEvent is an event of a unit in a run of all units.
+type UnitEvent struct {
+	Time time.Time
+	// Err is the error the unit finished with, for UnitFinished events.
+	Err  error
+	Type UnitEventType
+	// Path is the absolute path of the unit.
+	Path string
+	// Status is the outcome of the unit, for UnitFinished events.
+	Status UnitRunStatus
+	// Stream is the stream of the Output, for UnitOutput events.
+	Stream OutputStream
+	// Output is the chunk of output, for UnitOutput events.
+	Output string
+	// WaitingFor are the absolute paths of the units the unit waits for, for UnitWaiting events.
+	WaitingFor []string
+	// Attempt is the number of the attempt about to start, for UnitRetrying events, the first attempt being 1.
+	Attempt int
+	// ExitCode is the exit code the unit finished with, for UnitFinished events.
+	ExitCode int
+}
+
+// Runner runs a command in all the units of a stack, in the order of their dependencies. It is how the CLI and stacks
+// run all units, and how programs embedding Terragrunt can run them without shelling out. The units are run by the
+// modules of the Stack, in the same way as Stack.Run:
+//
+//	runner, err := configstack.NewRunner(ctx, opts)
+//	if err != nil {
+//		return err
+//	}
+//
+//	events := runner.Events()
+//
+//	if err := runner.Start(ctx); err != nil {
+//		return err
+//	}
+//
+//	for event := range events {
+//		fmt.Println(event.Path, event.Type, event.Status)
+//	}
+//
+//	return runner.Wait()
+type Runner interface {
+	// Start starts running the units in the background. It returns an error if the run was already started.
+	Start(ctx context.Context) error
+	// Events returns the events of the units, in the order they happen. The channel is closed once the run finished.
+	// The events are only recorded if Events is called before Start. The run never waits for the events to be read,
+	// they are buffered until then.
+	Events() <-chan UnitEvent
+	// Wait blocks until the run finished, and returns the error of the run.
+	Wait() error
+	// Cancel cancels the run: the running units are interrupted, and the units that did not start yet are cancelled.
+	Cancel()
+}
+
+// ErrRunCancelled is the cause of the cancellation of a run cancelled with Runner.Cancel.
+var ErrRunCancelled = errors.New("run cancelled")
+
+// NewRunner finds all the units in the subfolders of the working directory of the given TerragruntOptions, and returns
+// the runner of the command of the options in these units.
+func NewRunner(ctx context.Context, terragruntOptions *options.TerragruntOptions, opts ...Option) (Runner, error) {
+	stack, err := FindStackInSubfolders(ctx, terragruntOptions, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return stack.Runner(terragruntOptions), nil
+}
+
+// stackRunner is the Runner of the units of a Stack.
+type stackRunner struct {
+	err               error
+	stack             *Stack
+	terragruntOptions *options.TerragruntOptions
+	// events is the channel of the events, or nil if the events are not recorded.
+	events  chan UnitEvent
+	done    chan struct{}
+	cancel  context.CancelCauseFunc
+	mu      sync.Mutex
+	started bool
+}
+
+// Runner returns the runner of the command of the given options in the units of the stack.
+func (stack *Stack) Runner(terragruntOptions *options.TerragruntOptions) Runner {
+	return &stackRunner{
+		stack:             stack,
+		terragruntOptions: terragruntOptions,
+		done:              make(chan struct{}),
+	}
+}
+
+func (runner *stackRunner) Start(ctx context.Context) error {
+	runner.mu.Lock()
+	defer runner.mu.Unlock()
+
+	if runner.started {
+		return errors.New("the run was already started")
+	}
+
+	runner.started = true
+
+	ctx, runner.cancel = context.WithCancelCause(ctx)
+
+	var events *eventQueue
+
+	if runner.events != nil {
+		events = newEventQueue()
+		go events.forward(runner.events)
+	}
+
+	go func() {
+		defer close(runner.done)
+		defer runner.cancel(nil)
+
+		if events == nil {
+			runner.err = runner.stack.run(ctx, runner.terragruntOptions, nil)
+			return
+		}
+
+		defer events.close()
+
+		runner.err = runner.stack.run(ctx, runner.terragruntOptions, events.push)
+	}()
+
+	return nil
+}
+
+func (runner *stackRunner) Events() <-chan UnitEvent {
+	runner.mu.Lock()
+	defer runner.mu.Unlock()
+
+	if runner.events == nil {
+		runner.events = make(chan UnitEvent)
+
+		if runner.started {
+			// The events of a run started without recording them are never sent.
+			close(runner.events)
+		}
+	}
+
+	return runner.events
+}
+
+func (runner *stackRunner) Wait() error {
+	runner.mu.Lock()
+	started := runner.started
+	runner.mu.Unlock()
+
+	if !started {
+		return errors.New("the run was not started")
+	}
+
+	<-runner.done
+
+	return runner.err
+}
+
+func (runner *stackRunner) Cancel() {
+	runner.mu.Lock()
+	defer runner.mu.Unlock()
+
+	if runner.cancel != nil {
+		runner.cancel(ErrRunCancelled)
+	}
+}
+
+// eventQueue buffers the events of a run without bound, so that recording them never blocks the run.
+type eventQueue struct {
+	cond   *sync.Cond
+	events []UnitEvent
+	mu     sync.Mutex
+	closed bool
+}
+
+func newEventQueue() *eventQueue {
+	queue := &eventQueue{}
+	queue.cond = sync.NewCond(&queue.mu)
+
+	return queue
+}
+
+func (queue *eventQueue) push(event UnitEvent) {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+
+	queue.events = append(queue.events, event)
+	queue.cond.Signal()
+}
+
+func (queue *eventQueue) close() {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+
+	queue.closed = true
+	queue.cond.Signal()
+}
+
+// forward sends the events to the given channel, and closes it once the queue is closed and all events were sent.
+func (queue *eventQueue) forward(events chan<- UnitEvent) {
+	defer close(events)
+
+	for {
+		queue.mu.Lock()
+
+		for len(queue.events) == 0 && !queue.closed {
+			queue.cond.Wait()
+		}
+
+		if len(queue.events) == 0 {
+			queue.mu.Unlock()
+			return
+		}
+
+		event := queue.events[0]
+		queue.events = queue.events[1:]
+
+		queue.mu.Unlock()
+
+		events <- event
+	}
+}
+
+// runCancelled returns true if the run of the given context was cancelled with Runner.Cancel.
+func runCancelled(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ErrRunCancelled)
+}
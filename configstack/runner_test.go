@@ -0,0 +1,186 @@
+package configstack_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRunnerTestModule(t *testing.T, opts *options.TerragruntOptions, name string, runTerragrunt func(ctx context.Context, opts *options.TerragruntOptions) error) *configstack.TerraformModule {
+	t.Helper()
+
+	moduleOpts, err := opts.CloneWithConfigPath(filepath.Join(opts.WorkingDir, name, config.DefaultTerragruntConfigPath))
+	require.NoError(t, err)
+
+	moduleOpts.RunTerragrunt = runTerragrunt
+
+	return &configstack.TerraformModule{
+		Stack:             &configstack.Stack{},
+		Path:              filepath.Join(opts.WorkingDir, name),
+		Dependencies:      configstack.TerraformModules{},
+		TerragruntOptions: moduleOpts,
+	}
+}
+
+func TestRunnerEvents(t *testing.T) {
+	t.Parallel()
+
+	opts := newFailFastTestOptions(t)
+
+	// The units are discovered, so that they are queued in the order of the run queue: "app" depends on "vpc".
+	for unit, content := range map[string]string{"vpc": "", "app": `dependencies { paths = ["../vpc"] }`} {
+		require.NoError(t, os.MkdirAll(filepath.Join(opts.WorkingDir, unit), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(opts.WorkingDir, unit, config.DefaultTerragruntConfigPath), []byte(content), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(opts.WorkingDir, unit, "main.tf"), nil, 0644))
+	}
+
+	stack, err := configstack.FindStackInSubfolders(context.Background(), opts)
+	require.NoError(t, err)
+
+	for _, module := range stack.Modules {
+		switch filepath.Base(module.Path) {
+		case "vpc":
+			module.TerragruntOptions.RunTerragrunt = func(_ context.Context, opts *options.TerragruntOptions) error {
+				_, err := fmt.Fprintln(opts.Writer, "vpc created")
+				return err
+			}
+		case "app":
+			module.TerragruntOptions.RunTerragrunt = func(ctx context.Context, opts *options.TerragruntOptions) error {
+				tf.RetryCountFromContext(ctx).Inc()
+
+				_, err := fmt.Fprintln(opts.ErrWriter, "app failed")
+				assert.NoError(t, err)
+
+				return cli.NewExitError("app failed", 3)
+			}
+		}
+	}
+
+	runner := stack.Runner(opts)
+	events := runner.Events()
+
+	require.NoError(t, runner.Start(context.Background()))
+	require.Error(t, runner.Start(context.Background()))
+
+	type event struct {
+		Type       configstack.UnitEventType
+		Status     configstack.UnitRunStatus
+		Stream     configstack.OutputStream
+		Output     string
+		WaitingFor []string
+		Attempt    int
+		ExitCode   int
+	}
+
+	queued := []string{}
+	unitEvents := map[string][]event{}
+
+	for unitEvent := range events {
+		unit := filepath.Base(unitEvent.Path)
+
+		if unitEvent.Type == configstack.UnitQueued {
+			queued = append(queued, unit)
+			continue
+		}
+
+		var waitingFor []string
+		for _, path := range unitEvent.WaitingFor {
+			waitingFor = append(waitingFor, filepath.Base(path))
+		}
+
+		unitEvents[unit] = append(unitEvents[unit], event{
+			Type:       unitEvent.Type,
+			Status:     unitEvent.Status,
+			Stream:     unitEvent.Stream,
+			Output:     unitEvent.Output,
+			WaitingFor: waitingFor,
+			Attempt:    unitEvent.Attempt,
+			ExitCode:   unitEvent.ExitCode,
+		})
+	}
+
+	require.Error(t, runner.Wait())
+	assert.Equal(t, []string{"vpc", "app"}, queued)
+	assert.Equal(t, map[string][]event{
+		"vpc": {
+			{Type: configstack.UnitStarted},
+			{Type: configstack.UnitOutput, Stream: configstack.OutputStdout, Output: "vpc created\n"},
+			{Type: configstack.UnitFinished, Status: configstack.UnitSucceeded},
+		},
+		"app": {
+			{Type: configstack.UnitWaiting, WaitingFor: []string{"vpc"}},
+			{Type: configstack.UnitStarted},
+			{Type: configstack.UnitRetrying, Attempt: 2},
+			{Type: configstack.UnitOutput, Stream: configstack.OutputStderr, Output: "app failed\n"},
+			{Type: configstack.UnitFinished, Status: configstack.UnitFailed, ExitCode: 3},
+		},
+	}, unitEvents)
+}
+
+func TestRunnerEventsNotRecorded(t *testing.T) {
+	t.Parallel()
+
+	opts := newFailFastTestOptions(t)
+
+	vpc := newRunnerTestModule(t, opts, "vpc", func(_ context.Context, _ *options.TerragruntOptions) error { return nil })
+
+	stack := configstack.NewStack(opts)
+	stack.Modules = configstack.TerraformModules{vpc}
+
+	runner := stack.Runner(opts)
+	require.NoError(t, runner.Start(context.Background()))
+	require.NoError(t, runner.Wait())
+
+	// The events of a run started before asking for them are not recorded.
+	_, ok := <-runner.Events()
+	assert.False(t, ok)
+}
+
+func TestRunnerCancel(t *testing.T) {
+	t.Parallel()
+
+	opts := newFailFastTestOptions(t)
+
+	slow := newRunnerTestModule(t, opts, "slow", func(ctx context.Context, _ *options.TerragruntOptions) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	dependent := newRunnerTestModule(t, opts, "dependent", func(_ context.Context, _ *options.TerragruntOptions) error { return nil })
+	dependent.Dependencies = configstack.TerraformModules{slow}
+
+	stack := configstack.NewStack(opts)
+	stack.Modules = configstack.TerraformModules{slow, dependent}
+
+	runner := stack.Runner(opts)
+	events := runner.Events()
+
+	require.NoError(t, runner.Start(context.Background()))
+
+	statuses := map[string]configstack.UnitRunStatus{}
+
+	for event := range events {
+		if event.Type == configstack.UnitStarted && filepath.Base(event.Path) == "slow" {
+			runner.Cancel()
+		}
+
+		if event.Type == configstack.UnitFinished {
+			statuses[filepath.Base(event.Path)] = event.Status
+		}
+	}
+
+	require.ErrorIs(t, runner.Wait(), context.Canceled)
+	assert.Equal(t, map[string]configstack.UnitRunStatus{
+		"slow":      configstack.UnitCancelled,
+		"dependent": configstack.UnitCancelled,
+	}, statuses)
+}
@@ -3,15 +3,18 @@ package configstack
 import (
 	"bytes"
 	"context"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/telemetry"
 	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
 )
 
 const (
@@ -37,13 +40,27 @@ type DependencyOrder int
 // RunningModule represents a module we are trying to "run" (i.e. apply or destroy)
 // as part of the apply-all or destroy-all command.
 type RunningModule struct {
+	StartTime      time.Time
+	EndTime        time.Time
 	Err            error
 	Module         *TerraformModule
 	DependencyDone chan *RunningModule
 	Dependencies   map[string]*RunningModule
 	NotifyWhenDone []*RunningModule
 	Status         ModuleStatus
-	FlagExcluded   bool
+	// Retries is the number of times OpenTofu/Terraform was retried after failing with a retryable error.
+	Retries int
+	// DetailedExitCode is the exit code of `plan -detailed-exitcode`, if the module was planned with it.
+	DetailedExitCode int
+	FlagExcluded     bool
+	// Resumed is true if the module succeeded in a previous attempt of the run, so that it is not run again.
+	Resumed bool
+	// onFinished is called once the module has finished, before its dependents are notified.
+	onFinished func(module *RunningModule)
+	// onEvent is called with the events of the module, if not nil.
+	onEvent func(event UnitEvent)
+	// priority orders the modules ready to run, the modules with the highest priority run first.
+	priority float64
 }
 
 // Create a new RunningModule struct for the given module. This will initialize all fields to reasonable defaults,
@@ -61,7 +78,18 @@ func newRunningModule(module *TerraformModule) *RunningModule {
 }
 
 // Run a module once all of its dependencies have finished executing.
-func (module *RunningModule) runModuleWhenReady(ctx context.Context, opts *options.TerragruntOptions, semaphore chan struct{}) {
+func (module *RunningModule) runModuleWhenReady(ctx context.Context, opts *options.TerragruntOptions, scheduler *scheduler, failFast *failFastState) {
+	if len(module.Dependencies) > 0 {
+		waitingFor := make([]string, 0, len(module.Dependencies))
+		for path := range module.Dependencies {
+			waitingFor = append(waitingFor, path)
+		}
+
+		sort.Strings(waitingFor)
+
+		module.notify(UnitEvent{Type: UnitWaiting, Path: module.Module.Path, Time: time.Now(), WaitingFor: waitingFor})
+	}
+
 	err := telemetry.Telemetry(ctx, opts, "wait_for_module_ready", map[string]any{
 		"path":             module.Module.Path,
 		"terraformCommand": module.Module.TerragruntOptions.TerraformCommand,
@@ -69,20 +97,39 @@ func (module *RunningModule) runModuleWhenReady(ctx context.Context, opts *optio
 		return module.waitForDependencies()
 	})
 
-	semaphore <- struct{}{} // Add one to the buffered channel. Will block if parallelism limit is met
-	defer func() {
-		<-semaphore // Remove one from the buffered channel
-	}()
+	release := scheduler.acquire(module) // Will block if the parallelism limit of the module is met
+	defer release()
+
+	failedModule := failFast.failedModule()
+
+	switch {
+	case err != nil:
+	case failedModule != nil:
+		module.Module.TerragruntOptions.Logger.Warnf("Module %s is cancelled because module %s failed", module.Module.Path, failedModule.Module.Path)
+		err = errors.New(UnitCancelledError{Module: module.Module, FailedModule: failedModule.Module})
+	case runCancelled(ctx):
+		module.Module.TerragruntOptions.Logger.Warnf("Module %s is cancelled because the run was cancelled", module.Module.Path)
+		err = errors.New(UnitCancelledError{Module: module.Module})
+	default:
+		module.notify(UnitEvent{Type: UnitStarted, Path: module.Module.Path, Time: time.Now()})
 
-	if err == nil {
 		err = telemetry.Telemetry(ctx, opts, "run_module", map[string]any{
 			"path":             module.Module.Path,
 			"terraformCommand": module.Module.TerragruntOptions.TerraformCommand,
 		}, func(childCtx context.Context) error {
 			return module.runNow(ctx, opts)
 		})
+
+		switch {
+		case err == nil:
+		case runCancelled(ctx):
+			err = errors.New(UnitCancelledError{Module: module.Module, Err: err})
+		default:
+			err = failFast.fail(module, err)
+		}
 	}
 
+	scheduler.finished(module)
 	module.moduleFinished(err)
 }
 
@@ -112,6 +159,12 @@ func (module *RunningModule) waitForDependencies() error {
 
 func (module *RunningModule) runTerragrunt(ctx context.Context, opts *options.TerragruntOptions) error {
 	opts.Logger.Debugf("Running %s", module.Module.Path)
+	if module.onEvent != nil {
+		// The module writer must remain the outermost writer, FlushOutput expects it, and it buffers the output by line.
+		opts.Writer = io.MultiWriter(opts.Writer, module.outputWriter(OutputStdout))
+		opts.ErrWriter = io.MultiWriter(opts.ErrWriter, module.outputWriter(OutputStderr))
+	}
+
 	opts.Writer = NewModuleWriter(opts.Writer)
 
 	defer module.Module.FlushOutput() //nolint:errcheck
@@ -123,10 +176,34 @@ func (module *RunningModule) runTerragrunt(ctx context.Context, opts *options.Te
 func (module *RunningModule) runNow(ctx context.Context, rootOptions *options.TerragruntOptions) error {
 	module.Status = Running
 
+	if module.Resumed {
+		module.Module.TerragruntOptions.Logger.Infof("Module %s succeeded in a previous attempt of the run, skipping", module.Module.Path)
+		return nil
+	}
+
 	if module.Module.AssumeAlreadyApplied {
 		module.Module.TerragruntOptions.Logger.Debugf("Assuming module %s has already been applied and skipping it", module.Module.Path)
 		return nil
 	} else {
+		module.StartTime = time.Now()
+
+		// Track the exit code and the retries of this module on their own, so that they can be reported per module.
+		retryCount := tf.NewRetryCount(func(retries int) {
+			module.notify(UnitEvent{Type: UnitRetrying, Path: module.Module.Path, Time: time.Now(), Attempt: retries + 1})
+		})
+		detailedExitCode := &tf.DetailedExitCode{}
+
+		defer func(parentExitCode *tf.DetailedExitCode) {
+			module.Retries = retryCount.Get()
+			module.DetailedExitCode = detailedExitCode.Get()
+
+			if parentExitCode != nil {
+				parentExitCode.Set(module.DetailedExitCode)
+			}
+		}(tf.DetailedExitCodeFromContext(ctx))
+
+		ctx = tf.ContextWithRetryCount(tf.ContextWithDetailedExitCode(ctx, detailedExitCode), retryCount)
+
 		if err := module.runTerragrunt(ctx, module.Module.TerragruntOptions); err != nil {
 			return err
 		}
@@ -176,12 +253,94 @@ func (module *RunningModule) moduleFinished(moduleErr error) {
 
 	module.Status = Finished
 	module.Err = moduleErr
+	module.EndTime = time.Now()
+
+	if module.onFinished != nil {
+		module.onFinished(module)
+	}
+
+	module.notify(UnitEvent{
+		Type:     UnitFinished,
+		Path:     module.Module.Path,
+		Time:     module.EndTime,
+		Status:   module.runStatus(),
+		Err:      moduleErr,
+		ExitCode: module.exitCode(),
+	})
 
 	for _, toNotify := range module.NotifyWhenDone {
 		toNotify.DependencyDone <- module
 	}
 }
 
+// notify calls the event handler of the module, if any, with the given event.
+func (module *RunningModule) notify(event UnitEvent) {
+	if module.onEvent != nil {
+		module.onEvent(event)
+	}
+}
+
+// outputWriter returns a writer sending what is written to it as output events of the given stream.
+func (module *RunningModule) outputWriter(stream OutputStream) io.Writer {
+	return outputEventWriter{module: module, stream: stream}
+}
+
+// exitCode returns the exit code of the module, once it has finished.
+func (module *RunningModule) exitCode() int {
+	if module.Err == nil {
+		return 0
+	}
+
+	if exitCode, err := util.GetExitCode(module.Err); err == nil {
+		return exitCode
+	}
+
+	return 1
+}
+
+// outputEventWriter sends what is written to it as output events of a module.
+type outputEventWriter struct {
+	module *RunningModule
+	stream OutputStream
+}
+
+func (writer outputEventWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	writer.module.notify(UnitEvent{
+		Type:   UnitOutput,
+		Path:   writer.module.Module.Path,
+		Time:   time.Now(),
+		Stream: writer.stream,
+		Output: string(p),
+	})
+
+	return len(p), nil
+}
+
+// runStatus returns the outcome of running the module, once it has finished.
+func (module *RunningModule) runStatus() UnitRunStatus {
+	if module.Err == nil {
+		return UnitSucceeded
+	}
+
+	// Modules cancelled by --fail-fast, and the modules depending on them.
+	var cancelledErr UnitCancelledError
+	if errors.As(module.Err, &cancelledErr) {
+		return UnitCancelled
+	}
+
+	// Modules whose dependencies failed finish without ever starting.
+	var dependencyErr ProcessingModuleDependencyError
+	if module.StartTime.IsZero() && errors.As(module.Err, &dependencyErr) {
+		return UnitSkipped
+	}
+
+	return UnitFailed
+}
+
 type RunningModules map[string]*RunningModule
 
 func (modules RunningModules) toTerraformModuleGroups(maxDepth int) []TerraformModules {
@@ -306,12 +465,19 @@ func (modules RunningModules) RemoveFlagExcluded() map[string]*RunningModule {
 
 // Run the given map of module path to runningModule. To "run" a module, execute the RunTerragrunt command in its
 // TerragruntOptions object. The modules will be executed in an order determined by their inter-dependencies, using
-// as much concurrency as possible.
-func (modules RunningModules) runModules(ctx context.Context, opts *options.TerragruntOptions, parallelism int) error {
-	var (
-		waitGroup sync.WaitGroup
-		semaphore = make(chan struct{}, parallelism) // Make a semaphore from a buffered channel
-	)
+// as much concurrency as possible. The modules ready to run at the same time are started in the given order of their
+// paths.
+func (modules RunningModules) runModules(ctx context.Context, opts *options.TerragruntOptions, parallelism int, order []string) error {
+	var waitGroup sync.WaitGroup
+
+	scheduler := newScheduler(opts, parallelism, modules, order)
+
+	ctx, failFast := newFailFastState(ctx, opts)
+	defer failFast.stop()
+
+	for _, module := range scheduler.queue {
+		module.notify(UnitEvent{Type: UnitQueued, Path: module.Module.Path, Time: time.Now()})
+	}
 
 	for _, module := range modules {
 		waitGroup.Add(1)
@@ -319,7 +485,7 @@ func (modules RunningModules) runModules(ctx context.Context, opts *options.Terr
 		go func(module *RunningModule) {
 			defer waitGroup.Done()
 
-			module.runModuleWhenReady(ctx, opts, semaphore)
+			module.runModuleWhenReady(ctx, opts, scheduler, failFast)
 		}(module)
 	}
 
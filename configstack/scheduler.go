@@ -0,0 +1,202 @@
+package configstack
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+// scheduler limits the modules running concurrently, globally with --parallelism and per concurrency group with
+// --parallelism-group. Each running module takes as many slots as its weight, set in its `scheduling` block.
+//
+// Slots are given to the modules ready to run in the order of their priority, and in the order of the run queue among
+// modules of the same priority. Modules are ready to run once all their dependencies finished, which is tracked here
+// rather than by the modules themselves, so that a module that gets ready is not overtaken by a module of a lower
+// priority before it gets to ask for slots.
+type scheduler struct {
+	cond        *sync.Cond
+	modules     RunningModules
+	groupLimits map[string]int
+	groupsUsed  map[string]int
+	// pendingDependencies are the paths of the dependencies of each queued module that did not finish yet.
+	pendingDependencies map[*RunningModule]map[string]bool
+	// waiting are the queued modules asking for slots.
+	waiting map[*RunningModule]bool
+	// queue are the modules that did not start yet, in the order slots are given to them.
+	queue       []*RunningModule
+	globalLimit int
+	globalUsed  int
+	mu          sync.Mutex
+}
+
+// newScheduler returns the scheduler of the given modules, which are given slots in the order of their priority, and in
+// the given order of their paths among modules of the same priority. The modules missing from the order, such as the
+// modules of a stack that was not discovered, come after the others, in alphabetical order.
+func newScheduler(opts *options.TerragruntOptions, parallelism int, modules RunningModules, order []string) *scheduler {
+	scheduler := &scheduler{
+		modules:             modules,
+		groupLimits:         opts.ParallelismGroups,
+		groupsUsed:          make(map[string]int, len(opts.ParallelismGroups)),
+		pendingDependencies: make(map[*RunningModule]map[string]bool, len(modules)),
+		waiting:             make(map[*RunningModule]bool, len(modules)),
+		queue:               make([]*RunningModule, 0, len(modules)),
+		globalLimit:         parallelism,
+	}
+	scheduler.cond = sync.NewCond(&scheduler.mu)
+
+	for _, module := range modules {
+		scheduler.queue = append(scheduler.queue, module)
+		scheduler.pendingDependencies[module] = make(map[string]bool, len(module.Dependencies))
+
+		for path := range module.Dependencies {
+			scheduler.pendingDependencies[module][path] = true
+		}
+	}
+
+	positions := make(map[string]int, len(order))
+	for i, path := range order {
+		positions[path] = i
+	}
+
+	position := func(module *RunningModule) int {
+		if position, ok := positions[module.Module.Path]; ok {
+			return position
+		}
+
+		return len(order)
+	}
+
+	sort.Slice(scheduler.queue, func(i, j int) bool {
+		left, right := scheduler.queue[i], scheduler.queue[j]
+
+		if left.priority != right.priority {
+			return left.priority > right.priority
+		}
+
+		if position(left) != position(right) {
+			return position(left) < position(right)
+		}
+
+		return left.Module.Path < right.Module.Path
+	})
+
+	return scheduler
+}
+
+// acquire blocks until the given module can run, and returns the function releasing the slots taken by the module.
+func (scheduler *scheduler) acquire(module *RunningModule) func() {
+	scheduler.mu.Lock()
+	defer scheduler.mu.Unlock()
+
+	scheduler.waiting[module] = true
+
+	for !scheduler.canRun(module) {
+		scheduler.cond.Wait()
+	}
+
+	delete(scheduler.waiting, module)
+	scheduler.dequeue(module)
+
+	group, groupWeight, globalWeight := scheduler.weights(module)
+	scheduler.groupsUsed[group] += groupWeight
+	scheduler.globalUsed += globalWeight
+
+	// The module is no longer ahead of the other modules, which may now be able to run.
+	scheduler.cond.Broadcast()
+
+	return func() {
+		scheduler.mu.Lock()
+		defer scheduler.mu.Unlock()
+
+		scheduler.groupsUsed[group] -= groupWeight
+		scheduler.globalUsed -= globalWeight
+
+		scheduler.cond.Broadcast()
+	}
+}
+
+// finished records that the given module finished, so that the modules waiting for it get ready once all their
+// dependencies finished. It must be called before the modules waiting for it are notified.
+func (scheduler *scheduler) finished(module *RunningModule) {
+	scheduler.mu.Lock()
+	defer scheduler.mu.Unlock()
+
+	for _, toNotify := range module.NotifyWhenDone {
+		// The modules to notify are linked before the excluded modules are removed, so look up the ones that run.
+		if dependent, ok := scheduler.modules[toNotify.Module.Path]; ok {
+			delete(scheduler.pendingDependencies[dependent], module.Module.Path)
+		}
+	}
+
+	scheduler.cond.Broadcast()
+}
+
+func (scheduler *scheduler) dequeue(module *RunningModule) {
+	for i, queued := range scheduler.queue {
+		if queued == module {
+			scheduler.queue = append(scheduler.queue[:i], scheduler.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+// canRun returns true if the module fits in the free slots, and no ready module ahead of it is waiting for global
+// slots. Modules ahead only waiting for the slots of their group do not hold back the other modules.
+func (scheduler *scheduler) canRun(module *RunningModule) bool {
+	for _, queued := range scheduler.queue {
+		if queued == module {
+			break
+		}
+
+		ready := scheduler.waiting[queued] || len(scheduler.pendingDependencies[queued]) == 0
+		if ready && scheduler.fitsGroup(queued) {
+			return false
+		}
+	}
+
+	_, _, globalWeight := scheduler.weights(module)
+
+	return scheduler.fitsGroup(module) && scheduler.globalUsed+globalWeight <= scheduler.globalLimit
+}
+
+func (scheduler *scheduler) fitsGroup(module *RunningModule) bool {
+	group, groupWeight, _ := scheduler.weights(module)
+
+	limit, hasLimit := scheduler.groupLimits[group]
+
+	return !hasLimit || scheduler.groupsUsed[group]+groupWeight <= limit
+}
+
+// weights returns the concurrency group of the module, along with the slots it takes in its group and globally. A
+// module heavier than a limit takes the whole limit, otherwise it could never run.
+func (scheduler *scheduler) weights(module *RunningModule) (string, int, int) {
+	scheduling := module.Module.Config.Scheduling
+	weight := scheduling.GetWeight()
+	group := scheduling.GetGroup()
+
+	groupWeight := 0
+	if limit, hasLimit := scheduler.groupLimits[group]; hasLimit {
+		groupWeight = min(weight, limit)
+	}
+
+	return group, groupWeight, min(weight, scheduler.globalLimit)
+}
+
+// validateScheduling returns an error if the parallelism of a concurrency group, or the weight of a module, is invalid.
+func (modules TerraformModules) validateScheduling(opts *options.TerragruntOptions) error {
+	for group, limit := range opts.ParallelismGroups {
+		if limit < 1 {
+			return errors.New(InvalidParallelismGroupError{Group: group, Limit: limit})
+		}
+	}
+
+	for _, module := range modules {
+		if weight := module.Config.Scheduling.GetWeight(); weight < 1 {
+			return errors.New(InvalidSchedulingWeightError{ModulePath: module.Path, Weight: weight})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package configstack_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// concurrencyCounter tracks the number of slots in use, and the highest number of slots in use at once.
+type concurrencyCounter struct {
+	current atomic.Int32
+	max     atomic.Int32
+}
+
+func (counter *concurrencyCounter) run(weight int32) {
+	current := counter.current.Add(weight)
+
+	for {
+		highest := counter.max.Load()
+		if current <= highest || counter.max.CompareAndSwap(highest, current) {
+			break
+		}
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	counter.current.Add(-weight)
+}
+
+func newSchedulingTestModule(t *testing.T, opts *options.TerragruntOptions, name string, scheduling *config.SchedulingConfig, counter *concurrencyCounter) *configstack.TerraformModule {
+	t.Helper()
+
+	moduleOpts, err := opts.CloneWithConfigPath(filepath.Join(opts.WorkingDir, name, config.DefaultTerragruntConfigPath))
+	require.NoError(t, err)
+
+	moduleOpts.RunTerragrunt = func(_ context.Context, _ *options.TerragruntOptions) error {
+		counter.run(int32(scheduling.GetWeight()))
+		return nil
+	}
+
+	return &configstack.TerraformModule{
+		Stack:             &configstack.Stack{},
+		Path:              filepath.Join(opts.WorkingDir, name),
+		Config:            config.TerragruntConfig{Scheduling: scheduling},
+		Dependencies:      configstack.TerraformModules{},
+		TerragruntOptions: moduleOpts,
+	}
+}
+
+func TestRunModulesParallelismGroup(t *testing.T) {
+	t.Parallel()
+
+	opts := newFailFastTestOptions(t)
+	opts.ParallelismGroups = map[string]int{"eks": 1}
+
+	eks := &config.SchedulingConfig{Group: new(string)}
+	*eks.Group = "eks"
+
+	eksCounter := &concurrencyCounter{}
+	otherCounter := &concurrencyCounter{}
+
+	modules := configstack.TerraformModules{
+		newSchedulingTestModule(t, opts, "eks-a", eks, eksCounter),
+		newSchedulingTestModule(t, opts, "eks-b", eks, eksCounter),
+		newSchedulingTestModule(t, opts, "eks-c", eks, eksCounter),
+		newSchedulingTestModule(t, opts, "vpc", nil, otherCounter),
+		newSchedulingTestModule(t, opts, "dns", nil, otherCounter),
+	}
+
+	require.NoError(t, modules.RunModules(context.Background(), opts, options.DefaultParallelism))
+
+	assert.Equal(t, int32(1), eksCounter.max.Load())
+	assert.Equal(t, int32(2), otherCounter.max.Load())
+}
+
+func TestRunModulesSchedulingWeight(t *testing.T) {
+	t.Parallel()
+
+	opts := newFailFastTestOptions(t)
+
+	heavy := &config.SchedulingConfig{Weight: new(int)}
+	*heavy.Weight = 3
+
+	counter := &concurrencyCounter{}
+
+	modules := configstack.TerraformModules{
+		newSchedulingTestModule(t, opts, "heavy", heavy, counter),
+		newSchedulingTestModule(t, opts, "light-a", nil, counter),
+		newSchedulingTestModule(t, opts, "light-b", nil, counter),
+	}
+
+	require.NoError(t, modules.RunModules(context.Background(), opts, 3))
+
+	// The heavy module takes all the slots, so it never runs along with the light modules.
+	assert.Equal(t, int32(3), counter.max.Load())
+}
+
+func TestStackRunInvalidScheduling(t *testing.T) {
+	t.Parallel()
+
+	opts := newFailFastTestOptions(t)
+	opts.ParallelismGroups = map[string]int{"eks": 0}
+
+	stack := configstack.NewStack(opts)
+	stack.Modules = configstack.TerraformModules{newSchedulingTestModule(t, opts, "eks", nil, &concurrencyCounter{})}
+
+	err := stack.Run(context.Background(), opts)
+
+	var groupErr configstack.InvalidParallelismGroupError
+	require.ErrorAs(t, err, &groupErr)
+	assert.Equal(t, "eks", groupErr.Group)
+
+	opts.ParallelismGroups = nil
+	weight := 0
+	stack.Modules = configstack.TerraformModules{
+		newSchedulingTestModule(t, opts, "zero", &config.SchedulingConfig{Weight: &weight}, &concurrencyCounter{}),
+	}
+
+	err = stack.Run(context.Background(), opts)
+
+	var weightErr configstack.InvalidSchedulingWeightError
+	require.ErrorAs(t, err, &weightErr)
+	assert.Equal(t, 0, weightErr.Weight)
+}
@@ -10,15 +10,19 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gruntwork-io/go-commons/collections"
 	"github.com/gruntwork-io/terragrunt/cli/commands/run/creds"
 	"github.com/gruntwork-io/terragrunt/cli/commands/run/creds/providers/externalcmd"
 	"github.com/gruntwork-io/terragrunt/config/hclparse"
+	"github.com/gruntwork-io/terragrunt/internal/discovery"
+	"github.com/gruntwork-io/terragrunt/internal/queue"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 	"github.com/gruntwork-io/terragrunt/telemetry"
 	"github.com/gruntwork-io/terragrunt/tf"
@@ -36,23 +40,42 @@ type Stack struct {
 	terragruntOptions     *options.TerragruntOptions
 	childTerragruntConfig *config.TerragruntConfig
 	Modules               TerraformModules
-	outputMu              sync.Mutex
+	// discovered are the discovered units of the modules of the stack, along with their dependencies.
+	discovered discovery.DiscoveredConfigs
+	// onRunFinished is called with the modules of a run once they all finished, e.g. to report their drift.
+	onRunFinished func(runningModules RunningModules)
+	// runQueue is the run queue of the units of the stack, giving the order in which the modules ready to run are
+	// started. It is nil for stacks whose modules were not discovered.
+	runQueue        *queue.Queue
+	outputMu        sync.Mutex
+	persistRunState bool
+}
+
+// newStackDiscovery returns the discovery of the units in the working dir of the given options. Units in hidden
+// directories are run as well, only the directories that can not contain units are skipped.
+func newStackDiscovery(terragruntOptions *options.TerragruntOptions) *discovery.Discovery {
+	configFilenames := append(slices.Clone(config.DefaultTerragruntConfigPaths), filepath.Base(terragruntOptions.TerragruntConfigPath))
+
+	return discovery.NewDiscovery(terragruntOptions.WorkingDir).
+		WithHidden().
+		WithModuleDirsOnly().
+		WithConfigFilenames(configFilenames...)
 }
 
 // FindStackInSubfolders finds all the Terraform modules in the subfolders of the working directory of the given TerragruntOptions and
 // assemble them into a Stack object that can be applied or destroyed in a single command
 func FindStackInSubfolders(ctx context.Context, terragruntOptions *options.TerragruntOptions, opts ...Option) (*Stack, error) {
-	var terragruntConfigFiles []string
+	var discovered discovery.DiscoveredConfigs
 
 	err := telemetry.Telemetry(ctx, terragruntOptions, "find_files_in_path", map[string]any{
 		"working_dir": terragruntOptions.WorkingDir,
 	}, func(childCtx context.Context) error {
-		result, err := config.FindConfigFilesInPath(terragruntOptions.WorkingDir, terragruntOptions)
+		configs, err := newStackDiscovery(terragruntOptions).Discover(childCtx, terragruntOptions)
 		if err != nil {
 			return err
 		}
 
-		terragruntConfigFiles = result
+		discovered = configs.Filter(discovery.ConfigTypeUnit)
 
 		return nil
 	})
@@ -62,7 +85,7 @@ func FindStackInSubfolders(ctx context.Context, terragruntOptions *options.Terra
 	}
 
 	stack := NewStack(terragruntOptions, opts...)
-	if err := stack.createStackForTerragruntConfigPaths(ctx, terragruntConfigFiles); err != nil {
+	if err := stack.createStackForDiscoveredConfigs(ctx, discovered); err != nil {
 		return nil, err
 	}
 
@@ -160,9 +183,53 @@ func (stack *Stack) Graph(terragruntOptions *options.TerragruntOptions) {
 	}
 }
 
+// Run runs the command of the given options in the units of the stack, and blocks until the run finished. Use Runner
+// to observe the units while they run, or to cancel the run.
 func (stack *Stack) Run(ctx context.Context, terragruntOptions *options.TerragruntOptions) error {
+	return stack.run(ctx, terragruntOptions, nil)
+}
+
+// run runs the command of the given options in the units of the stack, and calls onEvent, if not nil, with the events
+// of the units.
+func (stack *Stack) run(ctx context.Context, terragruntOptions *options.TerragruntOptions, onEvent func(event UnitEvent)) error {
 	stackCmd := terragruntOptions.TerraformCommand
 
+	var reportFormat string
+
+	if terragruntOptions.RunReportFile != "" {
+		format, err := runReportFormat(terragruntOptions)
+		if err != nil {
+			return err
+		}
+
+		reportFormat = format
+	}
+
+	if err := stack.Modules.validateScheduling(terragruntOptions); err != nil {
+		return err
+	}
+
+	summarizePlans := stackCmd == tf.CommandNamePlan && (terragruntOptions.PlanSummary || terragruntOptions.PlanSummaryFile != "")
+
+	var summaryFormat string
+
+	if summarizePlans {
+		if terragruntOptions.JSONOutputFolder == "" {
+			return errors.New(PlanSummaryWithoutJSONOutDirError{})
+		}
+
+		format, err := planSummaryFormat(terragruntOptions)
+		if err != nil {
+			return err
+		}
+
+		summaryFormat = format
+	}
+
+	if terragruntOptions.FromPlanDir != "" && stackCmd != tf.CommandNameApply {
+		return errors.New(FromPlanDirWithoutApplyError(stackCmd))
+	}
+
 	// prepare folder for output hierarchy if output folder is set
 	if terragruntOptions.OutputFolder != "" {
 		for _, module := range stack.Modules {
@@ -209,14 +276,157 @@ func (stack *Stack) Run(ctx context.Context, terragruntOptions *options.Terragru
 		defer stack.summarizePlanAllErrors(terragruntOptions, errorStreams)
 	}
 
+	dependencyOrder := NormalOrder
+
 	switch {
 	case terragruntOptions.IgnoreDependencyOrder:
-		return stack.Modules.RunModulesIgnoreOrder(ctx, terragruntOptions, terragruntOptions.Parallelism)
+		dependencyOrder = IgnoreOrder
 	case stackCmd == tf.CommandNameDestroy:
-		return stack.Modules.RunModulesReverseOrder(ctx, terragruntOptions, terragruntOptions.Parallelism)
-	default:
-		return stack.Modules.RunModules(ctx, terragruntOptions, terragruntOptions.Parallelism)
+		dependencyOrder = ReverseOrder
+	}
+
+	runningModules, err := stack.Modules.ToRunningModules(dependencyOrder)
+	if err != nil {
+		return err
+	}
+
+	var runState *RunState
+
+	if stack.persistRunState {
+		if runState, err = stack.prepareRunState(terragruntOptions, runningModules); err != nil {
+			return err
+		}
+	}
+
+	if onEvent != nil {
+		for _, module := range runningModules {
+			module.onEvent = onEvent
+		}
+	}
+
+	var durations *UnitDurations
+
+	if terragruntOptions.QueueCriticalPath {
+		if durations, err = loadUnitDurations(terragruntOptions); err != nil {
+			return err
+		}
+
+		runningModules.prioritizeCriticalPath(terragruntOptions, durations)
 	}
+
+	startTime := time.Now()
+	runErr := runningModules.runModules(ctx, terragruntOptions, terragruntOptions.Parallelism, stack.runOrder(dependencyOrder))
+
+	if stack.onRunFinished != nil {
+		stack.onRunFinished(runningModules)
+	}
+
+	if durations != nil {
+		if err := durations.Record(terragruntOptions, runningModules); err != nil {
+			terragruntOptions.Logger.Warnf("Failed to record the durations of the units: %v", err)
+		}
+	}
+
+	if runState != nil {
+		if runErr != nil {
+			terragruntOptions.Logger.Infof("To only run the units that failed or did not run, resume the run with --resume%s", runIDHint(terragruntOptions))
+		} else if err := runState.Remove(); err != nil {
+			terragruntOptions.Logger.Warnf("Failed to remove the state of run %s: %v", runState.RunID, err)
+		}
+	}
+
+	if terragruntOptions.RunReportFile != "" {
+		report := newRunReport(terragruntOptions, stack.Modules, runningModules, startTime, time.Now())
+
+		reportPath := terragruntOptions.RunReportFile
+		if !filepath.IsAbs(reportPath) {
+			reportPath = filepath.Join(terragruntOptions.WorkingDir, reportPath)
+		}
+
+		if err := report.Write(reportPath, reportFormat); err != nil {
+			return errors.Join(runErr, err)
+		}
+
+		terragruntOptions.Logger.Debugf("Wrote the run report to %s", reportPath)
+	}
+
+	if summarizePlans {
+		if err := stack.summarizePlans(terragruntOptions, runningModules, summaryFormat); err != nil {
+			return errors.Join(runErr, err)
+		}
+	}
+
+	return runErr
+}
+
+// summarizePlans prints the summary of the changes planned in every unit, and writes it to the plan summary file.
+func (stack *Stack) summarizePlans(terragruntOptions *options.TerragruntOptions, runningModules RunningModules, format string) error {
+	summary := newPlanSummary(terragruntOptions, stack.Modules, runningModules)
+
+	if terragruntOptions.PlanSummary {
+		if err := summary.Print(terragruntOptions.Writer); err != nil {
+			return err
+		}
+	}
+
+	if terragruntOptions.PlanSummaryFile == "" {
+		return nil
+	}
+
+	summaryPath := terragruntOptions.PlanSummaryFile
+	if !filepath.IsAbs(summaryPath) {
+		summaryPath = filepath.Join(terragruntOptions.WorkingDir, summaryPath)
+	}
+
+	if err := summary.Write(summaryPath, format); err != nil {
+		return err
+	}
+
+	terragruntOptions.Logger.Debugf("Wrote the plan summary to %s", summaryPath)
+
+	return nil
+}
+
+// prepareRunState loads the state of the run and hooks it up to the given modules: modules that succeeded in the previous
+// attempt of the run are marked as resumed when resuming, and the status of every module is persisted once it finishes.
+func (stack *Stack) prepareRunState(terragruntOptions *options.TerragruntOptions, runningModules RunningModules) (*RunState, error) {
+	runState, err := newRunState(terragruntOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, module := range runningModules {
+		unitPath := unitRelPath(terragruntOptions, module.Module.Path)
+
+		// Units that can't be hashed are run again when resuming.
+		hash, err := hashUnit(module.Module)
+		if err != nil {
+			terragruntOptions.Logger.Warnf("Failed to hash the code of module %s: %v", module.Module.Path, err)
+		}
+
+		module.Resumed = runState.Succeeded(unitPath, hash)
+
+		if !module.Resumed && runState.Status(unitPath) == UnitSucceeded {
+			terragruntOptions.Logger.Infof("Module %s changed since it succeeded in a previous attempt of run %s, running it again", module.Module.Path, runState.RunID)
+		}
+
+		module.onFinished = func(module *RunningModule) {
+			if err := runState.Record(unitPath, hash, module.runStatus()); err != nil {
+				terragruntOptions.Logger.Warnf("Failed to record the status of module %s in run %s: %v", module.Module.Path, runState.RunID, err)
+			}
+		}
+	}
+
+	return runState, nil
+}
+
+// runIDHint returns the --run-id flag needed to resume the run, if the run ID was set explicitly.
+func runIDHint(terragruntOptions *options.TerragruntOptions) string {
+	if terragruntOptions.RunID == "" {
+		return ""
+	}
+
+	return " --run-id " + terragruntOptions.RunID
 }
 
 // We inspect the error streams to give an explicit message if the plan failed because there were references to
@@ -266,6 +476,27 @@ func (stack *Stack) syncTerraformCliArgs(terragruntOptions *options.TerragruntOp
 	}
 }
 
+// runOrder returns the paths of the units in the order of the run queue of the stack, read from the back for the
+// commands run in the reverse order of the dependencies, such as destroy.
+func (stack *Stack) runOrder(dependencyOrder DependencyOrder) []string {
+	if stack.runQueue == nil {
+		return nil
+	}
+
+	entries := stack.runQueue.Entries()
+
+	order := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		order = append(order, entry.Path)
+	}
+
+	if dependencyOrder == ReverseOrder {
+		slices.Reverse(order)
+	}
+
+	return order
+}
+
 func (stack *Stack) toRunningModules(terraformCommand string) (RunningModules, error) {
 	switch terraformCommand {
 	case tf.CommandNameDestroy:
@@ -292,17 +523,17 @@ func (stack *Stack) GetModuleRunGraph(terraformCommand string) ([]TerraformModul
 	return groups, nil
 }
 
-// Find all the Terraform modules in the folders that contain the given Terragrunt config files and assemble those
-// modules into a Stack object that can be applied or destroyed in a single command
-func (stack *Stack) createStackForTerragruntConfigPaths(ctx context.Context, terragruntConfigPaths []string) error {
-	err := telemetry.Telemetry(ctx, stack.terragruntOptions, "create_stack_for_terragrunt_config_paths", map[string]any{
+// Resolve the modules of the given discovered units, along with their dependencies, and assemble them into the
+// modules of the stack, run in the order of the run queue of the units.
+func (stack *Stack) createStackForDiscoveredConfigs(ctx context.Context, discovered discovery.DiscoveredConfigs) error {
+	err := telemetry.Telemetry(ctx, stack.terragruntOptions, "create_stack_for_discovered_configs", map[string]any{
 		"working_dir": stack.terragruntOptions.WorkingDir,
 	}, func(childCtx context.Context) error {
-		if len(terragruntConfigPaths) == 0 {
+		if len(discovered) == 0 {
 			return errors.New(ErrNoTerraformModulesFound)
 		}
 
-		modules, err := stack.ResolveTerraformModules(ctx, terragruntConfigPaths)
+		modules, err := stack.ResolveDiscoveredConfigs(ctx, discovered)
 		if err != nil {
 			return errors.New(err)
 		}
@@ -329,31 +560,68 @@ func (stack *Stack) createStackForTerragruntConfigPaths(ctx context.Context, ter
 		return errors.New(err)
 	}
 
+	runQueue, err := queue.NewQueue(stack.discovered)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	stack.runQueue = runQueue
+
 	return nil
 }
 
-// ResolveTerraformModules goes through each of the given Terragrunt configuration files
-// and resolve the module that configuration file represents into a TerraformModule struct.
-// Return the list of these TerraformModule structs.
-func (stack *Stack) ResolveTerraformModules(ctx context.Context, terragruntConfigPaths []string) (TerraformModules, error) {
-	canonicalTerragruntConfigPaths, err := util.CanonicalPaths(terragruntConfigPaths, ".")
-	if err != nil {
-		return nil, err
+// ResolveDiscoveredConfigs resolves the modules of the given discovered units. The dependencies of the units are
+// discovered along with them, and resolved into modules as well: the dependencies outside the working dir are only run
+// if the user confirms it, and are otherwise assumed already applied.
+func (stack *Stack) ResolveDiscoveredConfigs(ctx context.Context, discovered discovery.DiscoveredConfigs) (TerraformModules, error) {
+	modulesMap := TerraformModulesMap{}
+
+	// The dependencies of the units are discovered again, along with the modules, on copies of the units.
+	units := make(map[string]bool, len(discovered))
+	unitConfigs := make(discovery.DiscoveredConfigs, 0, len(discovered))
+
+	for _, unit := range discovered {
+		unitPath, err := util.CanonicalPath(unit.Path, ".")
+		if err != nil {
+			return nil, err
+		}
+
+		units[unitPath] = true
+		unitConfigs = append(unitConfigs, &discovery.DiscoveredConfig{Type: unit.Type, Path: unitPath, Filename: unit.Filename})
 	}
 
-	var modulesMap TerraformModulesMap
+	var configs discovery.DiscoveredConfigs
 
-	err = telemetry.Telemetry(ctx, stack.terragruntOptions, "resolve_modules", map[string]any{
+	err := telemetry.Telemetry(ctx, stack.terragruntOptions, "resolve_modules", map[string]any{
 		"working_dir": stack.terragruntOptions.WorkingDir,
 	}, func(childCtx context.Context) error {
-		howThesePathsWereFound := "Terragrunt config file found in a subdirectory of " + stack.terragruntOptions.WorkingDir
+		dependencyDiscovery := discovery.NewDependencyDiscovery(unitConfigs, maxLevelsOfRecursion, true).
+			WithParseFunc(func(ctx context.Context, _ *options.TerragruntOptions, cfg *discovery.DiscoveredConfig) (*config.TerragruntConfig, error) {
+				howThisModuleWasFound := "Terragrunt config file found in a subdirectory of " + stack.terragruntOptions.WorkingDir
+				if !units[cfg.Path] {
+					howThisModuleWasFound = "dependency of a module in " + stack.terragruntOptions.WorkingDir
+				}
 
-		result, err := stack.resolveModules(ctx, canonicalTerragruntConfigPaths, howThesePathsWereFound)
-		if err != nil {
+				module, err := stack.resolveTerraformModule(ctx, cfg.ConfigPath(), howThisModuleWasFound)
+				if err != nil || module == nil {
+					return nil, err
+				}
+
+				modulesMap[module.Path] = module
+
+				// The dependencies of excluded modules are not run, there is no need to discover them.
+				if module.FlagExcluded {
+					return nil, nil
+				}
+
+				return &module.Config, nil
+			})
+
+		if err := dependencyDiscovery.DiscoverAllDependencies(childCtx, stack.terragruntOptions); err != nil {
 			return err
 		}
 
-		modulesMap = result
+		configs = dependencyDiscovery.Configs()
 
 		return nil
 	})
@@ -362,43 +630,43 @@ func (stack *Stack) ResolveTerraformModules(ctx context.Context, terragruntConfi
 		return nil, err
 	}
 
-	var externalDependencies TerraformModulesMap
+	var crossLinkedModules TerraformModules
 
-	err = telemetry.Telemetry(ctx, stack.terragruntOptions, "resolve_external_dependencies_for_modules", map[string]any{
+	err = telemetry.Telemetry(ctx, stack.terragruntOptions, "crosslink_dependencies", map[string]any{
 		"working_dir": stack.terragruntOptions.WorkingDir,
 	}, func(childCtx context.Context) error {
-		result, err := stack.resolveExternalDependenciesForModules(ctx, modulesMap, TerraformModulesMap{}, 0)
+		result, err := modulesMap.crosslinkDependencies(configs)
 		if err != nil {
 			return err
 		}
 
-		externalDependencies = result
+		crossLinkedModules = result
 
 		return nil
 	})
+
 	if err != nil {
 		return nil, err
 	}
 
-	var crossLinkedModules TerraformModules
-
-	err = telemetry.Telemetry(ctx, stack.terragruntOptions, "crosslink_dependencies", map[string]any{
+	err = telemetry.Telemetry(ctx, stack.terragruntOptions, "confirm_external_dependencies", map[string]any{
 		"working_dir": stack.terragruntOptions.WorkingDir,
 	}, func(childCtx context.Context) error {
-		result, err := modulesMap.mergeMaps(externalDependencies).crosslinkDependencies(canonicalTerragruntConfigPaths)
-		if err != nil {
-			return err
-		}
-
-		crossLinkedModules = result
-
-		return nil
+		return stack.confirmExternalDependencies(childCtx, crossLinkedModules, units)
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
+	stack.discovered = make(discovery.DiscoveredConfigs, 0, len(modulesMap))
+
+	for _, cfg := range configs {
+		if _, ok := modulesMap[cfg.Path]; ok {
+			stack.discovered = append(stack.discovered, cfg)
+		}
+	}
+
 	var withUnitsIncluded TerraformModules
 
 	err = telemetry.Telemetry(ctx, stack.terragruntOptions, "flag_included_dirs", map[string]any{
@@ -460,12 +728,18 @@ func (stack *Stack) ResolveTerraformModules(ctx context.Context, terragruntConfi
 		return nil, err
 	}
 
-	var withModulesExcluded TerraformModules
+	var withUnitsChanged TerraformModules
 
-	err = telemetry.Telemetry(ctx, stack.terragruntOptions, "flag_excluded_dirs", map[string]any{
+	err = telemetry.Telemetry(ctx, stack.terragruntOptions, "flag_units_changed_since", map[string]any{
 		"working_dir": stack.terragruntOptions.WorkingDir,
 	}, func(childCtx context.Context) error {
-		withModulesExcluded = withUnitsRead.flagExcludedDirs(stack.terragruntOptions)
+		result, err := withUnitsRead.flagUnitsChangedSince(childCtx, stack.terragruntOptions)
+		if err != nil {
+			return err
+		}
+
+		withUnitsChanged = result
+
 		return nil
 	})
 
@@ -473,83 +747,35 @@ func (stack *Stack) ResolveTerraformModules(ctx context.Context, terragruntConfi
 		return nil, err
 	}
 
-	return withModulesExcluded, nil
-}
-
-// Go through each of the given Terragrunt configuration files and resolve the module that configuration file represents
-// into a TerraformModule struct. Note that this method will NOT fill in the Dependencies field of the TerraformModule
-// struct (see the crosslinkDependencies method for that). Return a map from module path to TerraformModule struct.
-func (stack *Stack) resolveModules(ctx context.Context, canonicalTerragruntConfigPaths []string, howTheseModulesWereFound string) (TerraformModulesMap, error) {
-	modulesMap := TerraformModulesMap{}
-
-	for _, terragruntConfigPath := range canonicalTerragruntConfigPaths {
-		if !util.FileExists(terragruntConfigPath) {
-			return nil, ProcessingModuleError{UnderlyingError: os.ErrNotExist, ModulePath: terragruntConfigPath, HowThisModuleWasFound: howTheseModulesWereFound}
-		}
-
-		var module *TerraformModule
-
-		err := telemetry.Telemetry(ctx, stack.terragruntOptions, "resolve_terraform_module", map[string]any{
-			"config_path": terragruntConfigPath,
-			"working_dir": stack.terragruntOptions.WorkingDir,
-		}, func(childCtx context.Context) error {
-			m, err := stack.resolveTerraformModule(ctx, terragruntConfigPath, modulesMap, howTheseModulesWereFound)
-			if err != nil {
-				return err
-			}
-
-			module = m
-
-			return nil
-		})
-
-		if err != nil {
-			return modulesMap, err
-		}
-
-		if module != nil {
-			modulesMap[module.Path] = module
-
-			var dependencies TerraformModulesMap
-
-			err := telemetry.Telemetry(ctx, stack.terragruntOptions, "resolve_dependencies_for_module", map[string]any{
-				"config_path": terragruntConfigPath,
-				"working_dir": stack.terragruntOptions.WorkingDir,
-				"module_path": module.Path,
-			}, func(childCtx context.Context) error {
-				deps, err := stack.resolveDependenciesForModule(ctx, module, modulesMap, true)
-				if err != nil {
-					return err
-				}
-
-				dependencies = deps
+	var withModulesExcluded TerraformModules
 
-				return nil
-			})
-			if err != nil {
-				return modulesMap, err
-			}
+	err = telemetry.Telemetry(ctx, stack.terragruntOptions, "flag_excluded_dirs", map[string]any{
+		"working_dir": stack.terragruntOptions.WorkingDir,
+	}, func(childCtx context.Context) error {
+		withModulesExcluded = withUnitsChanged.flagExcludedDirs(stack.terragruntOptions)
+		return nil
+	})
 
-			modulesMap = collections.MergeMaps(modulesMap, dependencies)
-		}
+	if err != nil {
+		return nil, err
 	}
 
-	return modulesMap, nil
+	return withModulesExcluded, nil
 }
 
 // Create a TerraformModule struct for the Terraform module specified by the given Terragrunt configuration file path.
 // Note that this method will NOT fill in the Dependencies field of the TerraformModule struct (see the
 // crosslinkDependencies method for that).
-func (stack *Stack) resolveTerraformModule(ctx context.Context, terragruntConfigPath string, modulesMap TerraformModulesMap, howThisModuleWasFound string) (*TerraformModule, error) {
+func (stack *Stack) resolveTerraformModule(ctx context.Context, terragruntConfigPath string, howThisModuleWasFound string) (*TerraformModule, error) {
+	if !util.FileExists(terragruntConfigPath) {
+		return nil, ProcessingModuleError{UnderlyingError: os.ErrNotExist, ModulePath: terragruntConfigPath, HowThisModuleWasFound: howThisModuleWasFound}
+	}
+
 	modulePath, err := util.CanonicalPath(filepath.Dir(terragruntConfigPath), ".")
 	if err != nil {
 		return nil, err
 	}
 
-	if _, ok := modulesMap[modulePath]; ok {
-		return nil, nil
-	}
-
 	// Clone the options struct so we don't modify the original one. This is especially important as run-all operations
 	// happen concurrently.
 	opts, err := stack.terragruntOptions.CloneWithConfigPath(terragruntConfigPath)
@@ -589,6 +815,9 @@ func (stack *Stack) resolveTerraformModule(ctx context.Context, terragruntConfig
 			config.DependencyBlock,
 			config.FeatureFlagsBlock,
 			config.ErrorsBlock,
+
+			// Need for scheduling the modules
+			config.SchedulingBlock,
 		)
 
 	// Credentials have to be acquired before the config is parsed, as the config may contain interpolation functions
@@ -657,107 +886,45 @@ func (stack *Stack) resolveTerraformModule(ctx context.Context, terragruntConfig
 	return &TerraformModule{Stack: stack, Path: modulePath, Config: *terragruntConfig, TerragruntOptions: opts}, nil
 }
 
-// resolveDependenciesForModule looks through the dependencies of the given module and resolve the dependency paths listed in the module's config.
-// If `skipExternal` is true, the func returns only dependencies that are inside of the current working directory, which means they are part of the environment the
-// user is trying to apply-all or destroy-all. Note that this method will NOT fill in the Dependencies field of the TerraformModule struct (see the crosslinkDependencies method for that).
-func (stack *Stack) resolveDependenciesForModule(ctx context.Context, module *TerraformModule, modulesMap TerraformModulesMap, skipExternal bool) (TerraformModulesMap, error) {
-	if module.Config.Dependencies == nil || len(module.Config.Dependencies.Paths) == 0 {
-		return TerraformModulesMap{}, nil
+// Confirm whether the dependencies of the given modules outside the working dir, which are not among the given units,
+// should be run as well, or assumed already applied. The dependencies are confirmed once, with the first module
+// depending on them, the modules in the working dir first.
+func (stack *Stack) confirmExternalDependencies(ctx context.Context, modules TerraformModules, units map[string]bool) error {
+	isExternal := func(module *TerraformModule) bool {
+		return !units[module.Path] && !util.HasPathPrefix(module.Path, stack.terragruntOptions.WorkingDir)
 	}
 
-	key := fmt.Sprintf("%s-%s-%v-%v", module.Path, stack.terragruntOptions.WorkingDir, skipExternal, stack.terragruntOptions.TerraformCommand)
-	if value, ok := existingModules.Get(ctx, key); ok {
-		return *value, nil
-	}
-
-	externalTerragruntConfigPaths := []string{}
-
-	for _, dependency := range module.Config.Dependencies.Paths {
-		dependencyPath, err := util.CanonicalPath(dependency, module.Path)
-		if err != nil {
-			return TerraformModulesMap{}, err
-		}
-
-		if skipExternal && !util.HasPathPrefix(dependencyPath, stack.terragruntOptions.WorkingDir) {
-			continue
-		}
-
-		terragruntConfigPath := config.GetDefaultConfigPath(dependencyPath)
-
-		if _, alreadyContainsModule := modulesMap[dependencyPath]; !alreadyContainsModule {
-			externalTerragruntConfigPaths = append(externalTerragruntConfigPaths, terragruntConfigPath)
-		}
-	}
-
-	howThesePathsWereFound := fmt.Sprintf("dependency of module at '%s'", module.Path)
-
-	result, err := stack.resolveModules(ctx, externalTerragruntConfigPaths, howThesePathsWereFound)
-	if err != nil {
-		return nil, err
-	}
-
-	existingModules.Put(ctx, key, &result)
-
-	return result, nil
-}
-
-// Look through the dependencies of the modules in the given map and resolve the "external" dependency paths listed in
-// each modules config (i.e. those dependencies not in the given list of Terragrunt config canonical file paths).
-// These external dependencies are outside of the current working directory, which means they may not be part of the
-// environment the user is trying to apply-all or destroy-all. Therefore, this method also confirms whether the user wants
-// to actually apply those dependencies or just assume they are already applied. Note that this method will NOT fill in
-// the Dependencies field of the TerraformModule struct (see the crosslinkDependencies method for that).
-func (stack *Stack) resolveExternalDependenciesForModules(ctx context.Context, modulesMap, modulesAlreadyProcessed TerraformModulesMap, recursionLevel int) (TerraformModulesMap, error) {
-	allExternalDependencies := TerraformModulesMap{}
-	modulesToSkip := modulesMap.mergeMaps(modulesAlreadyProcessed)
-
-	// Simple protection from circular dependencies causing a Stack Overflow due to infinite recursion
-	if recursionLevel > maxLevelsOfRecursion {
-		return allExternalDependencies, errors.New(InfiniteRecursionError{RecursionLevel: maxLevelsOfRecursion, Modules: modulesToSkip})
-	}
-
-	sortedKeys := modulesMap.getSortedKeys()
-	for _, key := range sortedKeys {
-		module := modulesMap[key]
-
-		externalDependencies, err := stack.resolveDependenciesForModule(ctx, module, modulesToSkip, false)
-		if err != nil {
-			return externalDependencies, err
-		}
+	dependents := slices.Clone(modules)
+	sort.SliceStable(dependents, func(i, j int) bool {
+		return !isExternal(dependents[i]) && isExternal(dependents[j])
+	})
 
-		moduleOpts, err := stack.terragruntOptions.CloneWithConfigPath(config.GetDefaultConfigPath(module.Path))
-		if err != nil {
-			return nil, err
-		}
+	confirmed := map[string]bool{}
 
-		for _, externalDependency := range externalDependencies {
-			if _, alreadyFound := modulesToSkip[externalDependency.Path]; alreadyFound {
+	for _, module := range dependents {
+		for _, dependency := range module.Dependencies {
+			if confirmed[dependency.Path] || !isExternal(dependency) {
 				continue
 			}
 
+			confirmed[dependency.Path] = true
+
 			shouldApply := false
+
 			if !stack.terragruntOptions.IgnoreExternalDependencies {
-				shouldApply, err = module.confirmShouldApplyExternalDependency(ctx, externalDependency, moduleOpts)
+				var err error
+
+				shouldApply, err = module.confirmShouldApplyExternalDependency(ctx, dependency, module.TerragruntOptions)
 				if err != nil {
-					return externalDependencies, err
+					return err
 				}
 			}
 
-			externalDependency.AssumeAlreadyApplied = !shouldApply
-			allExternalDependencies[externalDependency.Path] = externalDependency
+			dependency.AssumeAlreadyApplied = !shouldApply
 		}
 	}
 
-	if len(allExternalDependencies) > 0 {
-		recursiveDependencies, err := stack.resolveExternalDependenciesForModules(ctx, allExternalDependencies, modulesMap, recursionLevel+1)
-		if err != nil {
-			return allExternalDependencies, err
-		}
-
-		return allExternalDependencies.mergeMaps(recursiveDependencies), nil
-	}
-
-	return allExternalDependencies, nil
+	return nil
 }
 
 // ListStackDependentModules - build a map with each module and its dependent modules
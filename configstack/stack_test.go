@@ -196,18 +196,18 @@ func createDirIfNotExist(t *testing.T, path string) {
 	}
 }
 
-func TestResolveTerraformModulesNoPaths(t *testing.T) {
+func TestResolveDiscoveredConfigsNoPaths(t *testing.T) {
 	t.Parallel()
 
 	configPaths := []string{}
 	expected := configstack.TerraformModules{}
 	stack := configstack.NewStack(mockOptions)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 	require.NoError(t, actualErr, "Unexpected error: %v", actualErr)
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesOneModuleNoDependencies(t *testing.T) {
+func TestResolveDiscoveredConfigsOneModuleNoDependencies(t *testing.T) {
 	t.Parallel()
 
 	moduleA := &configstack.TerraformModule{
@@ -225,12 +225,12 @@ func TestResolveTerraformModulesOneModuleNoDependencies(t *testing.T) {
 	expected := configstack.TerraformModules{moduleA}
 
 	stack := configstack.NewStack(mockOptions)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 	require.NoError(t, actualErr, "Unexpected error: %v", actualErr)
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesOneJsonModuleNoDependencies(t *testing.T) {
+func TestResolveDiscoveredConfigsOneJsonModuleNoDependencies(t *testing.T) {
 	t.Parallel()
 
 	moduleA := &configstack.TerraformModule{
@@ -248,12 +248,12 @@ func TestResolveTerraformModulesOneJsonModuleNoDependencies(t *testing.T) {
 	expected := configstack.TerraformModules{moduleA}
 
 	stack := configstack.NewStack(mockOptions)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 	require.NoError(t, actualErr, "Unexpected error: %v", actualErr)
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesOneModuleWithIncludesNoDependencies(t *testing.T) {
+func TestResolveDiscoveredConfigsOneModuleWithIncludesNoDependencies(t *testing.T) {
 	t.Parallel()
 
 	moduleB := &configstack.TerraformModule{
@@ -274,12 +274,12 @@ func TestResolveTerraformModulesOneModuleWithIncludesNoDependencies(t *testing.T
 	expected := configstack.TerraformModules{moduleB}
 
 	stack := configstack.NewStack(mockOptions)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 	require.NoError(t, actualErr, "Unexpected error: %v", actualErr)
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesReadConfigFromParentConfig(t *testing.T) {
+func TestResolveDiscoveredConfigsReadConfigFromParentConfig(t *testing.T) {
 	t.Parallel()
 
 	childDir := "../test/fixtures/modules/module-m/module-m-child"
@@ -359,12 +359,12 @@ func TestResolveTerraformModulesReadConfigFromParentConfig(t *testing.T) {
 	mockOptions.OriginalTerragruntConfigPath = childConfigPath
 
 	stack := configstack.NewStack(mockOptions, configstack.WithChildTerragruntConfig(childTerragruntConfig))
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 	require.NoError(t, actualErr, "Unexpected error: %v", actualErr)
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesOneJsonModuleWithIncludesNoDependencies(t *testing.T) {
+func TestResolveDiscoveredConfigsOneJsonModuleWithIncludesNoDependencies(t *testing.T) {
 	t.Parallel()
 
 	moduleB := &configstack.TerraformModule{
@@ -385,12 +385,12 @@ func TestResolveTerraformModulesOneJsonModuleWithIncludesNoDependencies(t *testi
 	expected := configstack.TerraformModules{moduleB}
 
 	stack := configstack.NewStack(mockOptions)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 	require.NoError(t, actualErr, "Unexpected error: %v", actualErr)
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesOneHclModuleWithIncludesNoDependencies(t *testing.T) {
+func TestResolveDiscoveredConfigsOneHclModuleWithIncludesNoDependencies(t *testing.T) {
 	t.Parallel()
 
 	moduleB := &configstack.TerraformModule{
@@ -411,12 +411,12 @@ func TestResolveTerraformModulesOneHclModuleWithIncludesNoDependencies(t *testin
 	expected := configstack.TerraformModules{moduleB}
 
 	stack := configstack.NewStack(mockOptions)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 	require.NoError(t, actualErr, "Unexpected error: %v", actualErr)
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesTwoModulesWithDependencies(t *testing.T) {
+func TestResolveDiscoveredConfigsTwoModulesWithDependencies(t *testing.T) {
 	t.Parallel()
 
 	moduleA := &configstack.TerraformModule{
@@ -446,12 +446,12 @@ func TestResolveTerraformModulesTwoModulesWithDependencies(t *testing.T) {
 	expected := configstack.TerraformModules{moduleA, moduleC}
 
 	stack := configstack.NewStack(mockOptions)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 	require.NoError(t, actualErr, "Unexpected error: %v", actualErr)
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesJsonModulesWithHclDependencies(t *testing.T) {
+func TestResolveDiscoveredConfigsJsonModulesWithHclDependencies(t *testing.T) {
 	t.Parallel()
 
 	moduleA := &configstack.TerraformModule{
@@ -481,12 +481,12 @@ func TestResolveTerraformModulesJsonModulesWithHclDependencies(t *testing.T) {
 	expected := configstack.TerraformModules{moduleA, moduleC}
 
 	stack := configstack.NewStack(mockOptions)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 	require.NoError(t, actualErr, "Unexpected error: %v", actualErr)
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesHclModulesWithJsonDependencies(t *testing.T) {
+func TestResolveDiscoveredConfigsHclModulesWithJsonDependencies(t *testing.T) {
 	t.Parallel()
 
 	moduleA := &configstack.TerraformModule{
@@ -516,12 +516,12 @@ func TestResolveTerraformModulesHclModulesWithJsonDependencies(t *testing.T) {
 	expected := configstack.TerraformModules{moduleA, moduleC}
 
 	stack := configstack.NewStack(mockOptions)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 	require.NoError(t, actualErr, "Unexpected error: %v", actualErr)
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesTwoModulesWithDependenciesExcludedDirsWithDependency(t *testing.T) {
+func TestResolveDiscoveredConfigsTwoModulesWithDependenciesExcludedDirsWithDependency(t *testing.T) {
 	t.Parallel()
 
 	opts, _ := options.NewTerragruntOptionsForTest("running_module_test")
@@ -548,7 +548,7 @@ func TestResolveTerraformModulesTwoModulesWithDependenciesExcludedDirsWithDepend
 	configPaths := []string{"../test/fixtures/modules/module-a/" + config.DefaultTerragruntConfigPath, "../test/fixtures/modules/module-c/" + config.DefaultTerragruntConfigPath}
 
 	stack := configstack.NewStack(opts)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 
 	// construct the expected list
 	moduleA.FlagExcluded = true
@@ -558,7 +558,7 @@ func TestResolveTerraformModulesTwoModulesWithDependenciesExcludedDirsWithDepend
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesTwoModulesWithDependenciesExcludedDirsWithDependencyAndConflictingNaming(t *testing.T) {
+func TestResolveDiscoveredConfigsTwoModulesWithDependenciesExcludedDirsWithDependencyAndConflictingNaming(t *testing.T) {
 	t.Parallel()
 
 	opts, _ := options.NewTerragruntOptionsForTest("running_module_test")
@@ -597,7 +597,7 @@ func TestResolveTerraformModulesTwoModulesWithDependenciesExcludedDirsWithDepend
 	configPaths := []string{"../test/fixtures/modules/module-a/" + config.DefaultTerragruntConfigPath, "../test/fixtures/modules/module-c/" + config.DefaultTerragruntConfigPath, "../test/fixtures/modules/module-abba/" + config.DefaultTerragruntConfigPath}
 
 	stack := configstack.NewStack(opts)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 
 	// construct the expected list
 	moduleA.FlagExcluded = true
@@ -607,7 +607,7 @@ func TestResolveTerraformModulesTwoModulesWithDependenciesExcludedDirsWithDepend
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesTwoModulesWithDependenciesExcludedDirsWithDependencyAndConflictingNamingAndGlob(t *testing.T) {
+func TestResolveDiscoveredConfigsTwoModulesWithDependenciesExcludedDirsWithDependencyAndConflictingNamingAndGlob(t *testing.T) {
 	t.Parallel()
 
 	opts, _ := options.NewTerragruntOptionsForTest("running_module_test")
@@ -640,7 +640,7 @@ func TestResolveTerraformModulesTwoModulesWithDependenciesExcludedDirsWithDepend
 	configPaths := []string{"../test/fixtures/modules/module-a/" + config.DefaultTerragruntConfigPath, "../test/fixtures/modules/module-c/" + config.DefaultTerragruntConfigPath, "../test/fixtures/modules/module-abba/" + config.DefaultTerragruntConfigPath}
 
 	stack := configstack.NewStack(opts)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 	// construct the expected list
 	moduleA.FlagExcluded = true
 	moduleAbba.FlagExcluded = true
@@ -650,7 +650,7 @@ func TestResolveTerraformModulesTwoModulesWithDependenciesExcludedDirsWithDepend
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesTwoModulesWithDependenciesExcludedDirsWithNoDependency(t *testing.T) {
+func TestResolveDiscoveredConfigsTwoModulesWithDependenciesExcludedDirsWithNoDependency(t *testing.T) {
 	t.Parallel()
 
 	opts, _ := options.NewTerragruntOptionsForTest("running_module_test")
@@ -675,7 +675,7 @@ func TestResolveTerraformModulesTwoModulesWithDependenciesExcludedDirsWithNoDepe
 	configPaths := []string{"../test/fixtures/modules/module-a/" + config.DefaultTerragruntConfigPath, "../test/fixtures/modules/module-c/" + config.DefaultTerragruntConfigPath}
 
 	stack := configstack.NewStack(opts)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 
 	// construct the expected list
 	moduleC.FlagExcluded = true
@@ -685,7 +685,7 @@ func TestResolveTerraformModulesTwoModulesWithDependenciesExcludedDirsWithNoDepe
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesTwoModulesWithDependenciesIncludedDirsWithDependency(t *testing.T) {
+func TestResolveDiscoveredConfigsTwoModulesWithDependenciesIncludedDirsWithDependency(t *testing.T) {
 	t.Parallel()
 
 	opts, _ := options.NewTerragruntOptionsForTest("running_module_test")
@@ -717,7 +717,7 @@ func TestResolveTerraformModulesTwoModulesWithDependenciesIncludedDirsWithDepend
 	configPaths := []string{"../test/fixtures/modules/module-a/" + config.DefaultTerragruntConfigPath, "../test/fixtures/modules/module-c/" + config.DefaultTerragruntConfigPath}
 
 	stack := configstack.NewStack(opts)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 
 	// construct the expected list
 	moduleA.FlagExcluded = false
@@ -727,7 +727,7 @@ func TestResolveTerraformModulesTwoModulesWithDependenciesIncludedDirsWithDepend
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesTwoModulesWithDependenciesIncludedDirsWithNoDependency(t *testing.T) {
+func TestResolveDiscoveredConfigsTwoModulesWithDependenciesIncludedDirsWithNoDependency(t *testing.T) {
 	t.Parallel()
 
 	opts, _ := options.NewTerragruntOptionsForTest("running_module_test")
@@ -760,7 +760,7 @@ func TestResolveTerraformModulesTwoModulesWithDependenciesIncludedDirsWithNoDepe
 	configPaths := []string{"../test/fixtures/modules/module-a/" + config.DefaultTerragruntConfigPath, "../test/fixtures/modules/module-c/" + config.DefaultTerragruntConfigPath}
 
 	stack := configstack.NewStack(opts)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 
 	// construct the expected list
 	moduleC.FlagExcluded = true
@@ -770,7 +770,7 @@ func TestResolveTerraformModulesTwoModulesWithDependenciesIncludedDirsWithNoDepe
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesTwoModulesWithDependenciesIncludedDirsWithDependencyExcludeModuleWithNoDependency(t *testing.T) {
+func TestResolveDiscoveredConfigsTwoModulesWithDependenciesIncludedDirsWithDependencyExcludeModuleWithNoDependency(t *testing.T) {
 	t.Parallel()
 
 	opts, _ := options.NewTerragruntOptionsForTest("running_module_test")
@@ -810,7 +810,7 @@ func TestResolveTerraformModulesTwoModulesWithDependenciesIncludedDirsWithDepend
 	configPaths := []string{"../test/fixtures/modules/module-a/" + config.DefaultTerragruntConfigPath, "../test/fixtures/modules/module-c/" + config.DefaultTerragruntConfigPath, "../test/fixtures/modules/module-f/" + config.DefaultTerragruntConfigPath}
 
 	stack := configstack.NewStack(opts)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 
 	// construct the expected list
 	moduleF.FlagExcluded = true
@@ -820,7 +820,7 @@ func TestResolveTerraformModulesTwoModulesWithDependenciesIncludedDirsWithDepend
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesMultipleModulesWithDependencies(t *testing.T) {
+func TestResolveDiscoveredConfigsMultipleModulesWithDependencies(t *testing.T) {
 	t.Parallel()
 
 	moduleA := &configstack.TerraformModule{
@@ -875,12 +875,12 @@ func TestResolveTerraformModulesMultipleModulesWithDependencies(t *testing.T) {
 	expected := configstack.TerraformModules{moduleA, moduleB, moduleC, moduleD}
 
 	stack := configstack.NewStack(mockOptions)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 	require.NoError(t, actualErr, "Unexpected error: %v", actualErr)
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesMultipleModulesWithMixedDependencies(t *testing.T) {
+func TestResolveDiscoveredConfigsMultipleModulesWithMixedDependencies(t *testing.T) {
 	t.Parallel()
 
 	moduleA := &configstack.TerraformModule{
@@ -935,12 +935,12 @@ func TestResolveTerraformModulesMultipleModulesWithMixedDependencies(t *testing.
 	expected := configstack.TerraformModules{moduleA, moduleB, moduleC, moduleD}
 
 	stack := configstack.NewStack(mockOptions)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 	require.NoError(t, actualErr, "Unexpected error: %v", actualErr)
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesMultipleModulesWithDependenciesWithIncludes(t *testing.T) {
+func TestResolveDiscoveredConfigsMultipleModulesWithDependenciesWithIncludes(t *testing.T) {
 	t.Parallel()
 
 	moduleA := &configstack.TerraformModule{
@@ -987,12 +987,12 @@ func TestResolveTerraformModulesMultipleModulesWithDependenciesWithIncludes(t *t
 	expected := configstack.TerraformModules{moduleA, moduleB, moduleE}
 
 	stack := configstack.NewStack(mockOptions)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 	require.NoError(t, actualErr, "Unexpected error: %v", actualErr)
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesMultipleModulesWithExternalDependencies(t *testing.T) {
+func TestResolveDiscoveredConfigsMultipleModulesWithExternalDependencies(t *testing.T) {
 	t.Parallel()
 
 	moduleF := &configstack.TerraformModule{
@@ -1022,12 +1022,12 @@ func TestResolveTerraformModulesMultipleModulesWithExternalDependencies(t *testi
 	expected := configstack.TerraformModules{moduleF, moduleG}
 
 	stack := configstack.NewStack(mockOptions)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 	require.NoError(t, actualErr, "Unexpected error: %v", actualErr)
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesMultipleModulesWithNestedExternalDependencies(t *testing.T) {
+func TestResolveDiscoveredConfigsMultipleModulesWithNestedExternalDependencies(t *testing.T) {
 	t.Parallel()
 
 	moduleH := &configstack.TerraformModule{
@@ -1081,18 +1081,18 @@ func TestResolveTerraformModulesMultipleModulesWithNestedExternalDependencies(t
 	expected := configstack.TerraformModules{moduleH, moduleI, moduleJ, moduleK}
 
 	stack := configstack.NewStack(mockOptions)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 	require.NoError(t, actualErr)
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
-func TestResolveTerraformModulesInvalidPaths(t *testing.T) {
+func TestResolveDiscoveredConfigsInvalidPaths(t *testing.T) {
 	t.Parallel()
 
 	configPaths := []string{"../test/fixtures/modules/module-missing-dependency/" + config.DefaultTerragruntConfigPath}
 
 	stack := configstack.NewStack(mockOptions)
-	_, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	_, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 	require.Error(t, actualErr)
 
 	var processingModuleError configstack.ProcessingModuleError
@@ -1109,14 +1109,14 @@ func TestResolveTerraformModulesInvalidPaths(t *testing.T) {
 	require.True(t, os.IsNotExist(unwrapped), "Expected a file not exists error but got %v", processingModuleError.UnderlyingError)
 }
 
-func TestResolveTerraformModuleNoTerraformConfig(t *testing.T) {
+func TestResolveDiscoveredConfigsNoTerraformConfig(t *testing.T) {
 	t.Parallel()
 
 	configPaths := []string{"../test/fixtures/modules/module-l/" + config.DefaultTerragruntConfigPath}
 	expected := configstack.TerraformModules{}
 
 	stack := configstack.NewStack(mockOptions)
-	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	actualModules, actualErr := stack.ResolveDiscoveredConfigs(context.Background(), discoveredUnits(t, configPaths))
 	require.NoError(t, actualErr, "Unexpected error: %v", actualErr)
 	assertModuleListsEqual(t, expected, actualModules)
 }
@@ -1198,3 +1198,29 @@ func TestCircularDependencies(t *testing.T) {
 		t.Errorf("Expected %v, got %v", expected, result)
 	}
 }
+
+func TestStackRunFromPlanDir(t *testing.T) {
+	t.Parallel()
+
+	opts := newFailFastTestOptions(t)
+	opts.FromPlanDir = "plans"
+
+	var args []string
+
+	app := newRunnerTestModule(t, opts, "app", func(_ context.Context, opts *options.TerragruntOptions) error {
+		args = opts.TerraformCliArgs
+		return nil
+	})
+
+	stack := configstack.NewStack(opts)
+	stack.Modules = configstack.TerraformModules{app}
+
+	require.NoError(t, stack.Run(context.Background(), opts))
+	require.Equal(t, []string{tf.CommandNameApply, "-auto-approve", "-input=false", filepath.Join(opts.WorkingDir, "plans", "app", tf.TerraformPlanFile)}, args)
+
+	opts.TerraformCommand = tf.CommandNameDestroy
+	opts.TerraformCliArgs = []string{tf.CommandNameDestroy}
+
+	err := stack.Run(context.Background(), opts)
+	require.ErrorAs(t, err, new(configstack.FromPlanDirWithoutApplyError))
+}
@@ -2,11 +2,13 @@ package configstack_test
 
 import (
 	"context"
+	"path/filepath"
 	"sort"
 	"testing"
 
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/internal/discovery"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/util"
@@ -176,6 +178,24 @@ func canonical(t *testing.T, path string) string {
 	return out
 }
 
+// Return the discovered units of the given Terragrunt config files
+func discoveredUnits(t *testing.T, terragruntConfigPaths []string) discovery.DiscoveredConfigs {
+	t.Helper()
+
+	units := make(discovery.DiscoveredConfigs, 0, len(terragruntConfigPaths))
+
+	for _, terragruntConfigPath := range terragruntConfigPaths {
+		unit := &discovery.DiscoveredConfig{Type: discovery.ConfigTypeUnit, Path: canonical(t, filepath.Dir(terragruntConfigPath))}
+		if filename := filepath.Base(terragruntConfigPath); filename != config.DefaultTerragruntConfigPath {
+			unit.Filename = filename
+		}
+
+		units = append(units, unit)
+	}
+
+	return units
+}
+
 func globCanonical(t *testing.T, path string) []string {
 	t.Helper()
 
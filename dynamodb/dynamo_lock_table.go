@@ -2,6 +2,7 @@
 package dynamodb
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -187,6 +188,72 @@ func DeleteTable(tableName string, dbClient *dynamodb.DynamoDB) error {
 	return req.Send()
 }
 
+// AttrLockInfo is the name of the attribute in which OpenTofu/Terraform stores the JSON info of a held lock.
+const AttrLockInfo = "Info"
+
+// GetLockInfo returns the lock info stored in the lock table for the given lock ID, or an empty string if the lock is
+// not currently held.
+func GetLockInfo(ctx context.Context, tableName string, lockID string, client *dynamodb.DynamoDB) (string, error) {
+	output, err := client.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(tableName),
+		ConsistentRead: aws.Bool(true),
+		Key: map[string]*dynamodb.AttributeValue{
+			AttrLockID: {S: aws.String(lockID)},
+		},
+	})
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	if info, ok := output.Item[AttrLockInfo]; ok {
+		return aws.StringValue(info.S), nil
+	}
+
+	return "", nil
+}
+
+// DeleteLock removes the item of the given lock ID from the lock table, releasing the lock.
+func DeleteLock(ctx context.Context, tableName string, lockID string, client *dynamodb.DynamoDB) error {
+	_, err := client.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			AttrLockID: {S: aws.String(lockID)},
+		},
+	})
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// DeleteLockIfHeld removes the item of the given lock ID from the lock table, releasing the lock, only if the lock info
+// of the item still holds the given ID of the lock holder. Returns false if the item holds another ID, e.g. because the
+// lock was released and acquired again by another holder. Deleting an item that does not exist succeeds.
+func DeleteLockIfHeld(ctx context.Context, tableName string, lockID string, holderID string, client *dynamodb.DynamoDB) (bool, error) {
+	_, err := client.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			AttrLockID: {S: aws.String(lockID)},
+		},
+		ConditionExpression:      aws.String("attribute_not_exists(#info) OR contains(#info, :holder)"),
+		ExpressionAttributeNames: map[string]*string{"#info": aws.String(AttrLockInfo)},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":holder": {S: aws.String(holderID)},
+		},
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return false, nil
+		}
+
+		return false, errors.New(err)
+	}
+
+	return true, nil
+}
+
 type DeleteTableRetryer struct {
 	client.DefaultRetryer
 }
@@ -4,6 +4,7 @@ import (
 	libflag "flag"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/urfave/cli/v2"
@@ -13,7 +14,7 @@ import (
 var _ Flag = new(GenericFlag[string])
 
 type GenericType interface {
-	string | int | int64 | uint
+	string | int | int64 | uint | time.Duration
 }
 
 type GenericFlag[T GenericType] struct {
@@ -209,6 +210,14 @@ func (val *genericVar[T]) Set(str string) error {
 
 		*dest = v
 
+	case *time.Duration:
+		v, err := time.ParseDuration(str)
+		if err != nil {
+			return errors.New(InvalidValueError{underlyingError: err, msg: "must be a duration, such as 30s, 10m or 2h"})
+		}
+
+		*dest = v
+
 	default:
 		return errors.Errorf("flag type %T is undefined", dest)
 	}
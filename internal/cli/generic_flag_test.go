@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/gruntwork-io/terragrunt/internal/cli"
 	"github.com/stretchr/testify/assert"
@@ -154,6 +155,51 @@ func TestGenericFlagInt64Apply(t *testing.T) {
 	}
 }
 
+func TestGenericFlagDurationApply(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		expectedErr   error
+		envs          map[string]string
+		args          []string
+		flag          cli.GenericFlag[time.Duration]
+		expectedValue time.Duration
+	}{
+		{
+			flag:          cli.GenericFlag[time.Duration]{Name: "foo", EnvVars: []string{"FOO"}},
+			args:          []string{"--foo", "10m"},
+			envs:          map[string]string{"FOO": "20s"},
+			expectedValue: 10 * time.Minute,
+		},
+		{
+			flag:          cli.GenericFlag[time.Duration]{Name: "foo", EnvVars: []string{"FOO"}},
+			args:          []string{},
+			envs:          map[string]string{"FOO": "20s"},
+			expectedValue: 20 * time.Second,
+		},
+		{
+			flag:        cli.GenericFlag[time.Duration]{Name: "foo", EnvVars: []string{"FOO"}},
+			args:        []string{},
+			envs:        map[string]string{"FOO": "monkey"},
+			expectedErr: errors.New(`invalid value "monkey" for env var FOO: must be a duration, such as 30s, 10m or 2h`),
+		},
+		{
+			flag:          cli.GenericFlag[time.Duration]{Name: "foo", Destination: mockDestValue(time.Hour)},
+			expectedValue: time.Hour,
+		},
+	}
+
+	for i, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(fmt.Sprintf("testCase-%d", i), func(t *testing.T) {
+			t.Parallel()
+
+			testGenericFlagApply(t, &testCase.flag, testCase.args, testCase.envs, testCase.expectedValue, testCase.expectedErr)
+		})
+	}
+}
+
 func testGenericFlagApply[T cli.GenericType](t *testing.T, flag *cli.GenericFlag[T], args []string, envs map[string]string, expectedValue T, expectedErr error) {
 	t.Helper()
 
@@ -6,12 +6,17 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/experiment"
 	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/puzpuzpuz/xsync/v3"
 )
 
 const (
@@ -39,9 +44,23 @@ type DiscoveredConfig struct {
 	Type ConfigType
 	Path string
 
+	// Filename is the name of the configuration file of the unit in Path, if it is not `terragrunt.hcl`.
+	Filename string
+
 	Dependencies DiscoveredConfigs
 	Exclude      Exclude
 
+	// Scheduling is the `scheduling` block of the configuration, discovered along with its dependencies.
+	Scheduling *config.SchedulingConfig
+
+	// Reading are the absolute paths of the files read by the configuration: the included configurations, and the
+	// files read with HCL functions while parsing. Only discovered with WithReadFiles.
+	Reading []string
+
+	// SourceDir is the directory of the local `terraform.source` of the unit, such as `modules` for a source of
+	// `../modules//vpc`, if any. Only discovered with WithReadFiles.
+	SourceDir string
+
 	External bool
 }
 
@@ -59,6 +78,9 @@ type Discovery struct {
 	// hiddenDirMemo is a memoization of hidden directories.
 	hiddenDirMemo []string
 
+	// configFilenames are the names of the configuration files of units, by order of precedence.
+	configFilenames []string
+
 	// maxDependencyDepth is the maximum depth of the dependency tree to discover.
 	maxDependencyDepth int
 
@@ -70,6 +92,12 @@ type Discovery struct {
 
 	// discoverExternalDependencies determines whether to discover external dependencies.
 	discoverExternalDependencies bool
+
+	// moduleDirsOnly determines whether to skip the directories that can not contain units.
+	moduleDirsOnly bool
+
+	// readFiles determines whether to discover the files read by the configurations.
+	readFiles bool
 }
 
 // DiscoveryOption is a function that modifies a Discovery.
@@ -78,8 +106,9 @@ type DiscoveryOption func(*Discovery)
 // NewDiscovery creates a new Discovery.
 func NewDiscovery(dir string, opts ...DiscoveryOption) *Discovery {
 	discovery := &Discovery{
-		workingDir: dir,
-		hidden:     false,
+		workingDir:      dir,
+		hidden:          false,
+		configFilenames: []string{config.DefaultTerragruntConfigPath},
 	}
 
 	for _, opt := range opts {
@@ -128,11 +157,47 @@ func (d *Discovery) WithDiscoverExternalDependencies() *Discovery {
 	return d
 }
 
+// WithConfigFilenames sets the names of the configuration files of units, by order of precedence when a directory
+// contains several of them.
+func (d *Discovery) WithConfigFilenames(filenames ...string) *Discovery {
+	d.configFilenames = filenames
+
+	return d
+}
+
+// WithModuleDirsOnly skips the directories that can not contain units: the Terragrunt cache, the OpenTofu/Terraform
+// data dir and the download dir.
+func (d *Discovery) WithModuleDirsOnly() *Discovery {
+	d.moduleDirsOnly = true
+
+	return d
+}
+
+// WithReadFiles discovers the files read by the configurations and their local Terraform sources, along with their
+// dependencies.
+func (d *Discovery) WithReadFiles() *Discovery {
+	d.readFiles = true
+
+	return d.WithDiscoverDependencies()
+}
+
 // String returns a string representation of a DiscoveredConfig.
 func (c *DiscoveredConfig) String() string {
 	return c.Path
 }
 
+// ConfigPath returns the path of the configuration file of the DiscoveredConfig.
+func (c *DiscoveredConfig) ConfigPath() string {
+	switch {
+	case c.Type == ConfigTypeStack:
+		return filepath.Join(c.Path, config.DefaultStackFile)
+	case c.Filename != "":
+		return filepath.Join(c.Path, c.Filename)
+	default:
+		return filepath.Join(c.Path, config.DefaultTerragruntConfigPath)
+	}
+}
+
 // ContainsDependencyInAncestry returns true if the DiscoveredConfig or any of
 // its dependencies contains the given path as a dependency.
 func (c *DiscoveredConfig) ContainsDependencyInAncestry(path string) bool {
@@ -177,12 +242,25 @@ func (d *Discovery) isInHiddenDirectory(path string) bool {
 func (d *Discovery) Discover(ctx context.Context, opts *options.TerragruntOptions) (DiscoveredConfigs, error) {
 	var cfgs DiscoveredConfigs
 
+	// units maps the directories of the discovered units to their configuration, as a directory contains one unit only.
+	units := map[string]*DiscoveredConfig{}
+
 	walkFn := func(path string, e fs.DirEntry, err error) error {
 		if err != nil {
 			return errors.New(err)
 		}
 
 		if e.IsDir() {
+			if !d.moduleDirsOnly {
+				return nil
+			}
+
+			if ok, err := config.IsTerragruntModuleDir(path, opts); err != nil {
+				return err
+			} else if !ok {
+				return filepath.SkipDir
+			}
+
 			return nil
 		}
 
@@ -190,29 +268,61 @@ func (d *Discovery) Discover(ctx context.Context, opts *options.TerragruntOption
 			return nil
 		}
 
-		switch filepath.Base(path) {
-		case config.DefaultTerragruntConfigPath:
-			cfgs = append(cfgs, &DiscoveredConfig{
-				Type: ConfigTypeUnit,
-				Path: filepath.Dir(path),
-			})
-		case config.DefaultStackFile:
+		filename := filepath.Base(path)
+
+		if filename == config.DefaultStackFile {
 			cfgs = append(cfgs, &DiscoveredConfig{
 				Type: ConfigTypeStack,
 				Path: filepath.Dir(path),
 			})
+
+			return nil
+		}
+
+		precedence := slices.Index(d.configFilenames, filename)
+		if precedence < 0 {
+			return nil
+		}
+
+		if filename == config.DefaultTerragruntConfigPath {
+			filename = ""
 		}
 
+		if unit, ok := units[filepath.Dir(path)]; ok {
+			if precedence < slices.Index(d.configFilenames, filepath.Base(unit.ConfigPath())) {
+				unit.Filename = filename
+			}
+
+			return nil
+		}
+
+		unit := &DiscoveredConfig{
+			Type:     ConfigTypeUnit,
+			Path:     filepath.Dir(path),
+			Filename: filename,
+		}
+		units[unit.Path] = unit
+		cfgs = append(cfgs, unit)
+
 		return nil
 	}
 
-	if err := filepath.WalkDir(d.workingDir, walkFn); err != nil {
+	walk := filepath.WalkDir
+	if opts != nil && opts.Experiments.Evaluate(experiment.Symlinks) {
+		walk = walkDirWithSymlinks
+	}
+
+	if err := walk(d.workingDir, walkFn); err != nil {
 		return cfgs, errors.New(err)
 	}
 
 	if d.discoverDependencies {
 		dependencyDiscovery := NewDependencyDiscovery(cfgs, d.maxDependencyDepth, d.discoverExternalDependencies)
 
+		if d.readFiles {
+			dependencyDiscovery = dependencyDiscovery.WithReadFiles()
+		}
+
 		err := dependencyDiscovery.DiscoverAllDependencies(ctx, opts)
 		if err != nil {
 			return dependencyDiscovery.cfgs, errors.New(err)
@@ -228,10 +338,24 @@ func (d *Discovery) Discover(ctx context.Context, opts *options.TerragruntOption
 	return cfgs, nil
 }
 
+// walkDirWithSymlinks walks the file tree rooted at root like filepath.WalkDir, following the symlinks to directories.
+func walkDirWithSymlinks(root string, fn fs.WalkDirFunc) error {
+	return util.WalkWithSymlinks(root, func(path string, info os.FileInfo, err error) error {
+		return fn(path, fs.FileInfoToDirEntry(info), err)
+	})
+}
+
+// ParseFunc parses the configuration of the given discovered configuration, with options whose working dir and config
+// path are the ones of the configuration. The dependencies of a configuration parsed into a nil config are not
+// discovered.
+type ParseFunc func(ctx context.Context, opts *options.TerragruntOptions, cfg *DiscoveredConfig) (*config.TerragruntConfig, error)
+
 type DependencyDiscovery struct {
+	parseFunc        ParseFunc
 	cfgs             DiscoveredConfigs
 	depthRemaining   int
 	discoverExternal bool
+	readFiles        bool
 }
 
 func NewDependencyDiscovery(cfgs DiscoveredConfigs, depthRemaining int, discoverExternal bool) *DependencyDiscovery {
@@ -242,6 +366,26 @@ func NewDependencyDiscovery(cfgs DiscoveredConfigs, depthRemaining int, discover
 	}
 }
 
+// WithReadFiles discovers the files read by the configurations and their local Terraform sources as well.
+func (d *DependencyDiscovery) WithReadFiles() *DependencyDiscovery {
+	d.readFiles = true
+
+	return d
+}
+
+// WithParseFunc parses the configurations with the given function, rather than partially parsing the blocks needed
+// to discover their dependencies.
+func (d *DependencyDiscovery) WithParseFunc(parseFunc ParseFunc) *DependencyDiscovery {
+	d.parseFunc = parseFunc
+
+	return d
+}
+
+// Configs returns the discovered configurations, along with the external dependencies discovered with them.
+func (d *DependencyDiscovery) Configs() DiscoveredConfigs {
+	return d.cfgs
+}
+
 func (d *DependencyDiscovery) DiscoverAllDependencies(ctx context.Context, opts *options.TerragruntOptions) error {
 	errs := []error{}
 
@@ -272,22 +416,25 @@ func (d *DependencyDiscovery) DiscoverDependencies(ctx context.Context, opts *op
 	opts.WorkingDir = dCfg.Path
 
 	if dCfg.Type == ConfigTypeUnit {
-		opts.TerragruntConfigPath = filepath.Join(opts.WorkingDir, config.DefaultTerragruntConfigPath)
+		opts.TerragruntConfigPath = dCfg.ConfigPath()
 	}
 
-	parsingCtx := config.NewParsingContext(ctx, opts).WithDecodeList(
-		config.DependenciesBlock,
-		config.DependencyBlock,
-		config.FeatureFlagsBlock,
-		config.ExcludeBlock,
-	)
+	parseFunc := d.parseFunc
+	if parseFunc == nil {
+		parseFunc = d.parse
+	}
 
-	//nolint: contextcheck
-	cfg, err := config.PartialParseConfigFile(parsingCtx, opts.TerragruntConfigPath, nil)
+	cfg, err := parseFunc(ctx, opts, dCfg)
 	if err != nil {
 		return errors.New(err)
 	}
 
+	if cfg == nil {
+		return nil
+	}
+
+	dCfg.Scheduling = cfg.Scheduling
+
 	dependencyBlocks := cfg.TerragruntDependencies
 
 	depPaths := make([]string, 0, len(dependencyBlocks))
@@ -295,22 +442,12 @@ func (d *DependencyDiscovery) DiscoverDependencies(ctx context.Context, opts *op
 	errs := []error{}
 
 	for _, dependency := range dependencyBlocks {
-		depPath := dependency.ConfigPath.AsString()
-
-		if !filepath.IsAbs(depPath) {
-			depPath = filepath.Join(opts.WorkingDir, depPath)
-		}
-
-		depPaths = append(depPaths, depPath)
+		depPaths = append(depPaths, dependencyDir(dependency.ConfigPath.AsString(), opts.WorkingDir))
 	}
 
 	if cfg.Dependencies != nil {
 		for _, dependency := range cfg.Dependencies.Paths {
-			if !filepath.IsAbs(dependency) {
-				dependency = filepath.Join(opts.WorkingDir, dependency)
-			}
-
-			depPaths = append(depPaths, dependency)
+			depPaths = append(depPaths, dependencyDir(dependency, opts.WorkingDir))
 		}
 	}
 
@@ -318,13 +455,10 @@ func (d *DependencyDiscovery) DiscoverDependencies(ctx context.Context, opts *op
 		return errors.Join(errs...)
 	}
 
-	deduped := make(map[string]struct{}, len(depPaths))
-
-	for _, depPath := range depPaths {
-		deduped[depPath] = struct{}{}
-	}
+	// Discover the dependencies in a stable order, so that the external dependencies are discovered in the same order.
+	slices.Sort(depPaths)
 
-	for depPath := range deduped {
+	for _, depPath := range slices.Compact(depPaths) {
 		external := true
 
 		for _, c := range d.cfgs {
@@ -344,6 +478,10 @@ func (d *DependencyDiscovery) DiscoverDependencies(ctx context.Context, opts *op
 				External: true,
 			}
 
+			if filename := filepath.Base(config.GetDefaultConfigPath(depPath)); filename != config.DefaultTerragruntConfigPath {
+				ext.Filename = filename
+			}
+
 			dCfg.Dependencies = append(dCfg.Dependencies, ext)
 
 			if d.discoverExternal {
@@ -364,6 +502,151 @@ func (d *DependencyDiscovery) DiscoverDependencies(ctx context.Context, opts *op
 	return nil
 }
 
+// parse partially parses the blocks of the given configuration needed to discover its dependencies, and the files it
+// reads with WithReadFiles.
+func (d *DependencyDiscovery) parse(ctx context.Context, opts *options.TerragruntOptions, dCfg *DiscoveredConfig) (*config.TerragruntConfig, error) {
+	decodeList := []config.PartialDecodeSectionType{
+		config.DependenciesBlock,
+		config.DependencyBlock,
+		config.FeatureFlagsBlock,
+		config.ExcludeBlock,
+		config.SchedulingBlock,
+	}
+
+	if d.readFiles {
+		decodeList = append(decodeList, config.TerraformSource)
+		// Record only the files read while parsing this configuration.
+		opts.ReadFiles = xsync.NewMapOf[string, []string]()
+	}
+
+	parsingCtx := config.NewParsingContext(ctx, opts).WithDecodeList(decodeList...)
+
+	//nolint: contextcheck
+	cfg, err := config.PartialParseConfigFile(parsingCtx, opts.TerragruntConfigPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.readFiles {
+		dCfg.Reading = readFiles(opts, cfg)
+		dCfg.SourceDir = localSourceDir(opts, cfg)
+	}
+
+	return cfg, nil
+}
+
+// dependencyDir returns the directory of the unit of the given dependency path, relative to the given working dir. The
+// path is either the directory of the unit, or the path of its configuration file.
+func dependencyDir(path, workingDir string) string {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(workingDir, path)
+	}
+
+	if util.FileExists(path) && !util.IsDir(path) {
+		return filepath.Dir(path)
+	}
+
+	return filepath.Clean(path)
+}
+
+// readFiles returns the absolute paths of the files included or read with HCL functions by the given parsed
+// configuration.
+func readFiles(opts *options.TerragruntOptions, cfg *config.TerragruntConfig) []string {
+	var files []string
+
+	for _, include := range cfg.ProcessedIncludes {
+		if path, err := util.CanonicalPath(include.Path, opts.WorkingDir); err == nil {
+			files = append(files, path)
+		}
+	}
+
+	opts.ReadFiles.Range(func(file string, _ []string) bool {
+		files = append(files, file)
+		return true
+	})
+
+	sort.Strings(files)
+
+	return slices.Compact(files)
+}
+
+// localSourceDir returns the directory copied by Terragrunt for the Terraform source of the given parsed
+// configuration, if the source is a local path, such as `../modules//vpc`, and an empty string otherwise.
+func localSourceDir(opts *options.TerragruntOptions, cfg *config.TerragruntConfig) string {
+	if cfg.Terraform == nil || cfg.Terraform.Source == nil {
+		return ""
+	}
+
+	sourceURL, err := tf.ToSourceURL(*cfg.Terraform.Source, opts.WorkingDir)
+	if err != nil || !tf.IsLocalSource(sourceURL) {
+		return ""
+	}
+
+	rootSourceURL, _, err := tf.SplitSourceURL(sourceURL, opts.Logger)
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Clean(rootSourceURL.Path)
+}
+
+// AffectedBy returns the units affected by the given changed files, along with the units depending on them, directly
+// or through other dependencies. A unit is affected by the files in its directory, except the files of nested units,
+// by the files it reads and by the files of its local Terraform source. The files read and the sources are only known
+// for configurations discovered with WithReadFiles.
+func (c DiscoveredConfigs) AffectedBy(files []string) DiscoveredConfigs {
+	units := c.Filter(ConfigTypeUnit)
+	affected := make(map[string]bool, len(units))
+
+	for _, file := range files {
+		var owner *DiscoveredConfig
+
+		for _, unit := range units {
+			if util.HasPathPrefix(file, unit.Path) && (owner == nil || len(unit.Path) > len(owner.Path)) {
+				owner = unit
+			}
+
+			if slices.Contains(unit.Reading, file) || (unit.SourceDir != "" && util.HasPathPrefix(file, unit.SourceDir)) {
+				affected[unit.Path] = true
+			}
+		}
+
+		if owner != nil {
+			affected[owner.Path] = true
+		}
+	}
+
+	// Mark the units depending on the affected units, directly or through other dependencies, as affected as well.
+	for found := true; found; {
+		found = false
+
+		for _, unit := range units {
+			if affected[unit.Path] {
+				continue
+			}
+
+			for _, dependency := range unit.Dependencies {
+				if affected[dependency.Path] {
+					affected[unit.Path] = true
+					found = true
+
+					break
+				}
+			}
+		}
+	}
+
+	result := make(DiscoveredConfigs, 0, len(affected))
+
+	for _, unit := range units {
+		if affected[unit.Path] {
+			result = append(result, unit)
+		}
+	}
+
+	return result
+}
+
 // Sort sorts the DiscoveredConfigs by path.
 func (c DiscoveredConfigs) Sort() DiscoveredConfigs {
 	sort.Slice(c, func(i, j int) bool {
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/discovery"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/stretchr/testify/assert"
@@ -91,6 +92,127 @@ func TestDiscovery(t *testing.T) {
 	}
 }
 
+func TestDiscoveryConfigFilenames(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	testFiles := map[string]string{
+		filepath.Join(tmpDir, "hcl", "terragrunt.hcl"):                             "",
+		filepath.Join(tmpDir, "json", "terragrunt.hcl.json"):                       "{}",
+		filepath.Join(tmpDir, "both", "terragrunt.hcl"):                            "",
+		filepath.Join(tmpDir, "both", "terragrunt.hcl.json"):                       "{}",
+		filepath.Join(tmpDir, "hcl", ".terragrunt-cache", "abc", "terragrunt.hcl"): "",
+		filepath.Join(tmpDir, "hcl", ".terraform", "modules", "terragrunt.hcl"):    "",
+		filepath.Join(tmpDir, ".github", "unit", "terragrunt.hcl"):                 "",
+	}
+
+	for path, content := range testFiles {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(tmpDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.DownloadDir = filepath.Join(tmpDir, ".terragrunt-cache")
+
+	configs, err := discovery.NewDiscovery(tmpDir).
+		WithHidden().
+		WithModuleDirsOnly().
+		WithConfigFilenames("terragrunt.hcl.json", "terragrunt.hcl").
+		Discover(context.Background(), opts)
+	require.NoError(t, err)
+
+	configPaths := []string{}
+	for _, config := range configs {
+		configPaths = append(configPaths, config.ConfigPath())
+	}
+
+	assert.ElementsMatch(t, []string{
+		filepath.Join(tmpDir, "hcl", "terragrunt.hcl"),
+		filepath.Join(tmpDir, "json", "terragrunt.hcl.json"),
+		filepath.Join(tmpDir, "both", "terragrunt.hcl.json"),
+		filepath.Join(tmpDir, ".github", "unit", "terragrunt.hcl"),
+	}, configPaths)
+}
+
+func TestDiscoveryWithReadFiles(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	testFiles := map[string]string{
+		filepath.Join(tmpDir, "root.hcl"):                  "",
+		filepath.Join(tmpDir, "common.hcl"):                `locals { region = "us-east-1" }`,
+		filepath.Join(tmpDir, "modules", "vpc", "main.tf"): "",
+		filepath.Join(tmpDir, "vpc", "terragrunt.hcl"): `
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+terraform {
+  source = "../modules//vpc"
+}
+`,
+		filepath.Join(tmpDir, "db", "terragrunt.hcl"): `
+locals {
+  common = read_terragrunt_config("../common.hcl")
+}
+`,
+		filepath.Join(tmpDir, "app", "terragrunt.hcl"): `
+dependency "db" {
+  config_path = "../db"
+}
+`,
+	}
+
+	for path, content := range testFiles {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(tmpDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	configs, err := discovery.NewDiscovery(tmpDir).WithReadFiles().Discover(context.Background(), opts)
+	require.NoError(t, err)
+
+	vpc := configs.FilterByPath(filepath.Join(tmpDir, "vpc"))
+	require.Len(t, vpc, 1)
+	assert.Equal(t, []string{filepath.Join(tmpDir, "root.hcl")}, vpc[0].Reading)
+	assert.Equal(t, filepath.Join(tmpDir, "modules"), vpc[0].SourceDir)
+
+	db := configs.FilterByPath(filepath.Join(tmpDir, "db"))
+	require.Len(t, db, 1)
+	assert.Equal(t, []string{filepath.Join(tmpDir, "common.hcl")}, db[0].Reading)
+
+	tc := []struct {
+		name     string
+		file     string
+		expected []string
+	}{
+		{name: "unit file", file: filepath.Join(tmpDir, "vpc", "terragrunt.hcl"), expected: []string{"vpc"}},
+		{name: "include", file: filepath.Join(tmpDir, "root.hcl"), expected: []string{"vpc"}},
+		{name: "local source", file: filepath.Join(tmpDir, "modules", "vpc", "main.tf"), expected: []string{"vpc"}},
+		{name: "read file", file: filepath.Join(tmpDir, "common.hcl"), expected: []string{"app", "db"}},
+		{name: "unrelated file", file: filepath.Join(tmpDir, "README.md"), expected: []string{}},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			units := []string{}
+			for _, unit := range configs.AffectedBy([]string{tt.file}).Sort() {
+				units = append(units, filepath.Base(unit.Path))
+			}
+
+			assert.Equal(t, tt.expected, units)
+		})
+	}
+}
+
 func TestDiscoveredConfigsSort(t *testing.T) {
 	t.Parallel()
 
@@ -282,6 +404,54 @@ func TestDiscoveryWithDependencies(t *testing.T) {
 	}
 }
 
+func TestDependencyDiscoveryWithParseFunc(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	appDir := filepath.Join(tmpDir, "app")
+	dbDir := filepath.Join(tmpDir, "db")
+
+	testFiles := map[string]string{
+		filepath.Join(appDir, "terragrunt.hcl"):     `dependencies { paths = ["../db/terragrunt.hcl.json"] }`,
+		filepath.Join(dbDir, "terragrunt.hcl.json"): `{"dependencies": {"paths": ["../vpc"]}}`,
+	}
+
+	for path, content := range testFiles {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(tmpDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	parsed := []string{}
+
+	app := &discovery.DiscoveredConfig{Path: appDir, Type: discovery.ConfigTypeUnit}
+
+	dependencyDiscovery := discovery.NewDependencyDiscovery(discovery.DiscoveredConfigs{app}, 10, true).
+		WithParseFunc(func(ctx context.Context, opts *options.TerragruntOptions, cfg *discovery.DiscoveredConfig) (*config.TerragruntConfig, error) {
+			parsed = append(parsed, opts.TerragruntConfigPath)
+
+			// The dependencies of "db" are skipped.
+			if cfg.Path == dbDir {
+				return nil, nil
+			}
+
+			parsingCtx := config.NewParsingContext(ctx, opts).WithDecodeList(config.DependenciesBlock)
+
+			return config.PartialParseConfigFile(parsingCtx, opts.TerragruntConfigPath, nil)
+		})
+
+	require.NoError(t, dependencyDiscovery.DiscoverAllDependencies(context.Background(), opts))
+
+	db := &discovery.DiscoveredConfig{Path: dbDir, Type: discovery.ConfigTypeUnit, Filename: "terragrunt.hcl.json", External: true}
+
+	assert.Equal(t, []string{filepath.Join(appDir, "terragrunt.hcl"), filepath.Join(dbDir, "terragrunt.hcl.json")}, parsed)
+	assert.Equal(t, discovery.DiscoveredConfigs{db}, app.Dependencies)
+	assert.Equal(t, discovery.DiscoveredConfigs{app, db}, dependencyDiscovery.Configs())
+}
+
 func TestDiscoveredConfigsCycleCheck(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,41 @@
+package featureflags
+
+import (
+	"context"
+	"strings"
+)
+
+// EnvSource reads feature flags from the environment variables with a prefix. The name of a flag is the rest of the
+// name of its variable in lower case, e.g. `TG_FF_NEW_NETWORK=true` sets the flag `new_network` with the prefix
+// `TG_FF_`.
+type EnvSource struct {
+	env    map[string]string
+	prefix string
+}
+
+// NewEnvSource returns the source of the feature flags read from the given environment variables with the given
+// prefix.
+func NewEnvSource(prefix string, env map[string]string) *EnvSource {
+	return &EnvSource{prefix: prefix, env: env}
+}
+
+// Name implements Source.Name
+func (source *EnvSource) Name() string {
+	return "the environment variables with the prefix " + source.prefix
+}
+
+// Flags implements Source.Flags
+func (source *EnvSource) Flags(_ context.Context) (map[string]string, error) {
+	flags := make(map[string]string)
+
+	for name, value := range source.env {
+		flagName, ok := strings.CutPrefix(name, source.prefix)
+		if !ok || flagName == "" {
+			continue
+		}
+
+		flags[strings.ToLower(flagName)] = value
+	}
+
+	return flags, nil
+}
@@ -0,0 +1,17 @@
+package featureflags
+
+import "fmt"
+
+// SourceError is returned when the feature flags of a source can not be read.
+type SourceError struct {
+	Err    error
+	Source string
+}
+
+func (err SourceError) Error() string {
+	return fmt.Sprintf("unable to read the feature flags of %s: %v", err.Source, err.Err)
+}
+
+func (err SourceError) Unwrap() error {
+	return err.Err
+}
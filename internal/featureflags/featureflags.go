@@ -0,0 +1,132 @@
+// Package featureflags provides the external sources of the values of feature flags: environment variables with a
+// prefix, a JSON or YAML file and an HTTP endpoint. They override the defaults of the `feature` blocks, without
+// changing the configuration or the CLI invocation.
+//
+// The values are resolved once per run, when the first configuration is parsed, and the `--feature` flag always takes
+// precedence over them.
+package featureflags
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/sensitive"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+// Source provides the values of feature flags.
+type Source interface {
+	// Name returns the name of the source, used in logs and errors.
+	Name() string
+	// Flags returns the values of the feature flags of the source by name.
+	Flags(ctx context.Context) (map[string]string, error)
+}
+
+// Sources are feature flag sources in order of precedence, a flag provided by several sources gets the value of the
+// first one.
+type Sources []Source
+
+// NewSources returns the sources configured in the given options, in order of precedence: the environment variables,
+// the file and the HTTP endpoint.
+func NewSources(opts *options.TerragruntOptions) (Sources, error) {
+	var sources Sources
+
+	if opts.FeatureFlagEnvPrefix != "" {
+		sources = append(sources, NewEnvSource(opts.FeatureFlagEnvPrefix, opts.Env))
+	}
+
+	if opts.FeatureFlagFile != "" {
+		sources = append(sources, NewFileSource(opts.FeatureFlagFile))
+	}
+
+	if opts.FeatureFlagURL != "" {
+		header, err := ParseHTTPHeaders(opts.FeatureFlagURLHeaders)
+		if err != nil {
+			return nil, err
+		}
+
+		if opts.FeatureFlagURLToken != "" {
+			sensitive.Register(opts.FeatureFlagURLToken)
+			header.Set("Authorization", "Bearer "+opts.FeatureFlagURLToken)
+		}
+
+		sources = append(sources, NewHTTPSource(opts.FeatureFlagURL, header))
+	}
+
+	return sources, nil
+}
+
+// Resolve returns the values of the feature flags of all the sources.
+func (sources Sources) Resolve(ctx context.Context) (map[string]string, error) {
+	flags := make(map[string]string)
+
+	// Sources are read in reverse order, so that the sources with a higher precedence overwrite the others.
+	for i := len(sources) - 1; i >= 0; i-- {
+		source := sources[i]
+
+		sourceFlags, err := source.Flags(ctx)
+		if err != nil {
+			return nil, errors.New(SourceError{Source: source.Name(), Err: err})
+		}
+
+		for name, value := range sourceFlags {
+			flags[name] = value
+		}
+	}
+
+	return flags, nil
+}
+
+// Load resolves the feature flags of the sources configured in the given options and stores them in the feature
+// flags of the options. The flags set with `--feature` are kept, as they take precedence.
+func Load(ctx context.Context, opts *options.TerragruntOptions) error {
+	sources, err := NewSources(opts)
+	if err != nil {
+		return err
+	}
+
+	if len(sources) == 0 {
+		return nil
+	}
+
+	flags, err := sources.Resolve(ctx)
+	if err != nil {
+		return err
+	}
+
+	for name, value := range flags {
+		if _, loaded := opts.FeatureFlags.LoadOrStore(name, value); loaded {
+			opts.Logger.Debugf("Feature flag %s is set with --feature, ignoring the value of the external sources", name)
+			continue
+		}
+
+		opts.Logger.Debugf("Feature flag %s set to %s by the external sources", name, value)
+	}
+
+	return nil
+}
+
+// flagValuesToStrings converts the decoded values of feature flags to the format of the `--feature` flag: strings are
+// kept as they are, other values are encoded as JSON, e.g. `true` or `42`.
+func flagValuesToStrings(values map[string]any) (map[string]string, error) {
+	flags := make(map[string]string, len(values))
+
+	for name, value := range values {
+		switch value := value.(type) {
+		case string:
+			flags[name] = value
+		case nil:
+			return nil, errors.Errorf("feature flag %s has no value", name)
+		default:
+			content, err := json.Marshal(value)
+			if err != nil {
+				return nil, errors.Errorf("feature flag %s: %w", name, err)
+			}
+
+			flags[name] = string(content)
+		}
+	}
+
+	return flags, nil
+}
@@ -0,0 +1,196 @@
+package featureflags_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/internal/featureflags"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOptions(t *testing.T) *options.TerragruntOptions {
+	t.Helper()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	return opts
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func newTestServer(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+
+		w.WriteHeader(status)
+		w.Write([]byte(body)) //nolint:errcheck
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestSources(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t, http.StatusOK, `{"enabled": true, "replicas": 3, "tier": "gold"}`)
+
+	tc := []struct {
+		source   featureflags.Source
+		expected map[string]string
+		name     string
+	}{
+		{
+			name: "env",
+			source: featureflags.NewEnvSource("TG_FF_", map[string]string{
+				"TG_FF_NEW_NETWORK": "true",
+				"TG_FF_":            "ignored",
+				"OTHER":             "ignored",
+			}),
+			expected: map[string]string{"new_network": "true"},
+		},
+		{
+			name:     "json-file",
+			source:   featureflags.NewFileSource(writeFile(t, "flags.json", `{"enabled": true, "replicas": 3, "tier": "gold"}`)),
+			expected: map[string]string{"enabled": "true", "replicas": "3", "tier": "gold"},
+		},
+		{
+			name:     "yaml-file",
+			source:   featureflags.NewFileSource(writeFile(t, "flags.yaml", "enabled: true\nreplicas: 3\ntier: gold\n")),
+			expected: map[string]string{"enabled": "true", "replicas": "3", "tier": "gold"},
+		},
+		{
+			name:     "http",
+			source:   featureflags.NewHTTPSource(server.URL, nil),
+			expected: map[string]string{"enabled": "true", "replicas": "3", "tier": "gold"},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			flags, err := tt.source.Flags(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, flags)
+		})
+	}
+}
+
+func TestSourcesErrors(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t, http.StatusInternalServerError, `{}`)
+
+	tc := []struct {
+		source featureflags.Source
+		name   string
+	}{
+		{
+			name:   "missing-file",
+			source: featureflags.NewFileSource(filepath.Join(t.TempDir(), "missing.json")),
+		},
+		{
+			name:   "invalid-file",
+			source: featureflags.NewFileSource(writeFile(t, "flags.json", `[true]`)),
+		},
+		{
+			name:   "http-status",
+			source: featureflags.NewHTTPSource(server.URL, nil),
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := featureflags.Sources{tt.source}.Resolve(context.Background())
+			require.Error(t, err)
+			assert.ErrorAs(t, err, &featureflags.SourceError{})
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t, http.StatusOK, `{"cli": "http", "env": "http", "file": "http", "http": "http"}`)
+
+	opts := newTestOptions(t)
+	opts.Env = map[string]string{"TG_FF_CLI": "env", "TG_FF_ENV": "env"}
+	opts.FeatureFlagEnvPrefix = "TG_FF_"
+	opts.FeatureFlagFile = writeFile(t, "flags.yml", "cli: file\nenv: file\nfile: file\n")
+	opts.FeatureFlagURL = server.URL
+	opts.FeatureFlags.Store("cli", "cli")
+
+	require.NoError(t, featureflags.Load(context.Background(), opts))
+
+	// The --feature flag takes precedence over the environment variables, the file and the HTTP endpoint, in this order.
+	for name, expected := range map[string]string{"cli": "cli", "env": "env", "file": "file", "http": "http"} {
+		value, ok := opts.FeatureFlags.Load(name)
+		require.True(t, ok, name)
+		assert.Equal(t, expected, value, name)
+	}
+}
+
+func TestHTTPSourceHeaders(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret-token" || r.Header.Get("X-Environment") != "prod" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Write([]byte(`{"enabled": true}`)) //nolint:errcheck
+	}))
+	t.Cleanup(server.Close)
+
+	opts := newTestOptions(t)
+	opts.FeatureFlagURL = server.URL
+	opts.FeatureFlagURLHeaders = []string{"X-Environment: prod"}
+	opts.FeatureFlagURLToken = "secret-token"
+
+	require.NoError(t, featureflags.Load(context.Background(), opts))
+
+	value, ok := opts.FeatureFlags.Load("enabled")
+	require.True(t, ok)
+	assert.Equal(t, "true", value)
+
+	// Without the token, the endpoint refuses the request.
+	opts = newTestOptions(t)
+	opts.FeatureFlagURL = server.URL
+	opts.FeatureFlagURLHeaders = []string{"X-Environment: prod"}
+
+	require.ErrorAs(t, featureflags.Load(context.Background(), opts), &featureflags.SourceError{})
+}
+
+func TestParseHTTPHeaders(t *testing.T) {
+	t.Parallel()
+
+	header, err := featureflags.ParseHTTPHeaders([]string{"Authorization: Bearer abc:def", " X-Team :platform"})
+	require.NoError(t, err)
+	assert.Equal(t, http.Header{"Authorization": {"Bearer abc:def"}, "X-Team": {"platform"}}, header)
+
+	_, err = featureflags.ParseHTTPHeaders([]string{"Authorization"})
+	require.Error(t, err)
+
+	_, err = featureflags.ParseHTTPHeaders([]string{": value"})
+	require.Error(t, err)
+}
@@ -0,0 +1,52 @@
+package featureflags
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// FileSource reads feature flags from a JSON or YAML file, which holds an object of the values of the flags by name.
+// Files with the `.yaml` or `.yml` extension are decoded as YAML, any other file as JSON.
+type FileSource struct {
+	path string
+}
+
+// NewFileSource returns the source of the feature flags read from the given file.
+func NewFileSource(path string) *FileSource {
+	return &FileSource{path: path}
+}
+
+// Name implements Source.Name
+func (source *FileSource) Name() string {
+	return "the file " + source.path
+}
+
+// Flags implements Source.Flags
+func (source *FileSource) Flags(_ context.Context) (map[string]string, error) {
+	content, err := os.ReadFile(source.path)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	var values map[string]any
+
+	switch strings.ToLower(filepath.Ext(source.path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &values)
+	default:
+		err = json.Unmarshal(content, &values)
+	}
+
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return flagValuesToStrings(values)
+}
@@ -0,0 +1,88 @@
+package featureflags
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// httpSourceTimeout is the timeout of the requests to the HTTP endpoints of feature flags.
+const httpSourceTimeout = 30 * time.Second
+
+// HTTPSource reads feature flags from an HTTP endpoint, e.g. of a feature flag service, which responds to GET requests
+// with a JSON object of the values of the flags by name.
+type HTTPSource struct {
+	client *http.Client
+	header http.Header
+	url    string
+}
+
+// NewHTTPSource returns the source of the feature flags read from the given URL, requested with the given headers, e.g.
+// to authenticate to the endpoint.
+func NewHTTPSource(url string, header http.Header) *HTTPSource {
+	return &HTTPSource{url: url, header: header, client: &http.Client{Timeout: httpSourceTimeout}}
+}
+
+// ParseHTTPHeaders parses the given headers in the `Name: Value` format, as passed to `--feature-url-header`.
+func ParseHTTPHeaders(headers []string) (http.Header, error) {
+	header := make(http.Header, len(headers))
+
+	for _, str := range headers {
+		name, value, ok := strings.Cut(str, ":")
+		if name = strings.TrimSpace(name); !ok || name == "" {
+			return nil, errors.Errorf("invalid header %q, expected the format `Name: Value`", str)
+		}
+
+		header.Add(name, strings.TrimSpace(value))
+	}
+
+	return header, nil
+}
+
+// Name implements Source.Name
+func (source *HTTPSource) Name() string {
+	return "the endpoint " + source.url
+}
+
+// Flags implements Source.Flags
+func (source *HTTPSource) Flags(ctx context.Context) (map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source.url, nil)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	for name, values := range source.header {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := source.client.Do(req)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var values map[string]any
+	if err := json.Unmarshal(body, &values); err != nil {
+		return nil, errors.New(err)
+	}
+
+	return flagValuesToStrings(values)
+}
@@ -0,0 +1,75 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/shell"
+)
+
+// Command is an external evaluator. The command is run in the working dir of the unit with the path of the JSON plan
+// as its last argument, and prints the violations to its standard output as a JSON array of violations:
+//
+//	[{"policy": "tags", "address": "aws_instance.web", "message": "the instance has no owner tag"}]
+//
+// The command may exit with a non-zero exit code when it prints violations, any other failure fails the check.
+type Command struct {
+	terragruntOptions *options.TerragruntOptions
+	command           []string
+}
+
+// NewCommand returns an evaluator running the given command, made of the executable and its arguments.
+func NewCommand(opts *options.TerragruntOptions, command []string) *Command {
+	return &Command{
+		terragruntOptions: opts,
+		command:           command,
+	}
+}
+
+// Name implements Evaluator.Name
+func (evaluator *Command) Name() string {
+	if len(evaluator.command) == 0 {
+		return "command"
+	}
+
+	return filepath.Base(evaluator.command[0])
+}
+
+// Evaluate implements Evaluator.Evaluate
+func (evaluator *Command) Evaluate(ctx context.Context, plan *Plan) ([]Violation, error) {
+	if len(evaluator.command) == 0 {
+		return nil, errors.New("the policy command is empty")
+	}
+
+	args := append(evaluator.command[1:len(evaluator.command):len(evaluator.command)], plan.Path)
+
+	output, runErr := shell.RunCommandWithOutput(ctx, evaluator.terragruntOptions, "", true, false, evaluator.command[0], args...)
+
+	var violations []Violation
+
+	if stdout := strings.TrimSpace(output.Stdout.String()); stdout != "" {
+		if err := json.Unmarshal([]byte(stdout), &violations); err != nil {
+			if runErr != nil {
+				return nil, runErr
+			}
+
+			return nil, errors.Errorf("the command %s printed violations with an invalid JSON format: %w", evaluator.Name(), err)
+		}
+	}
+
+	if runErr != nil && len(violations) == 0 {
+		return nil, runErr
+	}
+
+	for i := range violations {
+		if violations[i].Policy == "" {
+			violations[i].Policy = evaluator.Name()
+		}
+	}
+
+	return violations, nil
+}
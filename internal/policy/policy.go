@@ -0,0 +1,144 @@
+// Package policy provides the evaluation of the plans of the units against policies, before they are applied.
+//
+// A plan is read from its JSON representation, as output by `show -json`, and checked by evaluators. Evaluators are
+// either built-in rules, such as DenyDestroyTagged, or external commands checking the plan file.
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+const (
+	actionDelete = "delete"
+
+	modeData = "data"
+)
+
+// Evaluator checks a plan against a policy.
+type Evaluator interface {
+	// Name returns the name of the policy, used in the violations and the logs.
+	Name() string
+	// Evaluate returns the violations of the policy by the plan. An error means the plan could not be checked.
+	Evaluate(ctx context.Context, plan *Plan) ([]Violation, error)
+}
+
+// Violation is a change of a plan that violates a policy.
+type Violation struct {
+	// Policy is the name of the violated policy.
+	Policy string `json:"policy"`
+	// Address is the address of the resource violating the policy, if the violation is about a single resource.
+	Address string `json:"address,omitempty"`
+	Message string `json:"message"`
+}
+
+func (violation Violation) String() string {
+	if violation.Address == "" {
+		return fmt.Sprintf("[%s] %s", violation.Policy, violation.Message)
+	}
+
+	return fmt.Sprintf("[%s] %s: %s", violation.Policy, violation.Address, violation.Message)
+}
+
+// Plan is the part of the JSON representation of a plan read by the built-in policies, the plan summary and the drift
+// detection.
+type Plan struct {
+	// Path is the path of the file the JSON plan was read from, passed to the external commands.
+	Path string `json:"-"`
+
+	ResourceChanges []*ResourceChange `json:"resource_changes"`
+	// ResourceDrift are the objects changed outside of OpenTofu/Terraform, detected when refreshing the state.
+	ResourceDrift []*ResourceChange `json:"resource_drift"`
+}
+
+// ResourceChange is a planned change of a resource, or a change of a resource made outside of OpenTofu/Terraform.
+type ResourceChange struct {
+	Address string `json:"address"`
+	Mode    string `json:"mode"`
+	Type    string `json:"type"`
+	Change  struct {
+		// Before is the state of the resource before the change, null for created resources.
+		Before  map[string]any `json:"before"`
+		Actions []string       `json:"actions"`
+	} `json:"change"`
+}
+
+// Destroys returns true if the change destroys the resource, including when the resource is replaced.
+func (change *ResourceChange) Destroys() bool {
+	return change.Mode != modeData && slices.Contains(change.Change.Actions, actionDelete)
+}
+
+// Tags returns the tags of the resource before the change, read from its `tags_all` and `tags` attributes.
+func (change *ResourceChange) Tags() map[string]string {
+	tags := map[string]string{}
+
+	for _, name := range []string{"tags_all", "tags"} {
+		values, ok := change.Change.Before[name].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for key, value := range values {
+			if value, ok := value.(string); ok {
+				tags[key] = value
+			}
+		}
+	}
+
+	return tags
+}
+
+// ReadPlan reads the JSON plan in the given file.
+func ReadPlan(path string) (*Plan, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	plan := &Plan{Path: path}
+	if err := json.Unmarshal(content, plan); err != nil {
+		return nil, errors.Errorf("failed to read the JSON plan %s: %w", path, err)
+	}
+
+	return plan, nil
+}
+
+// Evaluate checks the plan against all evaluators and returns a ViolationsError if any of the policies is violated.
+func Evaluate(ctx context.Context, plan *Plan, evaluators ...Evaluator) error {
+	var violations []Violation
+
+	for _, evaluator := range evaluators {
+		found, err := evaluator.Evaluate(ctx, plan)
+		if err != nil {
+			return errors.Errorf("failed to evaluate the %s policy: %w", evaluator.Name(), err)
+		}
+
+		violations = append(violations, found...)
+	}
+
+	if len(violations) > 0 {
+		return errors.New(ViolationsError{Violations: violations})
+	}
+
+	return nil
+}
+
+// ViolationsError is returned when a plan violates policies.
+type ViolationsError struct {
+	Violations []Violation
+}
+
+func (err ViolationsError) Error() string {
+	lines := make([]string, 0, len(err.Violations))
+	for _, violation := range err.Violations {
+		lines = append(lines, "  - "+violation.String())
+	}
+
+	return fmt.Sprintf("The plan violates %d policies:\n%s", len(err.Violations), strings.Join(lines, "\n"))
+}
@@ -0,0 +1,130 @@
+package policy_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/internal/policy"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPlan = `{
+  "resource_changes": [
+    {"address": "aws_db_instance.main", "mode": "managed", "type": "aws_db_instance",
+     "change": {"actions": ["delete", "create"], "before": {"tags": {"protected": "true"}}}},
+    {"address": "aws_s3_bucket.logs", "mode": "managed", "type": "aws_s3_bucket",
+     "change": {"actions": ["delete"], "before": {"tags_all": {"env": "prod"}}}},
+    {"address": "aws_instance.web", "mode": "managed", "type": "aws_instance",
+     "change": {"actions": ["update"], "before": {"tags": {"protected": "true"}}}},
+    {"address": "data.aws_db_instance.main", "mode": "data", "type": "aws_db_instance",
+     "change": {"actions": ["delete"], "before": {}}}
+  ]
+}`
+
+func readTestPlan(t *testing.T) *policy.Plan {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, os.WriteFile(path, []byte(testPlan), 0644))
+
+	plan, err := policy.ReadPlan(path)
+	require.NoError(t, err)
+
+	return plan
+}
+
+func TestDenyDestroyTagged(t *testing.T) {
+	t.Parallel()
+
+	plan := readTestPlan(t)
+
+	testCases := []struct {
+		tags      map[string]string
+		addresses []string
+	}{
+		{tags: map[string]string{"protected": "true"}, addresses: []string{"aws_db_instance.main"}},
+		{tags: map[string]string{"env": policy.AnyTagValue}, addresses: []string{"aws_s3_bucket.logs"}},
+		{tags: map[string]string{"env": "prod", "protected": "true"}, addresses: []string{"aws_db_instance.main", "aws_s3_bucket.logs"}},
+		{tags: map[string]string{"env": "dev"}},
+	}
+
+	for _, tc := range testCases {
+		rule := &policy.DenyDestroyTagged{Tags: tc.tags}
+
+		violations, err := rule.Evaluate(context.Background(), plan)
+		require.NoError(t, err)
+
+		addresses := []string{}
+		for _, violation := range violations {
+			assert.Equal(t, policy.DenyDestroyTaggedName, violation.Policy)
+			addresses = append(addresses, violation.Address)
+		}
+
+		assert.ElementsMatch(t, tc.addresses, addresses, "tags %v", tc.tags)
+	}
+}
+
+func TestDenyDestroyTypes(t *testing.T) {
+	t.Parallel()
+
+	rule := &policy.DenyDestroyTypes{Types: []string{"aws_db_instance", "aws_instance"}}
+
+	violations, err := rule.Evaluate(context.Background(), readTestPlan(t))
+	require.NoError(t, err)
+	require.Len(t, violations, 1, "data sources and updated resources are not destroyed")
+	assert.Equal(t, "aws_db_instance.main", violations[0].Address)
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	plan := readTestPlan(t)
+
+	err := policy.Evaluate(context.Background(), plan,
+		&policy.DenyDestroyTypes{Types: []string{"aws_s3_bucket"}},
+		&policy.DenyDestroyTagged{Tags: map[string]string{"protected": "true"}},
+	)
+
+	var violationsErr policy.ViolationsError
+	require.ErrorAs(t, err, &violationsErr)
+	assert.Len(t, violationsErr.Violations, 2)
+	assert.Contains(t, err.Error(), "[deny_destroy_types] aws_s3_bucket.logs")
+
+	require.NoError(t, policy.Evaluate(context.Background(), plan, &policy.DenyDestroyTypes{Types: []string{"aws_iam_role"}}))
+}
+
+func TestCommand(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	script := filepath.Join(dir, "check-plan.sh")
+	content := `#!/bin/sh
+grep -q aws_s3_bucket "$2" || exit 0
+echo '[{"address": "aws_s3_bucket.logs", "message": "'"$1"'"}]'
+exit 1
+`
+	require.NoError(t, os.WriteFile(script, []byte(content), 0755))
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(dir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = dir
+
+	violations, err := policy.NewCommand(opts, []string{script, "buckets must not be destroyed"}).Evaluate(context.Background(), readTestPlan(t))
+	require.NoError(t, err)
+	assert.Equal(t, []policy.Violation{{Policy: "check-plan.sh", Address: "aws_s3_bucket.logs", Message: "buckets must not be destroyed"}}, violations)
+
+	empty := &policy.Plan{Path: filepath.Join(dir, "empty.json")}
+	require.NoError(t, os.WriteFile(empty.Path, []byte("{}"), 0644))
+
+	violations, err = policy.NewCommand(opts, []string{script, "unused"}).Evaluate(context.Background(), empty)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+
+	_, err = policy.NewCommand(opts, []string{"false"}).Evaluate(context.Background(), empty)
+	require.Error(t, err, "a command failing without violations fails the check")
+}
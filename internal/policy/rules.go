@@ -0,0 +1,86 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+const (
+	DenyDestroyTaggedName = "deny_destroy_tagged"
+	DenyDestroyTypesName  = "deny_destroy_types"
+
+	// AnyTagValue matches any value of a tag in DenyDestroyTagged.
+	AnyTagValue = "*"
+)
+
+// DenyDestroyTagged denies destroying, or replacing, resources with any of the given tags.
+type DenyDestroyTagged struct {
+	// Tags maps the names of the tags to their values, or to AnyTagValue to match the tag whatever its value.
+	Tags map[string]string
+}
+
+// Name implements Evaluator.Name
+func (rule *DenyDestroyTagged) Name() string {
+	return DenyDestroyTaggedName
+}
+
+// Evaluate implements Evaluator.Evaluate
+func (rule *DenyDestroyTagged) Evaluate(_ context.Context, plan *Plan) ([]Violation, error) {
+	var violations []Violation
+
+	for _, change := range plan.ResourceChanges {
+		if !change.Destroys() {
+			continue
+		}
+
+		tags := change.Tags()
+
+		for _, name := range slices.Sorted(maps.Keys(rule.Tags)) {
+			value, ok := tags[name]
+			if !ok || (rule.Tags[name] != AnyTagValue && rule.Tags[name] != value) {
+				continue
+			}
+
+			violations = append(violations, Violation{
+				Policy:  rule.Name(),
+				Address: change.Address,
+				Message: fmt.Sprintf("the resource is tagged %s=%s and must not be destroyed", name, value),
+			})
+
+			break
+		}
+	}
+
+	return violations, nil
+}
+
+// DenyDestroyTypes denies destroying, or replacing, resources of the given types.
+type DenyDestroyTypes struct {
+	Types []string
+}
+
+// Name implements Evaluator.Name
+func (rule *DenyDestroyTypes) Name() string {
+	return DenyDestroyTypesName
+}
+
+// Evaluate implements Evaluator.Evaluate
+func (rule *DenyDestroyTypes) Evaluate(_ context.Context, plan *Plan) ([]Violation, error) {
+	var violations []Violation
+
+	for _, change := range plan.ResourceChanges {
+		if !change.Destroys() || !slices.Contains(rule.Types, change.Type) {
+			continue
+		}
+
+		violations = append(violations, Violation{
+			Policy:  rule.Name(),
+			Address: change.Address,
+			Message: fmt.Sprintf("resources of type %s must not be destroyed", change.Type),
+		})
+	}
+
+	return violations, nil
+}
@@ -33,7 +33,7 @@ type Queue struct {
 	entries discovery.DiscoveredConfigs
 }
 
-// Entries returns the queue entries. Used for testing.
+// Entries returns the queue entries, in run order.
 func (q *Queue) Entries() []*discovery.DiscoveredConfig {
 	return q.entries
 }
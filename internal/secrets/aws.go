@@ -0,0 +1,96 @@
+package secrets
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/gruntwork-io/terragrunt/awshelper"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+// SchemeAWSSecretsManager is the scheme of the secrets read from AWS Secrets Manager, e.g. `aws-sm://prod/db#password`.
+const SchemeAWSSecretsManager = "aws-sm"
+
+// AWSSecretsManager reads secrets from AWS Secrets Manager, with the credentials of the IAM role of the options, if
+// any. The path of a reference is the name or the ARN of the secret, and its query may set the `region`, the
+// `version_id` or the `version_stage` of the secret. The region defaults to the region of the ARN, then to the region
+// of the AWS configuration.
+type AWSSecretsManager struct {
+	// NewClient returns the client of the given region, or of the region of the AWS configuration if empty.
+	NewClient func(opts *options.TerragruntOptions, region string) (secretsmanageriface.SecretsManagerAPI, error)
+}
+
+// NewAWSSecretsManager returns the provider of the secrets read from AWS Secrets Manager.
+func NewAWSSecretsManager() *AWSSecretsManager {
+	return &AWSSecretsManager{NewClient: newAWSSecretsManagerClient}
+}
+
+// Scheme implements Provider.Scheme
+func (provider *AWSSecretsManager) Scheme() string {
+	return SchemeAWSSecretsManager
+}
+
+// GetSecret implements Provider.GetSecret
+func (provider *AWSSecretsManager) GetSecret(ctx context.Context, opts *options.TerragruntOptions, ref *Reference) (string, error) {
+	region := ref.Query.Get("region")
+
+	if secretARN, err := arn.Parse(ref.Path); region == "" && err == nil {
+		region = secretARN.Region
+	}
+
+	client, err := provider.NewClient(opts, region)
+	if err != nil {
+		return "", err
+	}
+
+	input := &secretsmanager.GetSecretValueInput{SecretId: aws.String(ref.Path)}
+
+	if versionID := ref.Query.Get("version_id"); versionID != "" {
+		input.VersionId = aws.String(versionID)
+	}
+
+	if versionStage := ref.Query.Get("version_stage"); versionStage != "" {
+		input.VersionStage = aws.String(versionStage)
+	}
+
+	output, err := client.GetSecretValueWithContext(ctx, input)
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == secretsmanager.ErrCodeResourceNotFoundException {
+			return "", errors.New(SecretNotFoundError{Reference: ref.String()})
+		}
+
+		return "", errors.Errorf("failed to read the secret %s from AWS Secrets Manager: %w", ref, err)
+	}
+
+	switch {
+	case output.SecretString != nil:
+		return *output.SecretString, nil
+	case utf8.Valid(output.SecretBinary):
+		return string(output.SecretBinary), nil
+	}
+
+	return "", errors.Errorf("the secret %s of AWS Secrets Manager is not a text", ref)
+}
+
+// newAWSSecretsManagerClient returns the client of AWS Secrets Manager of the given region, authenticated with the
+// IAM role of the options.
+func newAWSSecretsManagerClient(opts *options.TerragruntOptions, region string) (secretsmanageriface.SecretsManagerAPI, error) {
+	sess, err := awshelper.CreateAwsSession(nil, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	config := aws.NewConfig()
+	if region != "" {
+		config = config.WithRegion(region)
+	}
+
+	return secretsmanager.New(sess, config), nil
+}
@@ -0,0 +1,23 @@
+package secrets
+
+import "context"
+
+const (
+	ProvidersContextKey ctxKey = iota
+)
+
+type ctxKey byte
+
+// ContextWithProviders returns a new context containing the given secret providers, used in place of the default ones.
+func ContextWithProviders(ctx context.Context, providers Providers) context.Context {
+	return context.WithValue(ctx, ProvidersContextKey, providers)
+}
+
+// ProvidersFromContext returns the secret providers of the context if they have been set, otherwise the default ones.
+func ProvidersFromContext(ctx context.Context) Providers {
+	if providers, ok := ctx.Value(ProvidersContextKey).(Providers); ok {
+		return providers
+	}
+
+	return DefaultProviders()
+}
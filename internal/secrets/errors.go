@@ -0,0 +1,45 @@
+package secrets
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InvalidReferenceError is returned when a reference to a secret can not be parsed.
+type InvalidReferenceError struct {
+	Reference string
+	Reason    string
+}
+
+func (err InvalidReferenceError) Error() string {
+	return fmt.Sprintf("invalid reference to a secret %q, expected <scheme>://<path>[#<key>]: %s", err.Reference, err.Reason)
+}
+
+// UnknownSchemeError is returned when no provider reads the secrets of the scheme of a reference.
+type UnknownSchemeError struct {
+	Reference string
+	Schemes   []string
+}
+
+func (err UnknownSchemeError) Error() string {
+	return fmt.Sprintf("no secret provider for the reference %q, the supported schemes are %s", err.Reference, strings.Join(err.Schemes, ", "))
+}
+
+// SecretNotFoundError is returned when the referenced secret does not exist.
+type SecretNotFoundError struct {
+	Reference string
+}
+
+func (err SecretNotFoundError) Error() string {
+	return fmt.Sprintf("the secret %s was not found", err.Reference)
+}
+
+// KeyNotFoundError is returned when the key of a reference is not in its secret.
+type KeyNotFoundError struct {
+	Reference string
+	Key       string
+}
+
+func (err KeyNotFoundError) Error() string {
+	return fmt.Sprintf("the secret %s has no key %q", err.Reference, err.Key)
+}
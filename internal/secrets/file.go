@@ -0,0 +1,52 @@
+package secrets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// SchemeFile is the scheme of the secrets read from files, e.g. `file:///run/secrets/db_password`.
+const SchemeFile = "file"
+
+// File reads secrets from files, such as the secrets mounted by orchestrators. Relative paths are relative to the
+// working dir. The trailing newline of the files is not part of the secret.
+type File struct{}
+
+// NewFile returns the provider of the secrets read from files.
+func NewFile() *File {
+	return &File{}
+}
+
+// Scheme implements Provider.Scheme
+func (provider *File) Scheme() string {
+	return SchemeFile
+}
+
+// GetSecret implements Provider.GetSecret
+func (provider *File) GetSecret(_ context.Context, opts *options.TerragruntOptions, ref *Reference) (string, error) {
+	path := ref.Path
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Clean(util.JoinPath(opts.WorkingDir, path))
+	}
+
+	opts.AppendReadFile(path, opts.WorkingDir)
+
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", errors.New(SecretNotFoundError{Reference: ref.String()})
+	} else if err != nil {
+		return "", errors.New(err)
+	}
+
+	value := strings.TrimSuffix(string(content), "\n")
+	value = strings.TrimSuffix(value, "\r")
+
+	return value, nil
+}
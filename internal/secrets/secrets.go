@@ -0,0 +1,143 @@
+// Package secrets provides the secret providers of the `get_secret` function, which reads secrets from secret managers
+// by reference, such as `aws-sm://prod/db#password` or `vault://secret/data/db#password`.
+//
+// A reference is made of the scheme of its provider, the path of the secret, optional query parameters and an optional
+// key. The provider returns the value of the secret, and the key, if any, is read from the value as a JSON object.
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+// Provider reads secrets from a secret manager.
+type Provider interface {
+	// Scheme returns the scheme of the references to the secrets of the provider, e.g. `vault`.
+	Scheme() string
+	// GetSecret returns the value of the referenced secret, the key of the reference is read from it afterwards.
+	GetSecret(ctx context.Context, opts *options.TerragruntOptions, ref *Reference) (string, error)
+}
+
+// Providers are secret providers by scheme.
+type Providers map[string]Provider
+
+// NewProviders returns the given providers by scheme.
+func NewProviders(providers ...Provider) Providers {
+	providersByScheme := make(Providers, len(providers))
+
+	for _, provider := range providers {
+		providersByScheme[provider.Scheme()] = provider
+	}
+
+	return providersByScheme
+}
+
+// DefaultProviders returns the built-in providers.
+func DefaultProviders() Providers {
+	return NewProviders(NewAWSSecretsManager(), NewVault(), NewFile())
+}
+
+// Get returns the value of the given reference to a secret.
+func (providers Providers) Get(ctx context.Context, opts *options.TerragruntOptions, reference string) (string, error) {
+	ref, err := ParseReference(reference)
+	if err != nil {
+		return "", err
+	}
+
+	provider, ok := providers[ref.Scheme]
+	if !ok {
+		return "", errors.New(UnknownSchemeError{Reference: reference, Schemes: slices.Sorted(maps.Keys(providers))})
+	}
+
+	opts.Logger.Debugf("Reading the secret %s", reference)
+
+	value, err := provider.GetSecret(ctx, opts, ref)
+	if err != nil {
+		return "", err
+	}
+
+	if ref.Key == "" {
+		return value, nil
+	}
+
+	return ref.readKey(value)
+}
+
+// Reference is a reference to a secret, `<scheme>://<path>[?<query>][#<key>]`.
+type Reference struct {
+	Query url.Values
+	// Scheme is the scheme of the provider of the secret.
+	Scheme string
+	// Path is the path of the secret, in the format of its provider.
+	Path string
+	// Key is the key of the value to read from the secret, if the secret is a JSON object.
+	Key string
+
+	raw string
+}
+
+// ParseReference parses the given reference to a secret.
+func ParseReference(reference string) (*Reference, error) {
+	scheme, path, ok := strings.Cut(reference, "://")
+	if !ok || scheme == "" {
+		return nil, errors.New(InvalidReferenceError{Reference: reference, Reason: "the scheme of the secret provider is missing"})
+	}
+
+	path, key, _ := strings.Cut(path, "#")
+	path, rawQuery, _ := strings.Cut(path, "?")
+
+	if path == "" {
+		return nil, errors.New(InvalidReferenceError{Reference: reference, Reason: "the path of the secret is missing"})
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, errors.New(InvalidReferenceError{Reference: reference, Reason: err.Error()})
+	}
+
+	return &Reference{
+		Scheme: scheme,
+		Path:   path,
+		Query:  query,
+		Key:    key,
+		raw:    reference,
+	}, nil
+}
+
+func (ref *Reference) String() string {
+	return ref.raw
+}
+
+// readKey reads the key of the reference from the given value of the secret, as a JSON object. Values that are not
+// strings are returned as JSON.
+func (ref *Reference) readKey(value string) (string, error) {
+	var object map[string]any
+
+	// The error is not returned, as it may contain a part of the value of the secret.
+	if err := json.Unmarshal([]byte(value), &object); err != nil {
+		return "", errors.Errorf("the key %s can not be read from the secret %s, which is not a JSON object", ref.Key, ref)
+	}
+
+	val, ok := object[ref.Key]
+	if !ok {
+		return "", errors.New(KeyNotFoundError{Reference: ref.String(), Key: ref.Key})
+	}
+
+	if str, ok := val.(string); ok {
+		return str, nil
+	}
+
+	content, err := json.Marshal(val)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	return string(content), nil
+}
@@ -0,0 +1,172 @@
+package secrets_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/gruntwork-io/terragrunt/internal/secrets"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOptions(t *testing.T) *options.TerragruntOptions {
+	t.Helper()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = filepath.Dir(opts.TerragruntConfigPath)
+	opts.Env = map[string]string{}
+
+	return opts
+}
+
+func TestParseReference(t *testing.T) {
+	t.Parallel()
+
+	ref, err := secrets.ParseReference("aws-sm://arn:aws:secretsmanager:eu-west-1:123456789012:secret:prod/db?version_stage=AWSPREVIOUS#password")
+	require.NoError(t, err)
+	assert.Equal(t, "aws-sm", ref.Scheme)
+	assert.Equal(t, "arn:aws:secretsmanager:eu-west-1:123456789012:secret:prod/db", ref.Path)
+	assert.Equal(t, "AWSPREVIOUS", ref.Query.Get("version_stage"))
+	assert.Equal(t, "password", ref.Key)
+
+	for _, reference := range []string{"prod/db", "://prod/db", "vault://", "vault://#password"} {
+		_, err := secrets.ParseReference(reference)
+		require.ErrorAs(t, err, &secrets.InvalidReferenceError{}, reference)
+	}
+}
+
+func TestFile(t *testing.T) {
+	t.Parallel()
+
+	opts := newTestOptions(t)
+	providers := secrets.DefaultProviders()
+
+	require.NoError(t, os.WriteFile(filepath.Join(opts.WorkingDir, "db.json"), []byte(`{"password": "hunter2", "port": 5432}`+"\n"), 0600))
+
+	value, err := providers.Get(context.Background(), opts, "file://db.json#password")
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", value)
+
+	value, err = providers.Get(context.Background(), opts, "file://"+filepath.Join(opts.WorkingDir, "db.json")+"#port")
+	require.NoError(t, err)
+	assert.Equal(t, "5432", value)
+
+	_, err = providers.Get(context.Background(), opts, "file://db.json#user")
+	require.ErrorAs(t, err, &secrets.KeyNotFoundError{})
+
+	_, err = providers.Get(context.Background(), opts, "file://missing.json")
+	require.ErrorAs(t, err, &secrets.SecretNotFoundError{})
+
+	_, err = providers.Get(context.Background(), opts, "gcp-sm://db")
+	require.ErrorAs(t, err, &secrets.UnknownSchemeError{})
+}
+
+func TestVault(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors": ["permission denied"]}`))
+
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/secret/data/db":
+			_, _ = w.Write([]byte(`{"data": {"data": {"password": "hunter2"}, "metadata": {"version": 3}}}`))
+		case "/v1/kv/db":
+			_, _ = w.Write([]byte(`{"data": {"password": "hunter3"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors": []}`))
+		}
+	}))
+	defer server.Close()
+
+	opts := newTestOptions(t)
+	opts.Env["VAULT_ADDR"] = server.URL
+	opts.Env["VAULT_TOKEN"] = "test-token"
+
+	providers := secrets.DefaultProviders()
+
+	value, err := providers.Get(context.Background(), opts, "vault://secret/data/db#password")
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", value)
+
+	value, err = providers.Get(context.Background(), opts, "vault://kv/db")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"password": "hunter3"}`, value)
+
+	_, err = providers.Get(context.Background(), opts, "vault://secret/data/missing#password")
+	require.ErrorAs(t, err, &secrets.SecretNotFoundError{})
+
+	opts.Env["VAULT_TOKEN"] = "wrong-token"
+
+	_, err = providers.Get(context.Background(), opts, "vault://kv/db")
+	require.ErrorContains(t, err, "permission denied")
+}
+
+type fakeSecretsManager struct {
+	secretsmanageriface.SecretsManagerAPI
+
+	secrets map[string]string
+	inputs  []*secretsmanager.GetSecretValueInput
+}
+
+func (client *fakeSecretsManager) GetSecretValueWithContext(_ aws.Context, input *secretsmanager.GetSecretValueInput, _ ...request.Option) (*secretsmanager.GetSecretValueOutput, error) {
+	client.inputs = append(client.inputs, input)
+
+	value, ok := client.secrets[aws.StringValue(input.SecretId)]
+	if !ok {
+		return nil, awserr.New(secretsmanager.ErrCodeResourceNotFoundException, "secret not found", nil)
+	}
+
+	return &secretsmanager.GetSecretValueOutput{SecretString: aws.String(value)}, nil
+}
+
+func TestAWSSecretsManager(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeSecretsManager{secrets: map[string]string{
+		"prod/db": `{"password": "hunter2"}`,
+		"arn:aws:secretsmanager:eu-west-1:123456789012:secret:prod/api": "api-key",
+	}}
+
+	var regions []string
+
+	provider := secrets.NewAWSSecretsManager()
+	provider.NewClient = func(_ *options.TerragruntOptions, region string) (secretsmanageriface.SecretsManagerAPI, error) {
+		regions = append(regions, region)
+		return client, nil
+	}
+
+	providers := secrets.NewProviders(provider)
+	opts := newTestOptions(t)
+
+	value, err := providers.Get(context.Background(), opts, "aws-sm://prod/db?region=us-east-1&version_stage=AWSCURRENT#password")
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", value)
+
+	value, err = providers.Get(context.Background(), opts, "aws-sm://arn:aws:secretsmanager:eu-west-1:123456789012:secret:prod/api")
+	require.NoError(t, err)
+	assert.Equal(t, "api-key", value)
+
+	_, err = providers.Get(context.Background(), opts, "aws-sm://prod/missing")
+	require.ErrorAs(t, err, &secrets.SecretNotFoundError{})
+
+	assert.Equal(t, []string{"us-east-1", "eu-west-1", ""}, regions)
+	assert.Equal(t, "AWSCURRENT", aws.StringValue(client.inputs[0].VersionStage))
+}
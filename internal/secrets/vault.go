@@ -0,0 +1,148 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+const (
+	// SchemeVault is the scheme of the secrets read from HashiCorp Vault, e.g. `vault://secret/data/db#password`.
+	SchemeVault = "vault"
+
+	vaultAddrEnvName      = "VAULT_ADDR"
+	vaultTokenEnvName     = "VAULT_TOKEN"
+	vaultNamespaceEnvName = "VAULT_NAMESPACE"
+
+	defaultVaultAddr = "https://127.0.0.1:8200"
+	vaultTokenFile   = ".vault-token"
+)
+
+// Vault reads secrets from HashiCorp Vault, with the address, token and namespace of the Vault CLI: the VAULT_ADDR,
+// VAULT_TOKEN and VAULT_NAMESPACE environment variables, or the ~/.vault-token file written by `vault login`.
+//
+// The path of a reference is the API path of the secret, so the path of the secrets of a KV version 2 engine contains
+// `data/`. The value of a secret is the JSON object of its data.
+type Vault struct {
+	client *http.Client
+}
+
+// NewVault returns the provider of the secrets read from Vault.
+func NewVault() *Vault {
+	return &Vault{client: http.DefaultClient}
+}
+
+// Scheme implements Provider.Scheme
+func (provider *Vault) Scheme() string {
+	return SchemeVault
+}
+
+// GetSecret implements Provider.GetSecret
+func (provider *Vault) GetSecret(ctx context.Context, opts *options.TerragruntOptions, ref *Reference) (string, error) {
+	addr := getEnv(opts, vaultAddrEnvName)
+	if addr == "" {
+		addr = defaultVaultAddr
+	}
+
+	url := strings.TrimSuffix(addr, "/") + "/v1/" + strings.TrimPrefix(ref.Path, "/")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	token, err := vaultToken(opts)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("X-Vault-Token", token)
+
+	if namespace := getEnv(opts, vaultNamespaceEnvName); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	resp, err := provider.client.Do(req)
+	if err != nil {
+		return "", errors.Errorf("failed to read the secret %s from Vault: %w", ref, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return "", errors.New(SecretNotFoundError{Reference: ref.String()})
+	default:
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+
+		_ = json.Unmarshal(body, &vaultErr)
+
+		return "", errors.Errorf("failed to read the secret %s from Vault: %s %s", ref, resp.Status, strings.Join(vaultErr.Errors, ", "))
+	}
+
+	var secret struct {
+		Data map[string]any `json:"data"`
+	}
+
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", errors.Errorf("failed to read the secret %s from Vault, the response is not a secret", ref)
+	}
+
+	data := secret.Data
+
+	// The data of the secrets of a KV version 2 engine is next to their metadata.
+	if kvData, ok := data["data"].(map[string]any); ok && data["metadata"] != nil {
+		data = kvData
+	}
+
+	value, err := json.Marshal(data)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	return string(value), nil
+}
+
+// vaultToken returns the token to authenticate to Vault with.
+func vaultToken(opts *options.TerragruntOptions) (string, error) {
+	if token := getEnv(opts, vaultTokenEnvName); token != "" {
+		return token, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(home, vaultTokenFile))
+	if os.IsNotExist(err) {
+		return "", errors.Errorf("no Vault token, set %s or run `vault login`", vaultTokenEnvName)
+	} else if err != nil {
+		return "", errors.New(err)
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+// getEnv returns the value of the given environment variable of the options, or of the process.
+func getEnv(opts *options.TerragruntOptions, name string) string {
+	if value, ok := opts.Env[name]; ok {
+		return value
+	}
+
+	return os.Getenv(name)
+}
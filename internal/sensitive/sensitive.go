@@ -0,0 +1,244 @@
+// Package sensitive keeps track of the sensitive values of the configuration, such as decrypted secrets, so that they
+// are redacted from the logs and from the outputs of Terragrunt.
+//
+// Sensitive values are marked with Mark while the configuration is evaluated, so that the mark propagates to the values
+// computed from them. Marks can not be decoded into Go values, so the values are unmarked once evaluated, registering
+// the sensitive strings they contain to be redacted.
+package sensitive
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// Mark is the cty mark of the sensitive values, the same as the mark of the `sensitive` function of
+	// OpenTofu/Terraform.
+	Mark = "sensitive"
+
+	// Redacted replaces the sensitive values.
+	Redacted = "(sensitive value)"
+
+	// minRedactedLength is the length from which sensitive strings are redacted when they are part of a larger text.
+	// Shorter values, such as ports, flags or environment names, would redact unrelated parts of the logs.
+	minRedactedLength = 8
+)
+
+// Default is the registry of the sensitive strings of the current run, used by the functions of the package. It is
+// reset when a new run starts.
+var Default = NewRegistry()
+
+// Registry keeps the sensitive strings registered by the evaluated configurations.
+type Registry struct {
+	values []string
+	mu     sync.RWMutex
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register registers the given strings as sensitive in the default registry, so that they are redacted.
+func Register(strs ...string) {
+	Default.Register(strs...)
+}
+
+// IsSensitive returns true if the given string is a sensitive value of the default registry.
+func IsSensitive(str string) bool {
+	return Default.IsSensitive(str)
+}
+
+// Redact replaces the sensitive values of the default registry contained in the given text.
+func Redact(str string) string {
+	return Default.Redact(str)
+}
+
+// RedactValue redacts the given value, as decoded from JSON, with the default registry.
+func RedactValue(value any) any {
+	return Default.RedactValue(value)
+}
+
+// Reset forgets the sensitive values of the default registry, when a new run starts.
+func Reset() {
+	Default.Reset()
+}
+
+// Register registers the given strings as sensitive, so that they are redacted.
+func (registry *Registry) Register(strs ...string) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	for _, str := range strs {
+		if str == "" || slices.Contains(registry.values, str) {
+			continue
+		}
+
+		registry.values = append(registry.values, str)
+	}
+
+	// Redact the longest values first, as they may contain shorter ones.
+	slices.SortFunc(registry.values, func(a, b string) int {
+		return cmp.Compare(len(b), len(a))
+	})
+}
+
+// IsSensitive returns true if the given string is a registered sensitive value.
+func (registry *Registry) IsSensitive(str string) bool {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	return slices.Contains(registry.values, str)
+}
+
+// Redact replaces the sensitive values contained in the given text. The values shorter than minRedactedLength are not
+// redacted, and the others only when they are whole tokens of the text, not part of a longer word.
+func (registry *Registry) Redact(str string) string {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	for _, value := range registry.values {
+		if len(value) >= minRedactedLength {
+			str = redactTokens(str, value)
+		}
+	}
+
+	return str
+}
+
+// Reset forgets all the registered values.
+func (registry *Registry) Reset() {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	registry.values = nil
+}
+
+// redactTokens replaces the occurrences of the given value in the given text that are not part of a longer word.
+func redactTokens(str, value string) string {
+	var (
+		redacted strings.Builder
+		last     int
+	)
+
+	for from := 0; ; {
+		index := strings.Index(str[from:], value)
+		if index < 0 {
+			break
+		}
+
+		start, end := from+index, from+index+len(value)
+
+		if !isWordByte(str, start-1) && !isWordByte(str, end) {
+			redacted.WriteString(str[last:start])
+			redacted.WriteString(Redacted)
+
+			last, from = end, end
+
+			continue
+		}
+
+		from = start + 1
+	}
+
+	if last == 0 {
+		return str
+	}
+
+	redacted.WriteString(str[last:])
+
+	return redacted.String()
+}
+
+// isWordByte returns true if the byte of the given text at the given index is part of a word: a letter, a digit, an
+// underscore or a byte of a multi-byte character.
+func isWordByte(str string, index int) bool {
+	if index < 0 || index >= len(str) {
+		return false
+	}
+
+	b := str[index]
+
+	return b == '_' || b >= utf8.RuneSelf || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z') || ('0' <= b && b <= '9')
+}
+
+// RedactValue returns a copy of the given value, as decoded from JSON, where the strings that are sensitive are replaced
+// with Redacted, and the sensitive values contained in the other strings are redacted.
+func (registry *Registry) RedactValue(value any) any {
+	switch value := value.(type) {
+	case string:
+		if registry.IsSensitive(value) {
+			return Redacted
+		}
+
+		return registry.Redact(value)
+	case map[string]any:
+		redacted := make(map[string]any, len(value))
+
+		for key, val := range value {
+			redacted[key] = registry.RedactValue(val)
+		}
+
+		return redacted
+	case []any:
+		redacted := make([]any, len(value))
+
+		for i, val := range value {
+			redacted[i] = registry.RedactValue(val)
+		}
+
+		return redacted
+	}
+
+	return value
+}
+
+// MarkValue marks the given value as sensitive, registering the strings it contains.
+func MarkValue(val cty.Value) cty.Value {
+	unmarked, _ := val.UnmarkDeep()
+	registerStrings(unmarked)
+
+	return val.Mark(Mark)
+}
+
+// Unmark removes the marks of the given value, so that it can be decoded into Go values, registering the strings of
+// the parts of the value that are marked as sensitive.
+func Unmark(val cty.Value) cty.Value {
+	if !val.ContainsMarked() {
+		return val
+	}
+
+	unmarked, pathMarks := val.UnmarkDeepWithPaths()
+
+	for _, pathMark := range pathMarks {
+		if _, ok := pathMark.Marks[Mark]; !ok {
+			continue
+		}
+
+		if sensitiveVal, err := pathMark.Path.Apply(unmarked); err == nil {
+			registerStrings(sensitiveVal)
+		}
+	}
+
+	return unmarked
+}
+
+// registerStrings registers the known strings of the given unmarked value.
+func registerStrings(val cty.Value) {
+	var strs []string
+
+	_ = cty.Walk(val, func(_ cty.Path, val cty.Value) (bool, error) {
+		if val.IsKnown() && !val.IsNull() && val.Type() == cty.String {
+			strs = append(strs, val.AsString())
+		}
+
+		return true, nil
+	})
+
+	Register(strs...)
+}
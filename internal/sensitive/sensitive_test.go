@@ -0,0 +1,75 @@
+package sensitive_test
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/internal/sensitive"
+	"github.com/stretchr/testify/assert"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestUnmark(t *testing.T) {
+	t.Parallel()
+
+	val := cty.ObjectVal(map[string]cty.Value{
+		"user":     cty.StringVal("unmark-test-user"),
+		"password": cty.StringVal("unmark-test-password").Mark(sensitive.Mark),
+		"keys":     cty.ListVal([]cty.Value{cty.StringVal("unmark-test-key")}).Mark(sensitive.Mark),
+	})
+
+	unmarked := sensitive.Unmark(val)
+	assert.False(t, unmarked.ContainsMarked())
+	assert.Equal(t, "unmark-test-password", unmarked.GetAttr("password").AsString())
+
+	assert.True(t, sensitive.IsSensitive("unmark-test-password"))
+	assert.True(t, sensitive.IsSensitive("unmark-test-key"))
+	assert.False(t, sensitive.IsSensitive("unmark-test-user"))
+}
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	sensitive.MarkValue(cty.StringVal("redact-test-secret"))
+	sensitive.Register("123")
+
+	assert.Equal(t, "token=(sensitive value) port=123", sensitive.Redact("token=redact-test-secret port=123"))
+
+	// Short values are only redacted when they are the whole value.
+	assert.Equal(t, map[string]any{
+		"secret": sensitive.Redacted,
+		"port":   sensitive.Redacted,
+		"args":   []any{"--token=(sensitive value)", float64(123)},
+	}, sensitive.RedactValue(map[string]any{
+		"secret": "redact-test-secret",
+		"port":   "123",
+		"args":   []any{"--token=redact-test-secret", float64(123)},
+	}))
+}
+
+func TestRedactShortAndCommonValues(t *testing.T) {
+	t.Parallel()
+
+	registry := sensitive.NewRegistry()
+	registry.Register("prod", "true", "main", "p4ssw0rd-prod")
+
+	// Short values are not redacted from larger texts, as they would hide unrelated parts of the logs.
+	assert.Equal(t, "Deploying branch main to prod: true", registry.Redact("Deploying branch main to prod: true"))
+	assert.Equal(t, sensitive.Redacted, registry.RedactValue("prod"))
+
+	// Longer values are only redacted when they are whole tokens.
+	assert.Equal(t, "password=(sensitive value), user:(sensitive value)@host", registry.Redact("password=p4ssw0rd-prod, user:p4ssw0rd-prod@host"))
+	assert.Equal(t, "xp4ssw0rd-prod p4ssw0rd-prod2", registry.Redact("xp4ssw0rd-prod p4ssw0rd-prod2"))
+}
+
+func TestRegistryReset(t *testing.T) {
+	t.Parallel()
+
+	registry := sensitive.NewRegistry()
+	registry.Register("reset-test-secret")
+	assert.Equal(t, "token (sensitive value)", registry.Redact("token reset-test-secret"))
+
+	registry.Reset()
+
+	assert.False(t, registry.IsSensitive("reset-test-secret"))
+	assert.Equal(t, "token reset-test-secret", registry.Redact("token reset-test-secret"))
+}
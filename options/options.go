@@ -52,6 +52,8 @@ const (
 
 	DefaultIAMAssumeRoleDuration = 3600
 
+	DefaultDependencyOutputCacheTTL = time.Hour
+
 	minCommandLength = 2
 
 	defaultExcludesFile = ".terragrunt-excludes"
@@ -156,6 +158,28 @@ type TerragruntOptions struct {
 	JSONOutputFolder string
 	// Folder to store output files.
 	OutputFolder string
+	// Folder to apply the plans saved with OutputFolder from, after checking that they were made from the same code.
+	FromPlanDir string
+	// Path of the file to write the report of a run of all units to.
+	RunReportFile string
+	// Format of the run report, `json` or `junit`. Derived from the extension of RunReportFile if empty.
+	RunReportFormat string
+	// Path of the file to write the summary of the changes planned by a run of all units to.
+	PlanSummaryFile string
+	// Format of the plan summary, `json` or `markdown`. Derived from the extension of PlanSummaryFile if empty.
+	PlanSummaryFormat string
+	// ID of the run of all units, used to resume it. Derived from the working dir and the command if empty.
+	RunID string
+	// Folder to persist the per-unit completion state of runs of all units in.
+	RunStateDir string
+	// Path of the file to write the events of the units of a run of all units to, as newline-delimited JSON.
+	EventStreamFile string
+	// Path of the unix socket to write the events of the units of a run of all units to, as newline-delimited JSON.
+	EventStreamSocket string
+	// Show the progress of runs of all units in an interactive terminal UI.
+	TUI bool
+	// Print a summary of the changes planned in every unit by a run of all units.
+	PlanSummary bool
 	// The file which hclfmt should be specifically run on
 	HclFile string
 	// The hostname of the Terragrunt Provider Cache server.
@@ -192,18 +216,47 @@ type TerragruntOptions struct {
 	ModulesThatInclude []string
 	// When used with `run-all`, restrict the units in the stack to only those that read at least one of the files in this list.
 	UnitsReading []string
+	// When used with `run-all`, restrict the units in the stack to only those affected by the files changed since this
+	// git ref, and the units that depend on them.
+	QueueIncludeChangedSince string
 	// Experiments is a map of experiments, and their status.
 	Experiments experiment.Experiments `clone:"shadowcopy"`
 	// Maximum number of times to retry errors matching RetryableErrors
 	RetryMaxAttempts int
 	// Parallelism limits the number of commands to run concurrently during *-all commands
 	Parallelism int
+	// ParallelismGroups limits the number of commands to run concurrently during *-all commands per concurrency group,
+	// set in the `scheduling` block of the units.
+	ParallelismGroups map[string]int
+	// Start first the units with the longest chain of dependents during *-all commands, weighted by the durations of
+	// the units recorded in QueueDurationsFile.
+	QueueCriticalPath bool
+	// File to record the durations of the units in, used to weight the critical path of the queue. Derived from the
+	// working dir and the command in the cache dir if empty.
+	QueueDurationsFile string
 	// When searching the directory tree, this is the max folders to check before exiting with an error.
 	MaxFoldersToCheck int
 	// The port of the Terragrunt Provider Cache server.
 	ProviderCachePort int
 	// The duration in seconds to wait before retrying
 	RetrySleepInterval time.Duration
+	// How long entries of the persistent dependency output cache are considered valid.
+	DependencyOutputCacheTTL time.Duration
+	// The directory where the persistent dependency output cache is stored.
+	DependencyOutputCacheDir string
+	// The prefix of the environment variables that set feature flags, e.g. `TG_FF_`.
+	FeatureFlagEnvPrefix string
+	// The path of a JSON or YAML file that sets feature flags.
+	FeatureFlagFile string
+	// The URL of an HTTP endpoint that sets feature flags.
+	FeatureFlagURL string
+	// The headers of the requests to the HTTP endpoint that sets feature flags, in the `Name: Value` format.
+	FeatureFlagURLHeaders []string
+	// The bearer token to authenticate to the HTTP endpoint that sets feature flags.
+	FeatureFlagURLToken string
+	// LoadFeatureFlags resolves the feature flags of the external sources into FeatureFlags. It is called when a
+	// configuration is parsed, so that the commands not parsing any configuration don't read the sources.
+	LoadFeatureFlags func() error
 	// Output Terragrunt logs in JSON format
 	JSONLogFormat bool
 	// True if terragrunt should run in debug mode
@@ -214,6 +267,10 @@ type TerragruntOptions struct {
 	FailIfBucketCreationRequired bool
 	// Controls if s3 bucket should be updated or skipped
 	DisableBucketUpdate bool
+	// Migrate the state to the new backend when the remote_state configuration changes
+	BackendMigrate bool
+	// Only run the units that failed or did not run in the previous attempt of the run of all units.
+	Resume bool
 	// Disables validation terraform command
 	DisableCommandValidation bool
 	// If True then HCL from StdIn must should be formatted.
@@ -236,6 +293,8 @@ type TerragruntOptions struct {
 	ExcludeByDefault bool
 	// This is an experimental feature, used to speed up dependency processing by getting the output from the state
 	FetchDependencyOutputFromState bool
+	// Enables the persistent on-disk cache of dependency outputs, shared across invocations.
+	DependencyOutputCache bool
 	// True if is required to show dependent modules and confirm action
 	CheckDependentModules bool
 	// True if is required not to show dependent modules and confirm action
@@ -262,6 +321,10 @@ type TerragruntOptions struct {
 	IgnoreDependencyOrder bool
 	// If set to true, continue running *-all commands even if a dependency has errors.
 	IgnoreDependencyErrors bool
+	// If set to true, cancel the units that did not start yet as soon as a unit fails when running *-all commands.
+	FailFast bool
+	// If set to true, also interrupt the units that are running as soon as a unit fails when running *-all commands.
+	FailFastInterrupt bool
 	// Whether we should automatically run terraform with -auto-apply in run-all mode.
 	RunAllAutoApprove bool
 	// If set to true, delete the contents of the temporary folder before downloading Terraform source code into it
@@ -375,6 +438,7 @@ func NewTerragruntOptionsWithWriters(stdout, stderr io.Writer) *TerragruntOption
 		Check:                          false,
 		Diff:                           false,
 		FetchDependencyOutputFromState: false,
+		DependencyOutputCacheTTL:       DefaultDependencyOutputCacheTTL,
 		UsePartialParseConfigCache:     false,
 		ForwardTFStdout:                false,
 		JSONOut:                        DefaultJSONOutName,
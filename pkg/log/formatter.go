@@ -2,6 +2,7 @@ package log
 
 import (
 	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/sensitive"
 	"github.com/sirupsen/logrus"
 )
 
@@ -53,6 +54,9 @@ func (f *fromLogrusFormatter) Format(parent *logrus.Entry) ([]byte, error) {
 		return nil, errors.Errorf("nil entry provided")
 	}
 
+	// Sensitive values of the configuration must not end up in the logs.
+	parent.Message = sensitive.Redact(parent.Message)
+
 	entry := &Entry{
 		Entry:  parent,
 		Level:  FromLogrusLevel(parent.Level),
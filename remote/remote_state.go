@@ -4,13 +4,17 @@ package remote
 import (
 	"context"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
+	"strconv"
 	"sync"
 
 	"github.com/gruntwork-io/terragrunt/codegen"
 	"github.com/gruntwork-io/terragrunt/internal/cache"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
 )
 
 const initializedRemoteStateCacheName = "initializedRemoteStateCache"
@@ -68,10 +72,76 @@ type RemoteStateInitializer interface {
 	GetTerraformInitArgs(config map[string]any) map[string]any
 }
 
-// TODO: initialization actions for other remote state backends can be added here
+// RemoteStateReader is implemented by remote state initializers that are able to fetch the raw Terraform state
+// directly from the backend storage, without running `terraform init` and `terraform output`.
+type RemoteStateReader interface {
+	// ReadState returns the contents of the Terraform state file stored in the backend. If there is no state stored
+	// yet, an empty slice is returned.
+	ReadState(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) ([]byte, error)
+}
+
+// RemoteStateVersionReader is implemented by remote state initializers that are able to tell the version of the
+// Terraform state stored in the backend cheaply, from metadata, without downloading the state.
+type RemoteStateVersionReader interface {
+	// ReadStateVersion returns an opaque identifier of the version of the state stored in the backend, which changes
+	// whenever the state is written. If there is no state stored yet, an empty string is returned.
+	ReadStateVersion(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) (string, error)
+}
+
+// TerragruntOnlyConfigsProvider is implemented by remote state initializers whose config has keys that are only used
+// by Terragrunt, such as the tags of the S3 bucket. These keys are never passed to OpenTofu/Terraform, so they are not
+// recorded in the backend block of the .terraform/terraform.tfstate file.
+type TerragruntOnlyConfigsProvider interface {
+	// TerragruntOnlyConfigs returns the keys of the config that are only used by Terragrunt.
+	TerragruntOnlyConfigs() []string
+}
+
+// remoteStateInitializers holds the initializers for every known remote state backend, keyed by the backend name.
+// Additional backends can be added with RegisterRemoteStateInitializer.
 var remoteStateInitializers = map[string]RemoteStateInitializer{
-	"s3":  S3Initializer{},
-	"gcs": GCSInitializer{},
+	"s3":    S3Initializer{},
+	"gcs":   GCSInitializer{},
+	"http":  HTTPInitializer{},
+	"local": LocalInitializer{},
+}
+
+var remoteStateInitializersLock sync.RWMutex
+
+// RegisterRemoteStateInitializer registers the given initializer for the given backend name, replacing any
+// initializer previously registered for that backend.
+func RegisterRemoteStateInitializer(backend string, initializer RemoteStateInitializer) error {
+	if backend == "" {
+		return errors.New(ErrRemoteBackendMissing)
+	}
+
+	if initializer == nil {
+		return errors.New(NilRemoteStateInitializerError(backend))
+	}
+
+	remoteStateInitializersLock.Lock()
+	defer remoteStateInitializersLock.Unlock()
+
+	remoteStateInitializers[backend] = initializer
+
+	return nil
+}
+
+// GetRemoteStateInitializer returns the initializer registered for the given backend name, if there is one.
+func GetRemoteStateInitializer(backend string) (RemoteStateInitializer, bool) {
+	remoteStateInitializersLock.RLock()
+	defer remoteStateInitializersLock.RUnlock()
+
+	initializer, ok := remoteStateInitializers[backend]
+
+	return initializer, ok
+}
+
+// RegisteredRemoteStateBackends returns the sorted names of all backends that have an initializer registered.
+func RegisteredRemoteStateBackends() []string {
+	remoteStateInitializersLock.RLock()
+	defer remoteStateInitializersLock.RUnlock()
+
+	return slices.Sorted(maps.Keys(remoteStateInitializers))
 }
 
 // FillDefaults fills in any default configuration for remote state
@@ -93,7 +163,7 @@ func (state *RemoteState) Validate() error {
 func (state *RemoteState) Initialize(ctx context.Context, terragruntOptions *options.TerragruntOptions) error {
 	terragruntOptions.Logger.Debugf("Initializing remote state for the %s backend", state.Backend)
 
-	initializer, hasInitializer := remoteStateInitializers[state.Backend]
+	initializer, hasInitializer := GetRemoteStateInitializer(state.Backend)
 	if hasInitializer {
 		return initializer.Initialize(ctx, state, terragruntOptions)
 	}
@@ -127,7 +197,7 @@ func (state *RemoteState) NeedsInit(terragruntOptions *options.TerragruntOptions
 		return true, nil
 	}
 
-	if initializer, hasInitializer := remoteStateInitializers[state.Backend]; hasInitializer {
+	if initializer, hasInitializer := GetRemoteStateInitializer(state.Backend); hasInitializer {
 		// Remote state initializer says initialization is necessary
 		return initializer.NeedsInitialization(state, parsedState.Backend, terragruntOptions)
 	} else if parsedState.IsRemote() && state.DiffersFrom(parsedState.Backend, terragruntOptions) {
@@ -138,6 +208,63 @@ func (state *RemoteState) NeedsInit(terragruntOptions *options.TerragruntOptions
 	return false, nil
 }
 
+// ReadState fetches the raw Terraform state directly from the backend storage, if the initializer registered for the
+// backend supports it. Returns a StateReadNotSupportedError otherwise.
+func (state *RemoteState) ReadState(ctx context.Context, terragruntOptions *options.TerragruntOptions) ([]byte, error) {
+	initializer, hasInitializer := GetRemoteStateInitializer(state.Backend)
+	if !hasInitializer {
+		return nil, errors.New(StateReadNotSupportedError(state.Backend))
+	}
+
+	reader, ok := initializer.(RemoteStateReader)
+	if !ok {
+		return nil, errors.New(StateReadNotSupportedError(state.Backend))
+	}
+
+	return reader.ReadState(ctx, state, terragruntOptions)
+}
+
+// CanReadState returns true if the initializer registered for the backend is able to read state directly.
+func (state *RemoteState) CanReadState() bool {
+	initializer, hasInitializer := GetRemoteStateInitializer(state.Backend)
+	if !hasInitializer {
+		return false
+	}
+
+	_, ok := initializer.(RemoteStateReader)
+
+	return ok
+}
+
+// ReadStateVersion returns the version of the Terraform state stored in the backend, if the initializer registered for
+// the backend supports it. Returns a StateVersionReadNotSupportedError otherwise.
+func (state *RemoteState) ReadStateVersion(ctx context.Context, terragruntOptions *options.TerragruntOptions) (string, error) {
+	initializer, hasInitializer := GetRemoteStateInitializer(state.Backend)
+	if !hasInitializer {
+		return "", errors.New(StateVersionReadNotSupportedError(state.Backend))
+	}
+
+	reader, ok := initializer.(RemoteStateVersionReader)
+	if !ok {
+		return "", errors.New(StateVersionReadNotSupportedError(state.Backend))
+	}
+
+	return reader.ReadStateVersion(ctx, state, terragruntOptions)
+}
+
+// CanReadStateVersion returns true if the initializer registered for the backend is able to read the version of the
+// state.
+func (state *RemoteState) CanReadStateVersion() bool {
+	initializer, hasInitializer := GetRemoteStateInitializer(state.Backend)
+	if !hasInitializer {
+		return false
+	}
+
+	_, ok := initializer.(RemoteStateVersionReader)
+
+	return ok
+}
+
 // DiffersFrom returns true if this remote state is different than
 // the given remote state that is currently being used by terraform.
 func (state *RemoteState) DiffersFrom(existingBackend *TerraformBackend, terragruntOptions *options.TerragruntOptions) bool {
@@ -156,6 +283,21 @@ func (state *RemoteState) DiffersFrom(existingBackend *TerraformBackend, terragr
 	return false
 }
 
+// BackendDiffersFrom returns true if the given backend, that terraform is currently initialized with, is different
+// than this remote state. Unlike DiffersFrom, only the keys that are passed to the backend are compared, so changes
+// of the keys only used by Terragrunt are ignored.
+func (state *RemoteState) BackendDiffersFrom(existingBackend *TerraformBackend, terragruntOptions *options.TerragruntOptions) bool {
+	var terragruntOnlyKeys []string
+
+	if initializer, hasInitializer := GetRemoteStateInitializer(state.Backend); hasInitializer {
+		if provider, ok := initializer.(TerragruntOnlyConfigsProvider); ok {
+			terragruntOnlyKeys = provider.TerragruntOnlyConfigs()
+		}
+	}
+
+	return !backendConfigValuesEqual(state.Backend, state.Config, existingBackend, terragruntOnlyKeys, terragruntOptions)
+}
+
 // Return true if the existing config from a .tfstate file is equal to the new config from the user's backend
 // configuration. Under the hood, this method does a reflect.DeepEqual check, but with one twist: we strip out any
 // null values in the existing config. This is because Terraform >= 0.12 stores ALL possible keys for a given backend
@@ -171,6 +313,70 @@ func terraformStateConfigEqual(existingConfig map[string]any, newConfig map[stri
 	return reflect.DeepEqual(existingConfigNonNil, newConfig)
 }
 
+// backendConfigValuesEqual returns true if the given config matches what is configured for the existing backend of
+// the given type. Keys listed in terragruntOnlyKeys are ignored, and boolean values that Terraform stored as strings
+// are converted before the comparison. Neither the config nor the existing backend are modified.
+func backendConfigValuesEqual(backend string, config map[string]any, existingBackend *TerraformBackend, terragruntOnlyKeys []string, terragruntOptions *options.TerragruntOptions) bool {
+	if existingBackend == nil {
+		return len(config) == 0
+	}
+
+	if existingBackend.Type != backend {
+		terragruntOptions.Logger.Debugf("Backend type has changed from %s to %s", existingBackend.Type, backend)
+		return false
+	}
+
+	if len(config) == 0 && len(existingBackend.Config) == 0 {
+		return true
+	}
+
+	terraformConfig := map[string]any{}
+
+	for key, val := range config {
+		if !util.ListContainsElement(terragruntOnlyKeys, key) {
+			terraformConfig[key] = val
+		}
+	}
+
+	var existingConfig map[string]any
+
+	if existingBackend.Config != nil {
+		existingConfig = make(map[string]any, len(existingBackend.Config))
+
+		for key, val := range existingBackend.Config {
+			if strVal, ok := val.(string); ok && util.KindOf(terraformConfig[key]) == reflect.Bool {
+				if boolVal, err := strconv.ParseBool(strVal); err == nil {
+					val = boolVal
+				}
+			}
+
+			existingConfig[key] = val
+		}
+	}
+
+	if !terraformStateConfigEqual(existingConfig, terraformConfig) {
+		terragruntOptions.Logger.Debugf("Backend config changed from %s to %s", existingBackend.Config, config)
+		return false
+	}
+
+	return true
+}
+
+// filterTerragruntOnlyConfigs returns a copy of the given config without the keys listed in terragruntOnlyKeys.
+func filterTerragruntOnlyConfigs(config map[string]any, terragruntOnlyKeys []string) map[string]any {
+	filteredConfig := make(map[string]any, len(config))
+
+	for key, val := range config {
+		if util.ListContainsElement(terragruntOnlyKeys, key) {
+			continue
+		}
+
+		filteredConfig[key] = val
+	}
+
+	return filteredConfig
+}
+
 // Copy the non-nil values from the existingMap to a new map
 func copyExistingNotNullValues(existingMap map[string]any, newMap map[string]any) map[string]any {
 	existingConfigNonNil := map[string]any{}
@@ -207,7 +413,7 @@ func (state *RemoteState) ToTerraformInitArgs() []string {
 		return []string{}
 	}
 
-	initializer, hasInitializer := remoteStateInitializers[state.Backend]
+	initializer, hasInitializer := GetRemoteStateInitializer(state.Backend)
 	if hasInitializer {
 		// get modified config from backend, if backend exists
 		config = initializer.GetTerraformInitArgs(state.Config)
@@ -262,7 +468,7 @@ func (state *RemoteState) GenerateTerraformCode(terragruntOptions *options.Terra
 		}
 	}
 
-	initializer, hasInitializer := remoteStateInitializers[state.Backend]
+	initializer, hasInitializer := GetRemoteStateInitializer(state.Backend)
 	if hasInitializer {
 		config = initializer.GetTerraformInitArgs(config)
 	}
@@ -295,6 +501,24 @@ var (
 	ErrGenerateCalledWithNoGenerateAttr = errors.New("generate code routine called when no generate attribute is configured")
 )
 
+type NilRemoteStateInitializerError string
+
+func (backend NilRemoteStateInitializerError) Error() string {
+	return fmt.Sprintf("Cannot register a nil remote state initializer for the %s backend", string(backend))
+}
+
+type StateReadNotSupportedError string
+
+func (backend StateReadNotSupportedError) Error() string {
+	return fmt.Sprintf("Reading state directly is not supported for the %s backend", string(backend))
+}
+
+type StateVersionReadNotSupportedError string
+
+func (backend StateVersionReadNotSupportedError) Error() string {
+	return fmt.Sprintf("Reading the state version is not supported for the %s backend", string(backend))
+}
+
 type BucketCreationNotAllowed string
 
 func (bucketName BucketCreationNotAllowed) Error() string {
@@ -2,13 +2,18 @@ package remote
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"path"
 	"reflect"
 	"strconv"
 	"time"
 
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/impersonate"
 
 	"maps"
@@ -74,6 +79,12 @@ type accountFile struct {
 	ClientID     string `json:"client_id"`
 }
 
+// gcsDefaultStateFileName is the name of the state object of the default workspace within the configured prefix.
+const gcsDefaultStateFileName = "default.tfstate"
+
+// gcsDefaultLockFileName is the name of the lock object of the default workspace within the configured prefix.
+const gcsDefaultLockFileName = "default.tflock"
+
 const MaxRetriesWaitingForGcsBucket = 12
 const SleepBetweenRetriesWaitingForGcsBucket = 5 * time.Second
 
@@ -233,6 +244,11 @@ func (initializer GCSInitializer) Initialize(ctx context.Context, remoteState *R
 	})
 }
 
+// TerragruntOnlyConfigs implements TerragruntOnlyConfigsProvider.TerragruntOnlyConfigs
+func (initializer GCSInitializer) TerragruntOnlyConfigs() []string {
+	return terragruntGCSOnlyConfigs
+}
+
 // GetTerraformInitArgs returns the subset of the given config that should be passed to terraform init
 // when initializing the remote state.
 func (initializer GCSInitializer) GetTerraformInitArgs(config map[string]any) map[string]any {
@@ -249,6 +265,275 @@ func (initializer GCSInitializer) GetTerraformInitArgs(config map[string]any) ma
 	return filteredConfig
 }
 
+// ReadState downloads the Terraform state object of the default workspace from the GCS bucket specified in the given
+// config.
+func (initializer GCSInitializer) ReadState(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) ([]byte, error) {
+	gcsConfig, err := parseGCSConfig(remoteState.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	objectName := gcsStateObjectName(gcsConfig)
+
+	terragruntOptions.Logger.Debugf("Fetching state directly from gs://%s/%s", gcsConfig.Bucket, objectName)
+
+	gcsClient, err := CreateGCSClient(ctx, *gcsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := gcsClient.Close(); err != nil {
+			terragruntOptions.Logger.Warnf("Error closing GCS client: %v", err)
+		}
+	}()
+
+	objectHandle := gcsClient.Bucket(gcsConfig.Bucket).Object(objectName)
+
+	if gcsConfig.EncryptionKey != "" {
+		encryptionKey, err := base64.StdEncoding.DecodeString(gcsConfig.EncryptionKey)
+		if err != nil {
+			return nil, errors.Errorf("error decoding GCS encryption_key: %w", err)
+		}
+
+		objectHandle = objectHandle.Key(encryptionKey)
+	}
+
+	reader, err := objectHandle.NewReader(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, nil
+		}
+
+		return nil, errors.New(err)
+	}
+
+	defer func() {
+		if err := reader.Close(); err != nil {
+			terragruntOptions.Logger.Warnf("Failed to close remote state response %v", err)
+		}
+	}()
+
+	stateBody, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return stateBody, nil
+}
+
+// ReadStateVersion returns the generation of the Terraform state object of the default workspace in the GCS bucket,
+// from the attributes of the object.
+func (initializer GCSInitializer) ReadStateVersion(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) (string, error) {
+	gcsConfig, err := parseGCSConfig(remoteState.Config)
+	if err != nil {
+		return "", err
+	}
+
+	gcsClient, err := CreateGCSClient(ctx, *gcsConfig)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		if err := gcsClient.Close(); err != nil {
+			terragruntOptions.Logger.Warnf("Error closing GCS client: %v", err)
+		}
+	}()
+
+	attrs, err := gcsClient.Bucket(gcsConfig.Bucket).Object(gcsStateObjectName(gcsConfig)).Attrs(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return "", nil
+		}
+
+		return "", errors.New(err)
+	}
+
+	return strconv.FormatInt(attrs.Generation, 10), nil
+}
+
+// ReadLock returns the info of the lock held on the state of the default workspace, which the gcs backend stores as
+// a `<prefix>/default.tflock` object next to the state.
+func (initializer GCSInitializer) ReadLock(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) (*LockInfo, error) {
+	gcsConfig, err := parseGCSConfig(remoteState.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	gcsClient, err := CreateGCSClient(ctx, *gcsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := gcsClient.Close(); err != nil {
+			terragruntOptions.Logger.Warnf("Error closing GCS client: %v", err)
+		}
+	}()
+
+	reader, err := gcsClient.Bucket(gcsConfig.Bucket).Object(gcsLockObjectName(gcsConfig)).NewReader(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, nil
+		}
+
+		return nil, errors.New(err)
+	}
+
+	defer reader.Close() //nolint:errcheck
+
+	lockBody, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return ParseLockInfo(lockBody)
+}
+
+// ReleaseLock deletes the lock object of the default workspace state, only if it still holds the given lock: the
+// delete is conditional on the generation of the object that was read.
+func (initializer GCSInitializer) ReleaseLock(ctx context.Context, remoteState *RemoteState, lock *LockInfo, terragruntOptions *options.TerragruntOptions) error {
+	gcsConfig, err := parseGCSConfig(remoteState.Config)
+	if err != nil {
+		return err
+	}
+
+	gcsClient, err := CreateGCSClient(ctx, *gcsConfig)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := gcsClient.Close(); err != nil {
+			terragruntOptions.Logger.Warnf("Error closing GCS client: %v", err)
+		}
+	}()
+
+	object := gcsClient.Bucket(gcsConfig.Bucket).Object(gcsLockObjectName(gcsConfig))
+
+	reader, err := object.NewReader(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return errors.New(LockChangedError{LockID: lock.ID})
+		}
+
+		return errors.New(err)
+	}
+
+	defer reader.Close() //nolint:errcheck
+
+	lockBody, err := io.ReadAll(reader)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if current, err := ParseLockInfo(lockBody); err != nil || current.ID != lock.ID {
+		return errors.New(LockChangedError{LockID: lock.ID})
+	}
+
+	err = object.If(storage.Conditions{GenerationMatch: reader.Attrs.Generation}).Delete(ctx)
+	if err != nil {
+		var apiErr *googleapi.Error
+		if errors.Is(err, storage.ErrObjectNotExist) || (errors.As(err, &apiErr) && apiErr.Code == http.StatusPreconditionFailed) {
+			return errors.New(LockChangedError{LockID: lock.ID})
+		}
+
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// GetStatus reports whether the GCS bucket of the given config exists and whether the state is stored in it.
+func (initializer GCSInitializer) GetStatus(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) (*BackendStatus, error) {
+	gcsConfig, err := parseGCSConfig(remoteState.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	gcsClient, err := CreateGCSClient(ctx, *gcsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := gcsClient.Close(); err != nil {
+			terragruntOptions.Logger.Warnf("Error closing GCS client: %v", err)
+		}
+	}()
+
+	bucketHandle := gcsClient.Bucket(gcsConfig.Bucket)
+
+	bucket := &BackendResource{
+		Type:   BackendResourceGCSBucket,
+		Name:   gcsConfig.Bucket,
+		Exists: DoesGCSBucketExist(ctx, bucketHandle),
+	}
+
+	status := &BackendStatus{
+		StatePath: fmt.Sprintf("gs://%s/%s", gcsConfig.Bucket, gcsStateObjectName(gcsConfig)),
+		Resources: []*BackendResource{bucket},
+	}
+
+	if !bucket.Exists {
+		return status, nil
+	}
+
+	attrs, err := bucketHandle.Attrs(ctx)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	status.Versioning = attrs.VersioningEnabled
+
+	if _, err := bucketHandle.Object(gcsStateObjectName(gcsConfig)).Attrs(ctx); err == nil {
+		status.StateExists = true
+	} else if !errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, errors.New(err)
+	}
+
+	return status, nil
+}
+
+// DeleteState deletes the state object of the default workspace from the GCS bucket.
+func (initializer GCSInitializer) DeleteState(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) error {
+	gcsConfig, err := parseGCSConfig(remoteState.Config)
+	if err != nil {
+		return err
+	}
+
+	gcsClient, err := CreateGCSClient(ctx, *gcsConfig)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := gcsClient.Close(); err != nil {
+			terragruntOptions.Logger.Warnf("Error closing GCS client: %v", err)
+		}
+	}()
+
+	terragruntOptions.Logger.Debugf("Deleting state gs://%s/%s", gcsConfig.Bucket, gcsStateObjectName(gcsConfig))
+
+	if err := gcsClient.Bucket(gcsConfig.Bucket).Object(gcsStateObjectName(gcsConfig)).Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// gcsStateObjectName returns the name of the object holding the state of the default workspace, following the same
+// naming scheme as the gcs backend: `<prefix>/default.tfstate`.
+func gcsStateObjectName(gcsConfig *RemoteStateConfigGCS) string {
+	return path.Join(gcsConfig.Prefix, gcsDefaultStateFileName)
+}
+
+// gcsLockObjectName returns the name of the object holding the lock of the default workspace state.
+func gcsLockObjectName(gcsConfig *RemoteStateConfigGCS) string {
+	return path.Join(gcsConfig.Prefix, gcsDefaultLockFileName)
+}
+
 // Parse the given map into a GCS config
 func parseGCSConfig(config map[string]any) (*RemoteStateConfigGCS, error) {
 	var gcsConfig RemoteStateConfigGCS
@@ -0,0 +1,52 @@
+package remote_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGCSInitializerReadState reads the state through a local stand-in for the GCS API, configured with the
+// STORAGE_EMULATOR_HOST environment variable that is honored by the GCS client library.
+func TestGCSInitializerReadState(t *testing.T) { //nolint:paralleltest
+	var requestedPaths []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPaths = append(requestedPaths, r.URL.Path)
+
+		if strings.HasSuffix(r.URL.Path, "/my-bucket/env/prod/default.tfstate") {
+			_, _ = w.Write([]byte(testHTTPState))
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	t.Setenv("STORAGE_EMULATOR_HOST", strings.TrimPrefix(server.URL, "http://"))
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("remote_state_test")
+	require.NoError(t, err)
+
+	remoteState := &remote.RemoteState{
+		Backend: "gcs",
+		Config:  map[string]any{"bucket": "my-bucket", "prefix": "env/prod", "project": "my-project"},
+	}
+
+	state, err := remoteState.ReadState(context.Background(), terragruntOptions)
+	require.NoError(t, err)
+	assert.JSONEq(t, testHTTPState, string(state), "requested paths: %v", requestedPaths)
+
+	remoteState.Config["prefix"] = "env/missing"
+
+	state, err = remoteState.ReadState(context.Background(), terragruntOptions)
+	require.NoError(t, err)
+	assert.Empty(t, state)
+}
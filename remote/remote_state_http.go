@@ -0,0 +1,322 @@
+package remote
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/mitchellh/mapstructure"
+)
+
+const (
+	httpAddressEnvName             = "TF_HTTP_ADDRESS"
+	httpUsernameEnvName            = "TF_HTTP_USERNAME"
+	httpPasswordEnvName            = "TF_HTTP_PASSWORD"
+	httpRetryMaxEnvName            = "TF_HTTP_RETRY_MAX"
+	httpRetryWaitMinEnvName        = "TF_HTTP_RETRY_WAIT_MIN"
+	httpRetryWaitMaxEnvName        = "TF_HTTP_RETRY_WAIT_MAX"
+	httpClientCACertificateEnvName = "TF_HTTP_CLIENT_CA_CERTIFICATE_PEM"
+	httpClientCertificateEnvName   = "TF_HTTP_CLIENT_CERTIFICATE_PEM"
+	httpClientPrivateKeyEnvName    = "TF_HTTP_CLIENT_PRIVATE_KEY_PEM"
+	httpDefaultRetryMax            = 2
+	httpDefaultRetryWaitMinSeconds = 1
+	httpDefaultRetryWaitMaxSeconds = 30
+	httpRequestTimeout             = 30 * time.Second
+)
+
+// These are settings that can appear in the remote_state config that are ONLY used by Terragrunt and NOT forwarded
+// to the underlying Terraform http backend configuration.
+var terragruntHTTPOnlyConfigs = []string{
+	"validate_address",
+}
+
+// ExtendedRemoteStateConfigHTTP is a struct that contains the http specific configuration options, including the
+// ones that are only used by Terragrunt.
+type ExtendedRemoteStateConfigHTTP struct {
+	RemoteStateConfigHTTP `mapstructure:",squash"`
+
+	ValidateAddress bool `mapstructure:"validate_address"`
+}
+
+// RemoteStateConfigHTTP is a representation of the configuration options available for the http remote state backend.
+// Only the options used to read the state are decoded, with the same defaults as the backend.
+type RemoteStateConfigHTTP struct {
+	Address                string `mapstructure:"address"`
+	Username               string `mapstructure:"username"`
+	Password               string `mapstructure:"password"`
+	SkipCertVerification   bool   `mapstructure:"skip_cert_verification"`
+	RetryMax               *int   `mapstructure:"retry_max"`
+	RetryWaitMin           *int   `mapstructure:"retry_wait_min"`
+	RetryWaitMax           *int   `mapstructure:"retry_wait_max"`
+	ClientCACertificatePEM string `mapstructure:"client_ca_certificate_pem"`
+	ClientCertificatePEM   string `mapstructure:"client_certificate_pem"`
+	ClientPrivateKeyPEM    string `mapstructure:"client_private_key_pem"`
+}
+
+// HTTPInitializer is the remote state initializer for the Terraform http backend.
+type HTTPInitializer struct{}
+
+// NeedsInitialization returns true if any of the existing http backend settings are different than the current config.
+func (initializer HTTPInitializer) NeedsInitialization(remoteState *RemoteState, existingBackend *TerraformBackend, terragruntOptions *options.TerragruntOptions) (bool, error) {
+	if remoteState.DisableInit {
+		return false, nil
+	}
+
+	return !backendConfigValuesEqual("http", remoteState.Config, existingBackend, terragruntHTTPOnlyConfigs, terragruntOptions), nil
+}
+
+// Initialize validates, if `validate_address` is set, that the state address from the given config is reachable with
+// the configured credentials. The http backend has no storage that Terragrunt could create, so nothing else is done
+// here.
+func (initializer HTTPInitializer) Initialize(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) error {
+	httpConfig, err := ParseExtendedHTTPConfig(remoteState.Config, terragruntOptions)
+	if err != nil {
+		return err
+	}
+
+	if httpConfig.Address == "" {
+		return errors.New(MissingRequiredHTTPRemoteStateConfig("address"))
+	}
+
+	if !httpConfig.ValidateAddress {
+		return nil
+	}
+
+	cacheKey := "http-" + httpConfig.Address
+	if initialized, hit := initializedRemoteStateCache.Get(ctx, cacheKey); initialized && hit {
+		terragruntOptions.Logger.Debugf("HTTP state address %s has already been confirmed to be reachable, skipping validation", httpConfig.Address)
+		return nil
+	}
+
+	if _, err := fetchHTTPState(ctx, &httpConfig.RemoteStateConfigHTTP, terragruntOptions); err != nil {
+		return err
+	}
+
+	initializedRemoteStateCache.Put(ctx, cacheKey, true)
+
+	return nil
+}
+
+// TerragruntOnlyConfigs implements TerragruntOnlyConfigsProvider.TerragruntOnlyConfigs
+func (initializer HTTPInitializer) TerragruntOnlyConfigs() []string {
+	return terragruntHTTPOnlyConfigs
+}
+
+// GetTerraformInitArgs returns the subset of the given config that should be passed to terraform init
+// when initializing the remote state.
+func (initializer HTTPInitializer) GetTerraformInitArgs(config map[string]any) map[string]any {
+	return filterTerragruntOnlyConfigs(config, terragruntHTTPOnlyConfigs)
+}
+
+// ReadState downloads the Terraform state from the address specified in the given config.
+func (initializer HTTPInitializer) ReadState(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) ([]byte, error) {
+	httpConfig, err := ParseExtendedHTTPConfig(remoteState.Config, terragruntOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpConfig.Address == "" {
+		return nil, errors.New(MissingRequiredHTTPRemoteStateConfig("address"))
+	}
+
+	terragruntOptions.Logger.Debugf("Fetching state directly from %s", httpConfig.Address)
+
+	return fetchHTTPState(ctx, &httpConfig.RemoteStateConfigHTTP, terragruntOptions)
+}
+
+// ParseExtendedHTTPConfig parses the given map into an extended http config. Just like Terraform does, the settings
+// fall back to the TF_HTTP_* environment variables when they are not set in the config.
+func ParseExtendedHTTPConfig(config map[string]any, terragruntOptions *options.TerragruntOptions) (*ExtendedRemoteStateConfigHTTP, error) {
+	var extendedConfig ExtendedRemoteStateConfigHTTP
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &extendedConfig,
+	})
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	if err := decoder.Decode(config); err != nil {
+		return nil, errors.New(err)
+	}
+
+	envFallbacks := []struct {
+		setting *string
+		envName string
+	}{
+		{&extendedConfig.Address, httpAddressEnvName},
+		{&extendedConfig.Username, httpUsernameEnvName},
+		{&extendedConfig.Password, httpPasswordEnvName},
+		{&extendedConfig.ClientCACertificatePEM, httpClientCACertificateEnvName},
+		{&extendedConfig.ClientCertificatePEM, httpClientCertificateEnvName},
+		{&extendedConfig.ClientPrivateKeyPEM, httpClientPrivateKeyEnvName},
+	}
+
+	for _, fallback := range envFallbacks {
+		if *fallback.setting == "" {
+			*fallback.setting = terragruntOptions.Env[fallback.envName]
+		}
+	}
+
+	if extendedConfig.RetryMax == nil {
+		if extendedConfig.RetryMax, err = httpEnvInt(terragruntOptions, httpRetryMaxEnvName, httpDefaultRetryMax); err != nil {
+			return nil, err
+		}
+	}
+
+	if extendedConfig.RetryWaitMin == nil {
+		if extendedConfig.RetryWaitMin, err = httpEnvInt(terragruntOptions, httpRetryWaitMinEnvName, httpDefaultRetryWaitMinSeconds); err != nil {
+			return nil, err
+		}
+	}
+
+	if extendedConfig.RetryWaitMax == nil {
+		if extendedConfig.RetryWaitMax, err = httpEnvInt(terragruntOptions, httpRetryWaitMaxEnvName, httpDefaultRetryWaitMaxSeconds); err != nil {
+			return nil, err
+		}
+	}
+
+	return &extendedConfig, nil
+}
+
+// httpEnvInt returns the integer value of the given environment variable, or the given default value if it is not set.
+func httpEnvInt(terragruntOptions *options.TerragruntOptions, envName string, defaultValue int) (*int, error) {
+	value := defaultValue
+
+	if envValue := terragruntOptions.Env[envName]; envValue != "" {
+		var err error
+		if value, err = strconv.Atoi(envValue); err != nil {
+			return nil, errors.Errorf("invalid value of %s: %w", envName, err)
+		}
+	}
+
+	return &value, nil
+}
+
+// newHTTPStateClient returns a client with the TLS settings of the http backend: the CA certificate to verify the
+// server with, the client certificate for mutual TLS and skip_cert_verification.
+func newHTTPStateClient(config *RemoteStateConfigHTTP) (*http.Client, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.SkipCertVerification, //nolint:gosec
+	}
+
+	if config.ClientCACertificatePEM != "" {
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM([]byte(config.ClientCACertificatePEM)) {
+			return nil, errors.New("failed to read the http backend client_ca_certificate_pem")
+		}
+
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	if config.ClientCertificatePEM != "" || config.ClientPrivateKeyPEM != "" {
+		if config.ClientCertificatePEM == "" || config.ClientPrivateKeyPEM == "" {
+			return nil, errors.New("the http backend client_certificate_pem and client_private_key_pem must be set together")
+		}
+
+		certificate, err := tls.X509KeyPair([]byte(config.ClientCertificatePEM), []byte(config.ClientPrivateKeyPEM))
+		if err != nil {
+			return nil, errors.Errorf("failed to read the http backend client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	transport := cleanhttp.DefaultPooledTransport()
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{Timeout: httpRequestTimeout, Transport: transport}, nil
+}
+
+// fetchHTTPState performs a GET request against the state address, retried like the http backend does on connection
+// errors and server errors. Following the http backend protocol, a missing state is signaled with a 404 or 204 status
+// code, in which case an empty result is returned.
+func fetchHTTPState(ctx context.Context, config *RemoteStateConfigHTTP, terragruntOptions *options.TerragruntOptions) ([]byte, error) {
+	client, err := newHTTPStateClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	wait := time.Duration(*config.RetryWaitMin) * time.Second
+	maxWait := time.Duration(*config.RetryWaitMax) * time.Second
+
+	for attempt := 0; ; attempt++ {
+		body, retry, err := getHTTPState(ctx, client, config)
+		if err == nil || !retry || attempt >= *config.RetryMax {
+			return body, err
+		}
+
+		terragruntOptions.Logger.Debugf("Failed to fetch state from %s, retrying in %s: %v", config.Address, wait, err)
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.New(ctx.Err())
+		case <-time.After(wait):
+		}
+
+		wait = min(wait*2, maxWait) //nolint:mnd
+	}
+}
+
+// getHTTPState performs a single GET request against the state address, and returns whether it is worth retrying
+// when it fails.
+func getHTTPState(ctx context.Context, client *http.Client, config *RemoteStateConfigHTTP) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, config.Address, nil)
+	if err != nil {
+		return nil, false, errors.New(err)
+	}
+
+	if config.Username != "" {
+		req.SetBasicAuth(config.Username, config.Password)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, ctx.Err() == nil, errors.New(err)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+	case resp.StatusCode == http.StatusNoContent, resp.StatusCode == http.StatusNotFound:
+		return nil, false, nil
+	default:
+		retry := resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+		return nil, retry, errors.New(HTTPStateRequestError{Address: config.Address, StatusCode: resp.StatusCode})
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, errors.New(err)
+	}
+
+	return body, false, nil
+}
+
+// Custom error types
+
+type MissingRequiredHTTPRemoteStateConfig string
+
+func (configName MissingRequiredHTTPRemoteStateConfig) Error() string {
+	return "Missing required http remote state configuration " + string(configName)
+}
+
+type HTTPStateRequestError struct {
+	Address    string
+	StatusCode int
+}
+
+func (err HTTPStateRequestError) Error() string {
+	return fmt.Sprintf("Unexpected status code %d while fetching state from %s", err.StatusCode, err.Address)
+}
@@ -0,0 +1,268 @@
+package remote_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testHTTPState = `{"version": 4, "serial": 3, "lineage": "abc", "outputs": {"foo": {"value": "bar", "type": "string"}}}`
+
+func newTestHTTPStateServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "user" || password != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/state/existing":
+			_, _ = w.Write([]byte(testHTTPState))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestToTerraformInitArgsForHTTP(t *testing.T) {
+	t.Parallel()
+
+	remoteState := remote.RemoteState{
+		Backend: "http",
+		Config: map[string]any{
+			"address":                 "https://state.example.com/foo",
+			"lock_address":            "https://state.example.com/foo/lock",
+			"validate_address": true,
+		},
+	}
+	args := remoteState.ToTerraformInitArgs()
+
+	// must not contain validate_address
+	assertTerraformInitArgsEqual(t, args, "-backend-config=address=https://state.example.com/foo -backend-config=lock_address=https://state.example.com/foo/lock")
+}
+
+func TestHTTPInitializerNeedsInitialization(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("remote_state_test")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		existingBackend *remote.TerraformBackend
+		config          map[string]any
+		name            string
+		expected        bool
+	}{
+		{
+			name:            "same-address",
+			config:          map[string]any{"address": "https://a", "validate_address": true},
+			existingBackend: &remote.TerraformBackend{Type: "http", Config: map[string]any{"address": "https://a", "lock_address": nil}},
+			expected:        false,
+		},
+		{
+			name:            "bool-stored-as-string",
+			config:          map[string]any{"address": "https://a", "skip_cert_verification": true},
+			existingBackend: &remote.TerraformBackend{Type: "http", Config: map[string]any{"address": "https://a", "skip_cert_verification": "true"}},
+			expected:        false,
+		},
+		{
+			name:            "different-address",
+			config:          map[string]any{"address": "https://b"},
+			existingBackend: &remote.TerraformBackend{Type: "http", Config: map[string]any{"address": "https://a"}},
+			expected:        true,
+		},
+		{
+			name:            "different-backend",
+			config:          map[string]any{"address": "https://a"},
+			existingBackend: &remote.TerraformBackend{Type: "s3", Config: map[string]any{"address": "https://a"}},
+			expected:        true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			remoteState := &remote.RemoteState{Backend: "http", Config: tc.config}
+
+			actual, err := remote.HTTPInitializer{}.NeedsInitialization(remoteState, tc.existingBackend, terragruntOptions)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestHTTPInitializerReadState(t *testing.T) {
+	t.Parallel()
+
+	server := newTestHTTPStateServer(t)
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("remote_state_test")
+	require.NoError(t, err)
+
+	remoteState := &remote.RemoteState{
+		Backend: "http",
+		Config:  map[string]any{"address": server.URL + "/state/existing", "username": "user", "password": "secret"},
+	}
+
+	state, err := remoteState.ReadState(context.Background(), terragruntOptions)
+	require.NoError(t, err)
+	assert.JSONEq(t, testHTTPState, string(state))
+
+	remoteState.Config["address"] = server.URL + "/state/missing"
+
+	state, err = remoteState.ReadState(context.Background(), terragruntOptions)
+	require.NoError(t, err)
+	assert.Empty(t, state)
+}
+
+func TestHTTPInitializerInitialize(t *testing.T) {
+	t.Parallel()
+
+	server := newTestHTTPStateServer(t)
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("remote_state_test")
+	require.NoError(t, err)
+
+	// The address is not requested unless validate_address is set.
+	remoteState := &remote.RemoteState{
+		Backend: "http",
+		Config:  map[string]any{"address": server.URL + "/state/forbidden", "username": "user", "password": "wrong"},
+	}
+	require.NoError(t, remoteState.Initialize(context.Background(), terragruntOptions))
+
+	remoteState.Config["validate_address"] = true
+
+	err = remoteState.Initialize(context.Background(), terragruntOptions)
+
+	var requestErr remote.HTTPStateRequestError
+
+	require.ErrorAs(t, err, &requestErr)
+	assert.Equal(t, http.StatusForbidden, requestErr.StatusCode)
+
+	remoteState.Config["address"] = server.URL + "/state/initialize"
+	remoteState.Config["password"] = "secret"
+	require.NoError(t, remoteState.Initialize(context.Background(), terragruntOptions))
+}
+
+func TestHTTPInitializerReadStateRetries(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = w.Write([]byte(testHTTPState))
+	}))
+	t.Cleanup(server.Close)
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("remote_state_test")
+	require.NoError(t, err)
+
+	remoteState := &remote.RemoteState{
+		Backend: "http",
+		Config:  map[string]any{"address": server.URL, "retry_wait_min": 0},
+	}
+
+	state, err := remoteState.ReadState(context.Background(), terragruntOptions)
+	require.NoError(t, err)
+	assert.JSONEq(t, testHTTPState, string(state))
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestHTTPInitializerReadStateWithClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	clientCert, clientKey := newTestCertificate(t)
+
+	clientCAs := x509.NewCertPool()
+	require.True(t, clientCAs.AppendCertsFromPEM(clientCert))
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(testHTTPState))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	serverCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("remote_state_test")
+	require.NoError(t, err)
+
+	terragruntOptions.Env = map[string]string{"TF_HTTP_CLIENT_PRIVATE_KEY_PEM": string(clientKey)}
+
+	remoteState := &remote.RemoteState{
+		Backend: "http",
+		Config: map[string]any{
+			"address":                   server.URL,
+			"client_ca_certificate_pem": string(serverCert),
+			"client_certificate_pem":    string(clientCert),
+			"retry_max":                 0,
+		},
+	}
+
+	state, err := remoteState.ReadState(context.Background(), terragruntOptions)
+	require.NoError(t, err)
+	assert.JSONEq(t, testHTTPState, string(state))
+
+	// Without the client certificate, the server refuses the connection.
+	delete(remoteState.Config, "client_certificate_pem")
+	terragruntOptions.Env = nil
+
+	_, err = remoteState.ReadState(context.Background(), terragruntOptions)
+	require.Error(t, err)
+}
+
+// newTestCertificate returns a self-signed client certificate and its private key, PEM encoded.
+func newTestCertificate(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terragrunt"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
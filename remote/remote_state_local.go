@@ -0,0 +1,192 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/mitchellh/mapstructure"
+)
+
+// RemoteStateConfigLocal is a representation of the configuration options available for the local backend.
+type RemoteStateConfigLocal struct {
+	Path         string `mapstructure:"path"`
+	WorkspaceDir string `mapstructure:"workspace_dir"`
+}
+
+// LocalInitializer is the remote state initializer for the Terraform local backend.
+type LocalInitializer struct{}
+
+// NeedsInitialization returns true if the local backend recorded in the .terraform dir has different settings than
+// the current config. When the parsed state is the local state file itself there is no backend recorded, and
+// nothing needs to be initialized.
+func (initializer LocalInitializer) NeedsInitialization(remoteState *RemoteState, existingBackend *TerraformBackend, terragruntOptions *options.TerragruntOptions) (bool, error) {
+	if remoteState.DisableInit || existingBackend == nil {
+		return false, nil
+	}
+
+	return !backendConfigValuesEqual("local", remoteState.Config, existingBackend, nil, terragruntOptions), nil
+}
+
+// Initialize makes sure the directories that will hold the state files exist. Relative paths are resolved against
+// the Terraform working dir.
+func (initializer LocalInitializer) Initialize(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) error {
+	localConfig, err := parseLocalConfig(remoteState.Config)
+	if err != nil {
+		return err
+	}
+
+	if localConfig.Path != "" {
+		stateDir := filepath.Dir(resolveLocalStatePath(localConfig.Path, terragruntOptions.WorkingDir))
+		if err := util.EnsureDirectory(stateDir); err != nil {
+			return err
+		}
+	}
+
+	if localConfig.WorkspaceDir != "" {
+		if err := util.EnsureDirectory(resolveLocalStatePath(localConfig.WorkspaceDir, terragruntOptions.WorkingDir)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetTerraformInitArgs returns the given config unchanged, as there are no Terragrunt specific settings for the
+// local backend.
+func (initializer LocalInitializer) GetTerraformInitArgs(config map[string]any) map[string]any {
+	return filterTerragruntOnlyConfigs(config, nil)
+}
+
+// ReadState reads the state file specified in the given config.
+func (initializer LocalInitializer) ReadState(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) ([]byte, error) {
+	localConfig, err := parseLocalConfig(remoteState.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	statePath := localConfig.statePath(terragruntOptions)
+
+	terragruntOptions.Logger.Debugf("Fetching state directly from %s", statePath)
+
+	if !util.FileExists(statePath) {
+		return nil, nil
+	}
+
+	stateBody, err := os.ReadFile(statePath)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return stateBody, nil
+}
+
+// ReadStateVersion returns the lineage and serial of the state file, which Terraform bumps on every write. Reading
+// the local file is cheap, so there is no need for a metadata check.
+func (initializer LocalInitializer) ReadStateVersion(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) (string, error) {
+	stateBody, err := initializer.ReadState(ctx, remoteState, terragruntOptions)
+	if err != nil || len(stateBody) == 0 {
+		return "", err
+	}
+
+	state, err := ParseTerraformState(stateBody)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/%d", state.Lineage, state.Serial), nil
+}
+
+// ReadLock returns the info of the lock held on the state file, which the local backend records in a
+// `.<state file name>.lock.info` file next to the state.
+func (initializer LocalInitializer) ReadLock(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) (*LockInfo, error) {
+	localConfig, err := parseLocalConfig(remoteState.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	statePath := localConfig.statePath(terragruntOptions)
+	lockPath := filepath.Join(filepath.Dir(statePath), "."+filepath.Base(statePath)+".lock.info")
+
+	if !util.FileExists(lockPath) {
+		return nil, nil
+	}
+
+	lockBody, err := os.ReadFile(lockPath)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return ParseLockInfo(lockBody)
+}
+
+// GetStatus reports whether the dir holding the state file exists and whether the state file is in it.
+func (initializer LocalInitializer) GetStatus(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) (*BackendStatus, error) {
+	localConfig, err := parseLocalConfig(remoteState.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	statePath := localConfig.statePath(terragruntOptions)
+
+	return &BackendStatus{
+		StatePath: statePath,
+		Resources: []*BackendResource{{
+			Type:   BackendResourceDir,
+			Name:   filepath.Dir(statePath),
+			Exists: util.IsDir(filepath.Dir(statePath)),
+		}},
+		StateExists: util.FileExists(statePath),
+	}, nil
+}
+
+// DeleteState removes the state file.
+func (initializer LocalInitializer) DeleteState(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) error {
+	localConfig, err := parseLocalConfig(remoteState.Config)
+	if err != nil {
+		return err
+	}
+
+	statePath := localConfig.statePath(terragruntOptions)
+
+	terragruntOptions.Logger.Debugf("Deleting state %s", statePath)
+
+	if err := os.Remove(statePath); err != nil && !os.IsNotExist(err) {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// statePath returns the absolute path of the state file. Relative paths are resolved against the Terraform working
+// dir, as in Initialize.
+func (localConfig *RemoteStateConfigLocal) statePath(terragruntOptions *options.TerragruntOptions) string {
+	statePath := localConfig.Path
+	if statePath == "" {
+		statePath = DefaultPathToLocalStateFile
+	}
+
+	return resolveLocalStatePath(statePath, terragruntOptions.WorkingDir)
+}
+
+// Parse the given map into a local backend config
+func parseLocalConfig(config map[string]any) (*RemoteStateConfigLocal, error) {
+	var localConfig RemoteStateConfigLocal
+	if err := mapstructure.Decode(config, &localConfig); err != nil {
+		return nil, errors.New(err)
+	}
+
+	return &localConfig, nil
+}
+
+func resolveLocalStatePath(path, baseDir string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(baseDir, path)
+}
@@ -0,0 +1,110 @@
+package remote_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalInitializerReadState(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(t.TempDir(), "terragrunt.hcl")
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest(configPath)
+	require.NoError(t, err)
+
+	// Relative paths are resolved against the Terraform working dir, e.g. the dir the source was downloaded to.
+	terragruntOptions.WorkingDir = tmpDir
+
+	remoteState := &remote.RemoteState{
+		Backend: "local",
+		Config:  map[string]any{"path": "state/terraform.tfstate"},
+	}
+
+	state, err := remoteState.ReadState(context.Background(), terragruntOptions)
+	require.NoError(t, err)
+	assert.Empty(t, state)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "state"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "state", "terraform.tfstate"), []byte(`{"serial": 1}`), 0644))
+
+	state, err = remoteState.ReadState(context.Background(), terragruntOptions)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"serial": 1}`, string(state))
+}
+
+func TestLocalInitializerInitialize(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest(filepath.Join(tmpDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	terragruntOptions.WorkingDir = tmpDir
+
+	remoteState := &remote.RemoteState{
+		Backend: "local",
+		Config:  map[string]any{"path": "nested/dir/terraform.tfstate", "workspace_dir": "workspaces"},
+	}
+	require.NoError(t, remoteState.Initialize(context.Background(), terragruntOptions))
+
+	assert.DirExists(t, filepath.Join(tmpDir, "nested", "dir"))
+	assert.DirExists(t, filepath.Join(tmpDir, "workspaces"))
+}
+
+func TestLocalInitializerNeedsInitialization(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("remote_state_test")
+	require.NoError(t, err)
+
+	remoteState := &remote.RemoteState{Backend: "local", Config: map[string]any{"path": "foo.tfstate"}}
+
+	// The parsed state is the local state file itself, which does not record a backend.
+	needsInit, err := remote.LocalInitializer{}.NeedsInitialization(remoteState, nil, terragruntOptions)
+	require.NoError(t, err)
+	assert.False(t, needsInit)
+
+	needsInit, err = remote.LocalInitializer{}.NeedsInitialization(remoteState, &remote.TerraformBackend{Type: "local", Config: map[string]any{"path": "foo.tfstate"}}, terragruntOptions)
+	require.NoError(t, err)
+	assert.False(t, needsInit)
+
+	needsInit, err = remote.LocalInitializer{}.NeedsInitialization(remoteState, &remote.TerraformBackend{Type: "local", Config: map[string]any{"path": "bar.tfstate"}}, terragruntOptions)
+	require.NoError(t, err)
+	assert.True(t, needsInit)
+}
+
+func TestLocalInitializerReadLock(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest(filepath.Join(tmpDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	remoteState := &remote.RemoteState{Backend: "local", Config: map[string]any{}}
+	require.True(t, remoteState.CanReadLock())
+
+	lock, err := remoteState.ReadLock(context.Background(), terragruntOptions)
+	require.NoError(t, err)
+	assert.Nil(t, lock)
+
+	lockInfo := `{"ID": "5f7e2ab1", "Operation": "OperationTypeApply", "Who": "jane@host", "Created": "2025-01-02T03:04:05Z"}`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".terraform.tfstate.lock.info"), []byte(lockInfo), 0644))
+
+	lock, err = remoteState.ReadLock(context.Background(), terragruntOptions)
+	require.NoError(t, err)
+	require.NotNil(t, lock)
+	assert.Equal(t, "5f7e2ab1", lock.ID)
+	assert.Equal(t, "jane@host", lock.Who)
+	assert.Equal(t, "OperationTypeApply", lock.Operation)
+}
@@ -0,0 +1,131 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+// LockInfo is the information Terraform records about the holder of a state lock.
+type LockInfo struct {
+	Created   time.Time `json:"Created"`
+	ID        string    `json:"ID"`
+	Operation string    `json:"Operation"`
+	Info      string    `json:"Info"`
+	Who       string    `json:"Who"`
+	Version   string    `json:"Version"`
+	Path      string    `json:"Path"`
+}
+
+// String returns a human-readable summary of the lock holder.
+func (info *LockInfo) String() string {
+	return fmt.Sprintf("ID %s, held by %s since %s (operation %s)", info.ID, info.Who, info.Created.Format(time.RFC3339), info.Operation)
+}
+
+// ParseLockInfo parses the JSON lock info that Terraform writes when it acquires a state lock.
+func ParseLockInfo(data []byte) (*LockInfo, error) {
+	info := &LockInfo{}
+
+	if err := json.Unmarshal(data, info); err != nil {
+		return nil, errors.New(err)
+	}
+
+	return info, nil
+}
+
+// RemoteStateLockReader is an optional interface that can be implemented by a RemoteStateInitializer to inspect the
+// state lock of a backend without acquiring it.
+type RemoteStateLockReader interface {
+	// ReadLock returns the info of the lock currently held on the state, or nil if the state is not locked.
+	ReadLock(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) (*LockInfo, error)
+}
+
+// ReadLock returns the info of the lock currently held on the state, or nil if the state is not locked. Returns a
+// LockReadNotSupportedError if the initializer registered for the backend can not inspect locks.
+func (state *RemoteState) ReadLock(ctx context.Context, terragruntOptions *options.TerragruntOptions) (*LockInfo, error) {
+	initializer, hasInitializer := GetRemoteStateInitializer(state.Backend)
+	if !hasInitializer {
+		return nil, errors.New(LockReadNotSupportedError(state.Backend))
+	}
+
+	reader, ok := initializer.(RemoteStateLockReader)
+	if !ok {
+		return nil, errors.New(LockReadNotSupportedError(state.Backend))
+	}
+
+	return reader.ReadLock(ctx, state, terragruntOptions)
+}
+
+// CanReadLock returns true if the initializer registered for the backend is able to inspect state locks.
+func (state *RemoteState) CanReadLock() bool {
+	initializer, hasInitializer := GetRemoteStateInitializer(state.Backend)
+	if !hasInitializer {
+		return false
+	}
+
+	_, ok := initializer.(RemoteStateLockReader)
+
+	return ok
+}
+
+// RemoteStateLockReleaser is an optional interface that can be implemented by a RemoteStateInitializer to forcibly
+// release a state lock, e.g. one left behind by a crashed run.
+type RemoteStateLockReleaser interface {
+	// ReleaseLock removes the given lock, previously returned by ReadLock, only if it is still the lock held on the
+	// state. Returns a LockChangedError if the state is now locked by another holder, e.g. a new run.
+	ReleaseLock(ctx context.Context, remoteState *RemoteState, lock *LockInfo, terragruntOptions *options.TerragruntOptions) error
+}
+
+// ReleaseLock forcibly removes the given lock, previously returned by ReadLock, if it is still the lock held on the
+// state. Returns a LockReleaseNotSupportedError if the initializer registered for the backend can not release locks.
+func (state *RemoteState) ReleaseLock(ctx context.Context, lock *LockInfo, terragruntOptions *options.TerragruntOptions) error {
+	initializer, hasInitializer := GetRemoteStateInitializer(state.Backend)
+	if !hasInitializer {
+		return errors.New(LockReleaseNotSupportedError(state.Backend))
+	}
+
+	releaser, ok := initializer.(RemoteStateLockReleaser)
+	if !ok {
+		return errors.New(LockReleaseNotSupportedError(state.Backend))
+	}
+
+	return releaser.ReleaseLock(ctx, state, lock, terragruntOptions)
+}
+
+// CanReleaseLock returns true if the initializer registered for the backend is able to release state locks.
+func (state *RemoteState) CanReleaseLock() bool {
+	initializer, hasInitializer := GetRemoteStateInitializer(state.Backend)
+	if !hasInitializer {
+		return false
+	}
+
+	_, ok := initializer.(RemoteStateLockReleaser)
+
+	return ok
+}
+
+type LockReadNotSupportedError string
+
+func (backend LockReadNotSupportedError) Error() string {
+	return fmt.Sprintf("Inspecting state locks is not supported for the %s backend", string(backend))
+}
+
+type LockReleaseNotSupportedError string
+
+func (backend LockReleaseNotSupportedError) Error() string {
+	return fmt.Sprintf("Releasing state locks is not supported for the %s backend", string(backend))
+}
+
+// LockChangedError is returned when a lock is not released, because the lock held on the state is no longer the one
+// that was read.
+type LockChangedError struct {
+	LockID string
+}
+
+func (err LockChangedError) Error() string {
+	return fmt.Sprintf("The state lock %s is no longer held, the state was released or locked again in the meantime", err.LockID)
+}
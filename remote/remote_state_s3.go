@@ -3,6 +3,8 @@ package remote
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"reflect"
 	"strconv"
 	"time"
@@ -128,6 +130,7 @@ type RemoteStateConfigS3 struct {
 	AssumeRole       RemoteStateConfigS3AssumeRole `mapstructure:"assume_role"`
 	Encrypt          bool                          `mapstructure:"encrypt"`
 	S3ForcePathStyle bool                          `mapstructure:"force_path_style"`
+	UseLockfile      bool                          `mapstructure:"use_lockfile"`
 }
 
 // GetAwsSessionConfig builds a session config for AWS related requests
@@ -197,6 +200,17 @@ func (s3Config *RemoteStateConfigS3) GetLockTableName() string {
 	return s3Config.LockTable
 }
 
+// GetLockfileKey returns the key of the object the backend writes next to the state to lock it when S3-native
+// locking is enabled with "use_lockfile".
+func (s3Config *RemoteStateConfigS3) GetLockfileKey() string {
+	return s3Config.Key + s3LockfileSuffix
+}
+
+// GetLockID returns the ID under which the backend stores the lock of the state in the DynamoDB lock table.
+func (s3Config *RemoteStateConfigS3) GetLockID() string {
+	return s3Config.Bucket + "/" + s3Config.Key
+}
+
 // GetSessionRoleArn returns the role defined in the AssumeRole struct
 // or fallback to the top level argument deprecated in Terraform 1.6
 func (s3Config *RemoteStateConfigS3) GetSessionRoleArn() string {
@@ -234,6 +248,14 @@ func (s3Config *RemoteStateConfigS3) GetSessionName() string {
 	return s3Config.SessionName
 }
 
+const (
+	s3LockfileSuffix = ".tflock"
+
+	// s3DigestSuffix is appended to the lock ID of a state by the backend to store the digest of the state in the
+	// DynamoDB lock table.
+	s3DigestSuffix = "-md5"
+)
+
 const MaxRetriesWaitingForS3Bucket = 12
 const SleepBetweenRetriesWaitingForS3Bucket = 5 * time.Second
 
@@ -452,6 +474,11 @@ func (s3Initializer S3Initializer) Initialize(ctx context.Context, remoteState *
 	})
 }
 
+// TerragruntOnlyConfigs implements TerragruntOnlyConfigsProvider.TerragruntOnlyConfigs
+func (s3Initializer S3Initializer) TerragruntOnlyConfigs() []string {
+	return terragruntOnlyConfigs
+}
+
 func (s3Initializer S3Initializer) GetTerraformInitArgs(config map[string]any) map[string]any {
 	var filteredConfig = make(map[string]any)
 
@@ -490,6 +517,372 @@ func (s3Initializer S3Initializer) GetTerraformInitArgs(config map[string]any) m
 	return filteredConfig
 }
 
+// ReadState downloads the Terraform state object from the S3 bucket specified in the given config.
+func (s3Initializer S3Initializer) ReadState(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) ([]byte, error) {
+	s3ConfigExtended, err := ParseExtendedS3Config(remoteState.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	s3Config := s3ConfigExtended.RemoteStateConfigS3
+
+	terragruntOptions.Logger.Debugf("Fetching state directly from s3://%s/%s", s3Config.Bucket, s3Config.Key)
+
+	s3Client, err := CreateS3Client(s3ConfigExtended.GetAwsSessionConfig(), terragruntOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s3Client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s3Config.Bucket),
+		Key:    aws.String(s3Config.Key),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, nil
+		}
+
+		return nil, errors.New(err)
+	}
+
+	defer func(body io.ReadCloser) {
+		if err := body.Close(); err != nil {
+			terragruntOptions.Logger.Warnf("Failed to close remote state response %v", err)
+		}
+	}(result.Body)
+
+	stateBody, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return stateBody, nil
+}
+
+// ReadStateVersion returns the version ID of the Terraform state object in the S3 bucket, or its ETag if the bucket is
+// not versioned, with a HEAD request.
+func (s3Initializer S3Initializer) ReadStateVersion(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) (string, error) {
+	s3ConfigExtended, err := ParseExtendedS3Config(remoteState.Config)
+	if err != nil {
+		return "", err
+	}
+
+	s3Config := s3ConfigExtended.RemoteStateConfigS3
+
+	s3Client, err := CreateS3Client(s3ConfigExtended.GetAwsSessionConfig(), terragruntOptions)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := s3Client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s3Config.Bucket),
+		Key:    aws.String(s3Config.Key),
+	})
+	if err != nil {
+		var awsErr awserr.RequestFailure
+		if errors.As(err, &awsErr) && awsErr.StatusCode() == http.StatusNotFound {
+			return "", nil
+		}
+
+		return "", errors.New(err)
+	}
+
+	if versionID := aws.StringValue(result.VersionId); versionID != "" && versionID != "null" {
+		return versionID, nil
+	}
+
+	return aws.StringValue(result.ETag), nil
+}
+
+// ReadLock returns the info of the lock held on the state. The lock is looked up in the DynamoDB lock table if one is
+// configured, and in the S3 lockfile next to the state if S3-native locking is enabled with "use_lockfile". Both can
+// be enabled at the same time, in which case OpenTofu/Terraform acquires both.
+func (s3Initializer S3Initializer) ReadLock(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) (*LockInfo, error) {
+	s3ConfigExtended, err := ParseExtendedS3Config(remoteState.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	s3Config := s3ConfigExtended.RemoteStateConfigS3
+
+	if tableName := s3Config.GetLockTableName(); tableName != "" {
+		dynamodbClient, err := dynamodb.CreateDynamoDBClient(s3ConfigExtended.GetAwsSessionConfig(), terragruntOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		lockInfo, err := dynamodb.GetLockInfo(ctx, tableName, s3Config.GetLockID(), dynamodbClient)
+		if err != nil {
+			return nil, err
+		}
+
+		if lockInfo != "" {
+			return ParseLockInfo([]byte(lockInfo))
+		}
+	}
+
+	if !s3Config.UseLockfile {
+		return nil, nil
+	}
+
+	s3Client, err := CreateS3Client(s3ConfigExtended.GetAwsSessionConfig(), terragruntOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s3Client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s3Config.Bucket),
+		Key:    aws.String(s3Config.GetLockfileKey()),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, nil
+		}
+
+		return nil, errors.New(err)
+	}
+
+	defer result.Body.Close() //nolint:errcheck
+
+	lockBody, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return ParseLockInfo(lockBody)
+}
+
+// ReleaseLock removes the lock of the state from the DynamoDB lock table and deletes the S3 lockfile, depending on
+// which locking mechanisms are configured. Both deletes are conditional on the given lock still being the one held:
+// the DynamoDB item must hold the lock ID and the lockfile must have the ETag of the lockfile with that ID.
+func (s3Initializer S3Initializer) ReleaseLock(ctx context.Context, remoteState *RemoteState, lock *LockInfo, terragruntOptions *options.TerragruntOptions) error {
+	s3ConfigExtended, err := ParseExtendedS3Config(remoteState.Config)
+	if err != nil {
+		return err
+	}
+
+	s3Config := s3ConfigExtended.RemoteStateConfigS3
+
+	if tableName := s3Config.GetLockTableName(); tableName != "" {
+		dynamodbClient, err := dynamodb.CreateDynamoDBClient(s3ConfigExtended.GetAwsSessionConfig(), terragruntOptions)
+		if err != nil {
+			return err
+		}
+
+		terragruntOptions.Logger.Debugf("Deleting lock %s from DynamoDB table %s", s3Config.GetLockID(), tableName)
+
+		deleted, err := dynamodb.DeleteLockIfHeld(ctx, tableName, s3Config.GetLockID(), lock.ID, dynamodbClient)
+		if err != nil {
+			return err
+		}
+
+		if !deleted {
+			return errors.New(LockChangedError{LockID: lock.ID})
+		}
+	}
+
+	if !s3Config.UseLockfile {
+		return nil
+	}
+
+	s3Client, err := CreateS3Client(s3ConfigExtended.GetAwsSessionConfig(), terragruntOptions)
+	if err != nil {
+		return err
+	}
+
+	result, err := s3Client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s3Config.Bucket),
+		Key:    aws.String(s3Config.GetLockfileKey()),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			// The lock was held in the DynamoDB table only.
+			if s3Config.GetLockTableName() != "" {
+				return nil
+			}
+
+			return errors.New(LockChangedError{LockID: lock.ID})
+		}
+
+		return errors.New(err)
+	}
+
+	defer result.Body.Close() //nolint:errcheck
+
+	lockBody, err := io.ReadAll(result.Body)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if current, err := ParseLockInfo(lockBody); err != nil || current.ID != lock.ID {
+		return errors.New(LockChangedError{LockID: lock.ID})
+	}
+
+	terragruntOptions.Logger.Debugf("Deleting lockfile s3://%s/%s", s3Config.Bucket, s3Config.GetLockfileKey())
+
+	// The delete only succeeds if the lockfile was not replaced since it was read.
+	req, _ := s3Client.DeleteObjectRequest(&s3.DeleteObjectInput{
+		Bucket: aws.String(s3Config.Bucket),
+		Key:    aws.String(s3Config.GetLockfileKey()),
+	})
+	req.SetContext(ctx)
+	req.HTTPRequest.Header.Set("If-Match", aws.StringValue(result.ETag))
+
+	if err := req.Send(); err != nil {
+		var awsErr awserr.RequestFailure
+		if errors.As(err, &awsErr) && awsErr.StatusCode() == http.StatusPreconditionFailed {
+			return errors.New(LockChangedError{LockID: lock.ID})
+		}
+
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// GetStatus reports whether the S3 bucket, the access logging bucket and the DynamoDB lock table of the given config
+// exist, and which settings of the S3 bucket differ from the config.
+func (s3Initializer S3Initializer) GetStatus(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) (*BackendStatus, error) {
+	s3ConfigExtended, err := ParseExtendedS3Config(remoteState.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateS3Config(s3ConfigExtended); err != nil {
+		return nil, err
+	}
+
+	s3Config := s3ConfigExtended.RemoteStateConfigS3
+	sessionConfig := s3ConfigExtended.GetAwsSessionConfig()
+
+	s3Client, err := CreateS3Client(sessionConfig, terragruntOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket := &BackendResource{
+		Type:   BackendResourceS3Bucket,
+		Name:   s3Config.Bucket,
+		Exists: DoesS3BucketExist(s3Client, &s3Config.Bucket),
+	}
+
+	status := &BackendStatus{
+		StatePath: fmt.Sprintf("s3://%s/%s", s3Config.Bucket, s3Config.Key),
+		Resources: []*BackendResource{bucket},
+	}
+
+	if bucket.Exists {
+		// Initialize leaves the settings of existing buckets alone when bucket updates are disabled.
+		if !terragruntOptions.DisableBucketUpdate && !s3ConfigExtended.DisableBucketUpdate {
+			if bucket.Updates, _, err = getS3BucketUpdates(s3Client, s3ConfigExtended, terragruntOptions); err != nil {
+				return nil, err
+			}
+		}
+
+		if status.Versioning, err = checkIfVersioningEnabled(s3Client, &s3Config, terragruntOptions); err != nil {
+			return nil, err
+		}
+
+		_, err := s3Client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+			Bucket: aws.String(s3Config.Bucket),
+			Key:    aws.String(s3Config.Key),
+		})
+		if err == nil {
+			status.StateExists = true
+		} else if !isS3NotFoundError(err) {
+			return nil, errors.New(err)
+		}
+	}
+
+	if s3ConfigExtended.AccessLoggingBucketName != "" {
+		status.Resources = append(status.Resources, &BackendResource{
+			Type:   BackendResourceS3Bucket,
+			Name:   s3ConfigExtended.AccessLoggingBucketName,
+			Exists: DoesS3BucketExist(s3Client, &s3ConfigExtended.AccessLoggingBucketName),
+		})
+	}
+
+	if tableName := s3Config.GetLockTableName(); tableName != "" {
+		dynamodbClient, err := dynamodb.CreateDynamoDBClient(sessionConfig, terragruntOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		table := &BackendResource{Type: BackendResourceDynamoDBTable, Name: tableName}
+
+		if table.Exists, err = dynamodb.LockTableExistsAndIsActive(tableName, dynamodbClient); err != nil {
+			return nil, err
+		}
+
+		if table.Exists && s3ConfigExtended.EnableLockTableSSEncryption {
+			encrypted, err := dynamodb.LockTableCheckSSEncryptionIsOn(tableName, dynamodbClient)
+			if err != nil {
+				return nil, err
+			}
+
+			if !encrypted {
+				table.Updates = append(table.Updates, "Table Server-Side Encryption")
+			}
+		}
+
+		status.Resources = append(status.Resources, table)
+	}
+
+	return status, nil
+}
+
+// DeleteState deletes the state object from the S3 bucket. If a DynamoDB lock table is configured, the digest of the
+// state stored in it is deleted as well, so that OpenTofu/Terraform does not expect the deleted state to come back.
+func (s3Initializer S3Initializer) DeleteState(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) error {
+	s3ConfigExtended, err := ParseExtendedS3Config(remoteState.Config)
+	if err != nil {
+		return err
+	}
+
+	s3Config := s3ConfigExtended.RemoteStateConfigS3
+
+	s3Client, err := CreateS3Client(s3ConfigExtended.GetAwsSessionConfig(), terragruntOptions)
+	if err != nil {
+		return err
+	}
+
+	terragruntOptions.Logger.Debugf("Deleting state s3://%s/%s", s3Config.Bucket, s3Config.Key)
+
+	if _, err := s3Client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s3Config.Bucket),
+		Key:    aws.String(s3Config.Key),
+	}); err != nil {
+		return errors.New(err)
+	}
+
+	if tableName := s3Config.GetLockTableName(); tableName != "" {
+		dynamodbClient, err := dynamodb.CreateDynamoDBClient(s3ConfigExtended.GetAwsSessionConfig(), terragruntOptions)
+		if err != nil {
+			return err
+		}
+
+		if err := dynamodb.DeleteLock(ctx, tableName, s3Config.GetLockID()+s3DigestSuffix, dynamodbClient); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isS3NotFoundError returns true if the given error is returned by S3 for a missing object. HEAD requests have no
+// body, so the error code is derived from the status code.
+func isS3NotFoundError(err error) bool {
+	var awsErr awserr.RequestFailure
+	if errors.As(err, &awsErr) {
+		return awsErr.StatusCode() == http.StatusNotFound
+	}
+
+	return false
+}
+
 // ParseExtendedS3Config parses the given map into an extended S3 config.
 func ParseExtendedS3Config(config map[string]any) (*ExtendedRemoteStateConfigS3, error) {
 	var (
@@ -733,6 +1126,27 @@ type S3BucketUpdatesRequired struct {
 }
 
 func checkIfS3BucketNeedsUpdate(s3Client *s3.S3, config *ExtendedRemoteStateConfigS3, terragruntOptions *options.TerragruntOptions) (bool, S3BucketUpdatesRequired, error) {
+	updates, toUpdate, err := getS3BucketUpdates(s3Client, config, terragruntOptions)
+	if err != nil {
+		return false, toUpdate, err
+	}
+
+	// show update message if any of the above configs are not set
+	if len(updates) > 0 {
+		terragruntOptions.Logger.Warnf("The remote state S3 bucket %s needs to be updated:", config.RemoteStateConfigS3.Bucket)
+
+		for _, update := range updates {
+			terragruntOptions.Logger.Warnf("  - %s", update)
+		}
+
+		return true, toUpdate, nil
+	}
+
+	return false, toUpdate, nil
+}
+
+// getS3BucketUpdates returns the descriptions of the settings of the S3 bucket that differ from the given config.
+func getS3BucketUpdates(s3Client *s3.S3, config *ExtendedRemoteStateConfigS3, terragruntOptions *options.TerragruntOptions) ([]string, S3BucketUpdatesRequired, error) {
 	var (
 		updates  []string
 		toUpdate S3BucketUpdatesRequired
@@ -741,7 +1155,7 @@ func checkIfS3BucketNeedsUpdate(s3Client *s3.S3, config *ExtendedRemoteStateConf
 	if !config.SkipBucketVersioning {
 		enabled, err := checkIfVersioningEnabled(s3Client, &config.RemoteStateConfigS3, terragruntOptions)
 		if err != nil {
-			return false, toUpdate, err
+			return nil, toUpdate, err
 		}
 
 		if !enabled {
@@ -754,7 +1168,7 @@ func checkIfS3BucketNeedsUpdate(s3Client *s3.S3, config *ExtendedRemoteStateConf
 	if !config.SkipBucketSSEncryption {
 		matches, err := checkIfSSEForS3MatchesConfig(s3Client, config, terragruntOptions)
 		if err != nil {
-			return false, toUpdate, err
+			return nil, toUpdate, err
 		}
 
 		if !matches {
@@ -767,7 +1181,7 @@ func checkIfS3BucketNeedsUpdate(s3Client *s3.S3, config *ExtendedRemoteStateConf
 	if !config.SkipBucketRootAccess {
 		enabled, err := checkIfBucketRootAccess(s3Client, &config.RemoteStateConfigS3, terragruntOptions)
 		if err != nil {
-			return false, toUpdate, err
+			return nil, toUpdate, err
 		}
 
 		if !enabled {
@@ -780,7 +1194,7 @@ func checkIfS3BucketNeedsUpdate(s3Client *s3.S3, config *ExtendedRemoteStateConf
 	if !config.SkipBucketEnforcedTLS {
 		enabled, err := checkIfBucketEnforcedTLS(s3Client, &config.RemoteStateConfigS3, terragruntOptions)
 		if err != nil {
-			return false, toUpdate, err
+			return nil, toUpdate, err
 		}
 
 		if !enabled {
@@ -793,7 +1207,7 @@ func checkIfS3BucketNeedsUpdate(s3Client *s3.S3, config *ExtendedRemoteStateConf
 	if !config.SkipBucketAccessLogging && config.AccessLoggingBucketName != "" {
 		enabled, err := checkS3AccessLoggingConfiguration(s3Client, config, terragruntOptions)
 		if err != nil {
-			return false, toUpdate, err
+			return nil, toUpdate, err
 		}
 
 		if !enabled {
@@ -806,7 +1220,7 @@ func checkIfS3BucketNeedsUpdate(s3Client *s3.S3, config *ExtendedRemoteStateConf
 	if !config.SkipBucketPublicAccessBlocking {
 		enabled, err := checkIfS3PublicAccessBlockingEnabled(s3Client, &config.RemoteStateConfigS3, terragruntOptions)
 		if err != nil {
-			return false, toUpdate, err
+			return nil, toUpdate, err
 		}
 
 		if !enabled {
@@ -816,18 +1230,7 @@ func checkIfS3BucketNeedsUpdate(s3Client *s3.S3, config *ExtendedRemoteStateConf
 		}
 	}
 
-	// show update message if any of the above configs are not set
-	if len(updates) > 0 {
-		terragruntOptions.Logger.Warnf("The remote state S3 bucket %s needs to be updated:", config.RemoteStateConfigS3.Bucket)
-
-		for _, update := range updates {
-			terragruntOptions.Logger.Warnf("  - %s", update)
-		}
-
-		return true, toUpdate, nil
-	}
-
-	return false, toUpdate, nil
+	return updates, toUpdate, nil
 }
 
 // Check if versioning is enabled for the S3 bucket specified in the given config and warn the user if it is not
@@ -1634,6 +2037,10 @@ func checkBucketAccess(s3Client *s3.S3, bucket *string, key *string) error {
 // Create a table for locks in DynamoDB if the user has configured a lock table and the table doesn't already exist
 func createLockTableIfNecessary(extendedS3Config *ExtendedRemoteStateConfigS3, tags map[string]string, terragruntOptions *options.TerragruntOptions) error {
 	if extendedS3Config.RemoteStateConfigS3.GetLockTableName() == "" {
+		if extendedS3Config.RemoteStateConfigS3.UseLockfile {
+			terragruntOptions.Logger.Debugf("S3-native locking is enabled for the remote state S3 bucket %s, no DynamoDB lock table is needed", extendedS3Config.RemoteStateConfigS3.Bucket)
+		}
+
 		return nil
 	}
 
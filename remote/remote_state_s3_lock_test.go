@@ -0,0 +1,321 @@
+package remote_test
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLockInfo = `{"ID":"3f1b2c4d-0000-1111-2222-333344445555","Operation":"OperationTypeApply","Info":"","Who":"jane@laptop","Version":"1.9.0","Created":"2025-01-02T03:04:05Z","Path":"my-bucket/env/prod/terraform.tfstate"}`
+
+const testOtherLockInfo = `{"ID":"9a8b7c6d-0000-1111-2222-333344445555","Operation":"OperationTypePlan","Info":"","Who":"john@ci","Version":"1.9.0","Created":"2025-01-02T04:05:06Z","Path":"my-bucket/env/prod/terraform.tfstate"}`
+
+// objectETag returns the ETag of an S3 object with the given body.
+func objectETag(body string) string {
+	hash := md5.Sum([]byte(body)) //nolint:gosec
+
+	return `"` + hex.EncodeToString(hash[:]) + `"`
+}
+
+// s3LockStandIn is a minimal local stand-in for the S3 and DynamoDB APIs, holding S3 objects and DynamoDB lock items
+// in memory. Conditional deletes of objects (If-Match) and items (a condition on the lock info) are honored.
+type s3LockStandIn struct {
+	objects map[string]string
+	items   map[string]string
+	mu      sync.Mutex
+}
+
+func (standIn *s3LockStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	standIn.mu.Lock()
+	defer standIn.mu.Unlock()
+
+	if target := r.Header.Get("X-Amz-Target"); target != "" {
+		standIn.serveDynamoDB(w, r, strings.TrimPrefix(target, "DynamoDB_20120810."))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodHead:
+		body, ok := standIn.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("ETag", objectETag(body))
+	case http.MethodGet:
+		body, ok := standIn.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+
+			return
+		}
+
+		w.Header().Set("ETag", objectETag(body))
+		_, _ = w.Write([]byte(body))
+	case http.MethodDelete:
+		if etag := r.Header.Get("If-Match"); etag != "" && etag != objectETag(standIn.objects[r.URL.Path]) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>PreconditionFailed</Code><Message>At least one of the pre-conditions you specified did not hold</Message></Error>`))
+
+			return
+		}
+
+		delete(standIn.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (standIn *s3LockStandIn) serveDynamoDB(w http.ResponseWriter, r *http.Request, operation string) {
+	type attributeValue struct {
+		S string `json:"S"`
+	}
+
+	var input struct {
+		Key                       map[string]attributeValue `json:"Key"`
+		ExpressionAttributeValues map[string]attributeValue `json:"ExpressionAttributeValues"`
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	_ = json.Unmarshal(body, &input)
+
+	lockID := input.Key["LockID"].S
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+
+	switch operation {
+	case "GetItem":
+		info, ok := standIn.items[lockID]
+		if !ok {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+
+		output, _ := json.Marshal(map[string]any{
+			"Item": map[string]any{
+				"LockID": map[string]string{"S": lockID},
+				"Info":   map[string]string{"S": info},
+			},
+		})
+		_, _ = w.Write(output)
+	case "DeleteItem":
+		if holder, ok := input.ExpressionAttributeValues[":holder"]; ok {
+			if info, exists := standIn.items[lockID]; exists && !strings.Contains(info, holder.S) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"__type":"com.amazonaws.dynamodb.v20120810#ConditionalCheckFailedException","message":"The conditional request failed"}`))
+
+				return
+			}
+		}
+
+		delete(standIn.items, lockID)
+		_, _ = w.Write([]byte(`{}`))
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func newS3LockTestOptions(t *testing.T) *options.TerragruntOptions {
+	t.Helper()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("remote_state_test")
+	require.NoError(t, err)
+
+	terragruntOptions.Env = map[string]string{
+		"AWS_ACCESS_KEY_ID":     "test",
+		"AWS_SECRET_ACCESS_KEY": "test",
+	}
+
+	return terragruntOptions
+}
+
+func TestS3InitializerLockfile(t *testing.T) {
+	t.Parallel()
+
+	standIn := &s3LockStandIn{
+		objects: map[string]string{"/my-bucket/env/prod/terraform.tfstate.tflock": testLockInfo},
+		items:   map[string]string{},
+	}
+
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	remoteState := &remote.RemoteState{
+		Backend: "s3",
+		Config: map[string]any{
+			"bucket":           "my-bucket",
+			"key":              "env/prod/terraform.tfstate",
+			"region":           "us-east-1",
+			"endpoint":         server.URL,
+			"force_path_style": true,
+			"use_lockfile":     true,
+		},
+	}
+
+	terragruntOptions := newS3LockTestOptions(t)
+
+	lock, err := remoteState.ReadLock(context.Background(), terragruntOptions)
+	require.NoError(t, err)
+	require.NotNil(t, lock)
+	assert.Equal(t, "3f1b2c4d-0000-1111-2222-333344445555", lock.ID)
+	assert.Equal(t, "jane@laptop", lock.Who)
+
+	require.NoError(t, remoteState.ReleaseLock(context.Background(), lock, terragruntOptions))
+	assert.Empty(t, standIn.objects)
+
+	lock, err = remoteState.ReadLock(context.Background(), terragruntOptions)
+	require.NoError(t, err)
+	assert.Nil(t, lock)
+}
+
+func TestS3InitializerDynamoDBLock(t *testing.T) {
+	t.Parallel()
+
+	standIn := &s3LockStandIn{
+		objects: map[string]string{},
+		items:   map[string]string{"my-bucket/env/prod/terraform.tfstate": testLockInfo},
+	}
+
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	remoteState := &remote.RemoteState{
+		Backend: "s3",
+		Config: map[string]any{
+			"bucket":            "my-bucket",
+			"key":               "env/prod/terraform.tfstate",
+			"region":            "us-east-1",
+			"dynamodb_table":    "my-lock-table",
+			"dynamodb_endpoint": server.URL,
+		},
+	}
+
+	terragruntOptions := newS3LockTestOptions(t)
+
+	lock, err := remoteState.ReadLock(context.Background(), terragruntOptions)
+	require.NoError(t, err)
+	require.NotNil(t, lock)
+	assert.Equal(t, "OperationTypeApply", lock.Operation)
+
+	require.NoError(t, remoteState.ReleaseLock(context.Background(), lock, terragruntOptions))
+	assert.Empty(t, standIn.items)
+
+	lock, err = remoteState.ReadLock(context.Background(), terragruntOptions)
+	require.NoError(t, err)
+	assert.Nil(t, lock)
+}
+
+func TestS3InitializerReleaseChangedLock(t *testing.T) {
+	t.Parallel()
+
+	lock, err := remote.ParseLockInfo([]byte(testLockInfo))
+	require.NoError(t, err)
+
+	tc := []struct {
+		standIn *s3LockStandIn
+		config  map[string]any
+		name    string
+	}{
+		{
+			name: "lockfile",
+			standIn: &s3LockStandIn{
+				objects: map[string]string{"/my-bucket/env/prod/terraform.tfstate.tflock": testOtherLockInfo},
+				items:   map[string]string{},
+			},
+			config: map[string]any{"force_path_style": true, "use_lockfile": true},
+		},
+		{
+			name: "dynamodb",
+			standIn: &s3LockStandIn{
+				objects: map[string]string{},
+				items:   map[string]string{"my-bucket/env/prod/terraform.tfstate": testOtherLockInfo},
+			},
+			config: map[string]any{"dynamodb_table": "my-lock-table"},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(tt.standIn)
+			defer server.Close()
+
+			config := map[string]any{
+				"bucket":            "my-bucket",
+				"key":               "env/prod/terraform.tfstate",
+				"region":            "us-east-1",
+				"endpoint":          server.URL,
+				"dynamodb_endpoint": server.URL,
+			}
+
+			for key, value := range tt.config {
+				config[key] = value
+			}
+
+			remoteState := &remote.RemoteState{Backend: "s3", Config: config}
+			objects, items := maps.Clone(tt.standIn.objects), maps.Clone(tt.standIn.items)
+
+			// The lock was released and acquired again by another holder after it was read.
+			err := remoteState.ReleaseLock(context.Background(), lock, newS3LockTestOptions(t))
+			require.ErrorAs(t, err, &remote.LockChangedError{})
+
+			// The lock of the other holder is kept.
+			assert.Equal(t, objects, tt.standIn.objects)
+			assert.Equal(t, items, tt.standIn.items)
+		})
+	}
+}
+
+func TestS3InitializerReadStateVersion(t *testing.T) {
+	t.Parallel()
+
+	standIn := &s3LockStandIn{
+		objects: map[string]string{},
+		items:   map[string]string{},
+	}
+
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	remoteState := &remote.RemoteState{
+		Backend: "s3",
+		Config: map[string]any{
+			"bucket":           "my-bucket",
+			"key":              "env/prod/terraform.tfstate",
+			"region":           "us-east-1",
+			"endpoint":         server.URL,
+			"force_path_style": true,
+		},
+	}
+
+	terragruntOptions := newS3LockTestOptions(t)
+
+	require.True(t, remoteState.CanReadStateVersion())
+
+	version, err := remoteState.ReadStateVersion(context.Background(), terragruntOptions)
+	require.NoError(t, err)
+	assert.Empty(t, version)
+
+	standIn.objects["/my-bucket/env/prod/terraform.tfstate"] = `{"serial": 1}`
+
+	version, err = remoteState.ReadStateVersion(context.Background(), terragruntOptions)
+	require.NoError(t, err)
+	assert.Equal(t, objectETag(`{"serial": 1}`), version)
+}
@@ -0,0 +1,129 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+const (
+	BackendResourceS3Bucket      = "s3_bucket"
+	BackendResourceDynamoDBTable = "dynamodb_table"
+	BackendResourceGCSBucket     = "gcs_bucket"
+	BackendResourceDir           = "dir"
+)
+
+// BackendStatus describes the resources backing the remote state of a unit, such as buckets and lock tables, and
+// whether they are in the state Terragrunt would bring them to when initializing the remote state.
+type BackendStatus struct {
+	// StatePath is the location of the state, e.g. `s3://bucket/key`.
+	StatePath string             `json:"state_path"`
+	Resources []*BackendResource `json:"resources"`
+
+	// StateExists is true if the state of the unit is stored in the backend.
+	StateExists bool `json:"state_exists"`
+
+	// Versioning is true if previous versions of the state are kept by the backend, so that deleting the state can
+	// be undone.
+	Versioning bool `json:"versioning"`
+}
+
+// BackendResource is a resource backing the remote state.
+type BackendResource struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+
+	// Updates are the settings of the resource that differ from the remote_state configuration.
+	Updates []string `json:"updates,omitempty"`
+
+	Exists bool `json:"exists"`
+}
+
+// NeedsBootstrap returns true if any of the resources is missing or needs to be updated.
+func (status *BackendStatus) NeedsBootstrap() bool {
+	for _, resource := range status.Resources {
+		if !resource.Exists || len(resource.Updates) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Actions returns a human-readable description of everything initializing the remote state would create or update.
+func (status *BackendStatus) Actions() []string {
+	var actions []string
+
+	for _, resource := range status.Resources {
+		if !resource.Exists {
+			actions = append(actions, fmt.Sprintf("create %s %s", resource.Type, resource.Name))
+			continue
+		}
+
+		for _, update := range resource.Updates {
+			actions = append(actions, fmt.Sprintf("update %s %s: %s", resource.Type, resource.Name, update))
+		}
+	}
+
+	return actions
+}
+
+// RemoteStateStatusReader is an optional interface that can be implemented by a RemoteStateInitializer to report the
+// status of the resources backing the remote state, without changing them.
+type RemoteStateStatusReader interface {
+	// GetStatus returns the status of the resources backing the remote state.
+	GetStatus(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) (*BackendStatus, error)
+}
+
+// RemoteStateDeleter is an optional interface that can be implemented by a RemoteStateInitializer to delete the
+// state of a unit from the backend.
+type RemoteStateDeleter interface {
+	// DeleteState deletes the state from the backend. The resources backing the state, such as buckets, are kept.
+	DeleteState(ctx context.Context, remoteState *RemoteState, terragruntOptions *options.TerragruntOptions) error
+}
+
+// GetStatus returns the status of the resources backing the remote state. Returns a StatusNotSupportedError if the
+// initializer registered for the backend can not report it.
+func (state *RemoteState) GetStatus(ctx context.Context, terragruntOptions *options.TerragruntOptions) (*BackendStatus, error) {
+	initializer, hasInitializer := GetRemoteStateInitializer(state.Backend)
+	if !hasInitializer {
+		return nil, errors.New(StatusNotSupportedError(state.Backend))
+	}
+
+	reader, ok := initializer.(RemoteStateStatusReader)
+	if !ok {
+		return nil, errors.New(StatusNotSupportedError(state.Backend))
+	}
+
+	return reader.GetStatus(ctx, state, terragruntOptions)
+}
+
+// DeleteState deletes the state from the backend. Returns a StateDeleteNotSupportedError if the initializer
+// registered for the backend can not delete state.
+func (state *RemoteState) DeleteState(ctx context.Context, terragruntOptions *options.TerragruntOptions) error {
+	initializer, hasInitializer := GetRemoteStateInitializer(state.Backend)
+	if !hasInitializer {
+		return errors.New(StateDeleteNotSupportedError(state.Backend))
+	}
+
+	deleter, ok := initializer.(RemoteStateDeleter)
+	if !ok {
+		return errors.New(StateDeleteNotSupportedError(state.Backend))
+	}
+
+	return deleter.DeleteState(ctx, state, terragruntOptions)
+}
+
+type StatusNotSupportedError string
+
+func (backend StatusNotSupportedError) Error() string {
+	return fmt.Sprintf("Reporting the status of the %s backend is not supported", string(backend))
+}
+
+type StateDeleteNotSupportedError string
+
+func (backend StateDeleteNotSupportedError) Error() string {
+	return fmt.Sprintf("Deleting state is not supported for the %s backend", string(backend))
+}
@@ -296,6 +296,115 @@ func TestDiffersFrom(t *testing.T) {
 	}
 }
 
+func TestBackendDiffersFrom(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("remote_state_test")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		existingBackend remote.TerraformBackend
+		name            string
+		stateFromConfig remote.RemoteState
+		differs         bool
+	}{
+		{
+			name: "s3 terragrunt only keys ignored",
+			existingBackend: remote.TerraformBackend{
+				Type:   "s3",
+				Config: map[string]any{"bucket": "foo", "key": "bar", "encrypt": "true", "use_lockfile": nil},
+			},
+			stateFromConfig: remote.RemoteState{
+				Backend: "s3",
+				Config:  map[string]any{"bucket": "foo", "key": "bar", "encrypt": true, "s3_bucket_tags": map[string]any{"team": "infra"}, "skip_bucket_versioning": true},
+			},
+		},
+		{
+			name: "gcs terragrunt only keys ignored",
+			existingBackend: remote.TerraformBackend{
+				Type:   "gcs",
+				Config: map[string]any{"bucket": "foo", "prefix": "bar"},
+			},
+			stateFromConfig: remote.RemoteState{
+				Backend: "gcs",
+				Config:  map[string]any{"bucket": "foo", "prefix": "bar", "project": "foo-123456", "location": "europe-west3"},
+			},
+		},
+		{
+			name: "different s3 bucket",
+			existingBackend: remote.TerraformBackend{
+				Type:   "s3",
+				Config: map[string]any{"bucket": "foo", "key": "bar"},
+			},
+			stateFromConfig: remote.RemoteState{
+				Backend: "s3",
+				Config:  map[string]any{"bucket": "different", "key": "bar", "s3_bucket_tags": map[string]any{"team": "infra"}},
+			},
+			differs: true,
+		},
+		{
+			name: "different backend type",
+			existingBackend: remote.TerraformBackend{
+				Type:   "local",
+				Config: map[string]any{"path": "terraform.tfstate"},
+			},
+			stateFromConfig: remote.RemoteState{
+				Backend: "s3",
+				Config:  map[string]any{"bucket": "foo", "key": "bar"},
+			},
+			differs: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.differs, testCase.stateFromConfig.BackendDiffersFrom(&testCase.existingBackend, terragruntOptions))
+		})
+	}
+}
+
+type testRemoteStateInitializer struct {
+	remote.LocalInitializer
+}
+
+func (initializer testRemoteStateInitializer) GetTerraformInitArgs(config map[string]any) map[string]any {
+	filteredConfig := map[string]any{}
+
+	for key, val := range config {
+		if key != "terragrunt_only" {
+			filteredConfig[key] = val
+		}
+	}
+
+	return filteredConfig
+}
+
+func TestRegisterRemoteStateInitializer(t *testing.T) {
+	t.Parallel()
+
+	require.ErrorIs(t, remote.RegisterRemoteStateInitializer("", testRemoteStateInitializer{}), remote.ErrRemoteBackendMissing)
+	require.Error(t, remote.RegisterRemoteStateInitializer("test-registered", nil))
+
+	require.NoError(t, remote.RegisterRemoteStateInitializer("test-registered", testRemoteStateInitializer{}))
+	assert.Contains(t, remote.RegisteredRemoteStateBackends(), "test-registered")
+
+	initializer, ok := remote.GetRemoteStateInitializer("test-registered")
+	require.True(t, ok)
+	assert.IsType(t, testRemoteStateInitializer{}, initializer)
+
+	remoteState := remote.RemoteState{
+		Backend: "test-registered",
+		Config:  map[string]any{"foo": "bar", "terragrunt_only": true},
+	}
+	assertTerraformInitArgsEqual(t, remoteState.ToTerraformInitArgs(), "-backend-config=foo=bar")
+	assert.True(t, remoteState.CanReadState())
+
+	unknownState := remote.RemoteState{Backend: "s4"}
+	assert.False(t, unknownState.CanReadState())
+}
+
 func assertTerraformInitArgsEqual(t *testing.T, actualArgs []string, expectedArgs string) {
 	t.Helper()
 
@@ -23,6 +23,7 @@ const DefaultPathToRemoteStateFile = "terraform.tfstate"
 // TerraformState - represents the structure of the Terraform .tfstate file.
 type TerraformState struct {
 	Backend *TerraformBackend      `json:"Backend"`
+	Lineage string                 `json:"Lineage"`
 	Modules []TerraformStateModule `json:"Modules"`
 	Version int                    `json:"Version"`
 	Serial  int                    `json:"Serial"`
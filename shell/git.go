@@ -3,7 +3,9 @@ package shell
 import (
 	"bytes"
 	"context"
+	"io"
 	"net/url"
+	"path/filepath"
 	"strings"
 
 	"github.com/gruntwork-io/terragrunt/internal/cache"
@@ -53,6 +55,51 @@ func GitTopLevelDir(ctx context.Context, terragruntOptions *options.TerragruntOp
 	return cmdOutput, nil
 }
 
+// GitChangedFiles returns the absolute paths of the files of the git repository of the passed directory that changed
+// since the given git ref, including uncommitted and untracked files.
+func GitChangedFiles(ctx context.Context, terragruntOptions *options.TerragruntOptions, path, ref string) ([]string, error) {
+	opts, err := options.NewTerragruntOptionsWithConfigPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Logger = terragruntOptions.Logger.Clone()
+	opts.Env = terragruntOptions.Env
+	opts.Writer = io.Discard
+	opts.ErrWriter = io.Discard
+
+	// Resolve the top level dir relatively to the passed directory, rather than with `--show-toplevel`, so that the
+	// paths match the paths of the units even if the directory is reached through a symlink.
+	cmd, err := RunCommandWithOutput(ctx, opts, path, true, false, "git", "rev-parse", "--show-cdup")
+	if err != nil {
+		return nil, err
+	}
+
+	topLevelDir := filepath.Join(path, strings.TrimSpace(cmd.Stdout.String()))
+
+	diff, err := RunCommandWithOutput(ctx, opts, topLevelDir, true, false, "git", "diff", "--name-only", "--no-renames", ref, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	untracked, err := RunCommandWithOutput(ctx, opts, topLevelDir, true, false, "git", "ls-files", "--others", "--exclude-standard", "--full-name")
+	if err != nil {
+		return nil, err
+	}
+
+	var changedFiles []string
+
+	for _, line := range strings.Split(diff.Stdout.String()+"\n"+untracked.Stdout.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			changedFiles = append(changedFiles, filepath.Join(topLevelDir, filepath.FromSlash(line)))
+		}
+	}
+
+	terragruntOptions.Logger.Debugf("Files changed since %s: %v", ref, changedFiles)
+
+	return changedFiles, nil
+}
+
 // GitRepoTags fetches git repository tags from passed url.
 func GitRepoTags(ctx context.Context, opts *options.TerragruntOptions, gitRepo *url.URL) ([]string, error) {
 	repoPath := gitRepo.String()
@@ -0,0 +1,12 @@
+remote_state {
+  backend = "local"
+
+  generate = {
+    path      = "backend.tf"
+    if_exists = "overwrite"
+  }
+
+  config = {
+    path = "old/terraform.tfstate"
+  }
+}
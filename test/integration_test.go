@@ -25,6 +25,7 @@ import (
 	"github.com/gruntwork-io/terragrunt/internal/view/diagnostic"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 	"github.com/gruntwork-io/terragrunt/pkg/log/format/placeholders"
+	"github.com/gruntwork-io/terragrunt/remote"
 	"github.com/gruntwork-io/terragrunt/shell"
 	"github.com/gruntwork-io/terragrunt/test/helpers"
 	"github.com/gruntwork-io/terragrunt/tf"
@@ -39,6 +40,7 @@ import (
 const (
 	testFixtureAuthProviderCmd                = "fixtures/auth-provider-cmd"
 	testFixtureAutoInit                       = "fixtures/download/init-on-source-change"
+	testFixtureBackendMigrate                 = "fixtures/backend-migrate"
 	testFixtureBrokenDependency               = "fixtures/broken-dependency"
 	testFixtureBufferModuleOutput             = "fixtures/buffer-module-output"
 	testFixtureCodegenPath                    = "fixtures/codegen"
@@ -4175,3 +4177,43 @@ func TestTF110EphemeralVars(t *testing.T) {
 	require.NoError(t, err)
 	assert.Contains(t, stdout, "Apply complete! Resources: 1 added, 0 changed, 0 destroyed")
 }
+
+func TestTerragruntBackendMigrate(t *testing.T) {
+	t.Parallel()
+
+	tmpEnvPath := helpers.CopyEnvironment(t, testFixtureBackendMigrate)
+	rootPath := util.JoinPath(tmpEnvPath, testFixtureBackendMigrate)
+
+	helpers.RunTerragrunt(t, "terragrunt apply -auto-approve --non-interactive --working-dir "+rootPath)
+
+	oldState, err := remote.ParseTerraformStateFile(filepath.Join(rootPath, "old", "terraform.tfstate"))
+	require.NoError(t, err)
+
+	cfgPath := filepath.Join(rootPath, "terragrunt.hcl")
+	cfg, err := os.ReadFile(cfgPath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(cfgPath, []byte(strings.ReplaceAll(string(cfg), "old/terraform.tfstate", "new/terraform.tfstate")), 0644))
+
+	helpers.RunTerragrunt(t, "terragrunt plan --backend-migrate --non-interactive --working-dir "+rootPath)
+
+	newState, err := remote.ParseTerraformStateFile(filepath.Join(rootPath, "new", "terraform.tfstate"))
+	require.NoError(t, err)
+	assert.Equal(t, oldState.Lineage, newState.Lineage)
+	assert.Equal(t, oldState.Serial, newState.Serial)
+
+	migrationLogPath := filepath.Join(rootPath, run.BackendMigrationLogFileName)
+
+	info, err := os.Stat(migrationLogPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	migrationLog, err := os.ReadFile(migrationLogPath)
+	require.NoError(t, err)
+
+	var entry run.BackendMigrationLogEntry
+	require.NoError(t, json.Unmarshal(bytes.TrimSpace(migrationLog), &entry))
+	assert.Equal(t, "local", entry.OldBackend)
+	assert.Equal(t, "old/terraform.tfstate", entry.OldConfig["path"])
+	assert.Equal(t, "new/terraform.tfstate", entry.NewConfig["path"])
+	assert.Equal(t, oldState.Lineage, entry.Lineage)
+}
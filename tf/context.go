@@ -13,6 +13,7 @@ import (
 const (
 	TerraformCommandContextKey ctxKey = iota
 	DetailedExitCodeContextKey
+	RetryCountContextKey
 )
 
 type ctxKey byte
@@ -51,3 +52,19 @@ func DetailedExitCodeFromContext(ctx context.Context) *DetailedExitCode {
 
 	return nil
 }
+
+// ContextWithRetryCount returns a new context containing the given RetryCount.
+func ContextWithRetryCount(ctx context.Context, retryCount *RetryCount) context.Context {
+	return context.WithValue(ctx, RetryCountContextKey, retryCount)
+}
+
+// RetryCountFromContext returns RetryCount if the give context contains it.
+func RetryCountFromContext(ctx context.Context) *RetryCount {
+	if val := ctx.Value(RetryCountContextKey); val != nil {
+		if val, ok := val.(*RetryCount); ok {
+			return val
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package tf
+
+import (
+	"sync/atomic"
+)
+
+// RetryCount counts how many times OpenTofu/Terraform commands were retried after failing with a retryable error.
+type RetryCount struct {
+	onRetry func(retries int)
+	count   atomic.Int64
+}
+
+// NewRetryCount returns a RetryCount that calls onRetry with the number of retries so far on every retry.
+func NewRetryCount(onRetry func(retries int)) *RetryCount {
+	return &RetryCount{onRetry: onRetry}
+}
+
+// Inc records a retry.
+func (retryCount *RetryCount) Inc() {
+	retries := retryCount.count.Add(1)
+
+	if retryCount.onRetry != nil {
+		retryCount.onRetry(int(retries))
+	}
+}
+
+// Get returns the number of retries.
+func (retryCount *RetryCount) Get() int {
+	return int(retryCount.count.Load())
+}
@@ -62,8 +62,9 @@ const (
 
 	TerraformLockFile = ".terraform.lock.hcl"
 
-	TerraformPlanFile     = "tfplan.tfplan"
-	TerraformPlanJSONFile = "tfplan.json"
+	TerraformPlanFile            = "tfplan.tfplan"
+	TerraformPlanJSONFile        = "tfplan.json"
+	TerraformPlanFingerprintFile = "tfplan.fingerprint.json"
 )
 
 var (
@@ -96,6 +97,17 @@ var (
 		CommandNameState,
 	}
 
+	// CommandNamesModifyingState are the commands that can change the state, and therefore the outputs, of a unit.
+	CommandNamesModifyingState = []string{
+		CommandNameApply,
+		CommandNameDestroy,
+		CommandNameImport,
+		CommandNameRefresh,
+		CommandNameState,
+		CommandNameTaint,
+		CommandNameUntaint,
+	}
+
 	CommandUsages = map[string]string{
 		CommandNameApply:       "Create or update infrastructure.",
 		CommandNameConsole:     "Try OpenTofu/Terraform expressions at an interactive command prompt.",